@@ -6,7 +6,9 @@ package govcd
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
@@ -60,3 +62,90 @@ func (adminOrg *AdminOrg) GetLdapConfiguration(ctx context.Context) (*types.OrgL
 
 	return ldapSettings, nil
 }
+
+// GetFederationSettings retrieves the SAML federation settings of the Org
+func (adminOrg *AdminOrg) GetFederationSettings(ctx context.Context) (*types.OrgFederationSettings, error) {
+	util.Logger.Printf("[DEBUG] Reading federation settings for Org name %s", adminOrg.AdminOrg.Name)
+
+	federationSettings := &types.OrgFederationSettings{}
+
+	href := adminOrg.AdminOrg.HREF + "/settings/federation"
+
+	_, err := adminOrg.client.ExecuteRequest(ctx, href, http.MethodGet, types.MimeOrgFederationSettings,
+		"error getting federation settings: %s", nil, federationSettings)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return federationSettings, nil
+}
+
+// SetFederationSettings configures SAML federation for the Org. settings.SAMLMetadata contains the
+// metadata XML of the identity provider and settings.SamlAttributeMapping the names of the SAML
+// attributes used for the user properties. SAML is enabled or disabled with settings.Enabled.
+func (adminOrg *AdminOrg) SetFederationSettings(ctx context.Context, settings *types.OrgFederationSettings) (*types.OrgFederationSettings, error) {
+	util.Logger.Printf("[DEBUG] Configuring federation settings for Org name %s", adminOrg.AdminOrg.Name)
+
+	if settings.SAMLMetadata != "" {
+		err := validateSamlIdpMetadata(settings.SAMLMetadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+	settings.Xmlns = types.XMLNamespaceVCloud
+
+	href := adminOrg.AdminOrg.HREF + "/settings/federation"
+	_, err := adminOrg.client.ExecuteRequest(ctx, href, http.MethodPut, types.MimeOrgFederationSettings,
+		"error updating federation settings: %s", settings, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error updating federation settings for Org name '%s': %s", adminOrg.AdminOrg.Name, err)
+	}
+
+	federationSettings, err := adminOrg.GetFederationSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving federation settings: %s", err)
+	}
+
+	return federationSettings, nil
+}
+
+// GetSamlMetadata retrieves the SAML service provider metadata of the Org, which needs to be
+// registered in the identity provider. The complete document is available in the Raw field.
+func (adminOrg *AdminOrg) GetSamlMetadata(ctx context.Context) (*types.VcdSamlMetadata, error) {
+	samlMetadataUrl := getSamlMetadataUrl(adminOrg.client, adminOrg.AdminOrg.Name)
+
+	resp, err := adminOrg.client.ExecuteRequestWithCustomError(ctx, samlMetadataUrl, http.MethodGet, "",
+		"error getting SAML metadata: %s", nil, &types.Error{})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SAML metadata: %s", err)
+	}
+
+	metadata := &types.VcdSamlMetadata{}
+	err = xml.Unmarshal(body, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding SAML metadata: %s", err)
+	}
+	metadata.Raw = string(body)
+
+	return metadata, nil
+}
+
+// validateSamlIdpMetadata checks that metadata is an XML SAML metadata document with an entity ID
+func validateSamlIdpMetadata(metadata string) error {
+	var entityDescriptor types.VcdSamlMetadata
+	err := xml.Unmarshal([]byte(metadata), &entityDescriptor)
+	if err != nil {
+		return fmt.Errorf("identity provider metadata is not a valid SAML metadata document: %s", err)
+	}
+	if entityDescriptor.EntityID == "" {
+		return fmt.Errorf("identity provider metadata has no entity ID")
+	}
+	return nil
+}
@@ -76,3 +76,41 @@ func (vcd *TestVCD) Test_LDAP_Configuration(check *C) {
 	check.Assert(ldapConfig.OrgLdapMode, Equals, types.LdapModeNone)
 
 }
+
+// Test_FederationSettings tests reading and updating SAML federation settings
+func (vcd *TestVCD) Test_FederationSettings(check *C) {
+	if vcd.skipAdminTests {
+		check.Skip(fmt.Sprintf(TestRequiresSysAdminPrivileges, check.TestName()))
+	}
+
+	ctx := context.Background()
+
+	org, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+
+	metadata, err := org.GetSamlMetadata(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(metadata.EntityID, Not(Equals), "")
+	check.Assert(metadata.Raw, Not(Equals), "")
+
+	originalSettings, err := org.GetFederationSettings(ctx)
+	check.Assert(err, IsNil)
+
+	// Set attribute mapping with SAML disabled, so that no identity provider is needed
+	newSettings := &types.OrgFederationSettings{
+		Enabled: false,
+		SamlAttributeMapping: &types.SamlAttributeMapping{
+			EmailAttributeName: "email",
+			GroupAttributeName: "groups",
+		},
+	}
+	settings, err := org.SetFederationSettings(ctx, newSettings)
+	check.Assert(err, IsNil)
+	check.Assert(settings.Enabled, Equals, false)
+	check.Assert(settings.SamlAttributeMapping, NotNil)
+	check.Assert(settings.SamlAttributeMapping.EmailAttributeName, Equals, "email")
+	check.Assert(settings.SamlAttributeMapping.GroupAttributeName, Equals, "groups")
+
+	_, err = org.SetFederationSettings(ctx, originalSettings)
+	check.Assert(err, IsNil)
+}
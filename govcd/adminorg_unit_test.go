@@ -117,3 +117,29 @@ func Test_equalIds(t *testing.T) {
 		}
 	}
 }
+
+func Test_validateSamlIdpMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		wantErr  bool
+	}{
+		{name: "Valid", metadata: `<?xml version="1.0" encoding="UTF-8"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com/saml">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`},
+		{name: "NoEntityId", metadata: `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata"></EntityDescriptor>`, wantErr: true},
+		{name: "OtherDocument", metadata: `<Envelope entityID="https://idp.example.com/saml"></Envelope>`, wantErr: true},
+		{name: "NotXml", metadata: `https://idp.example.com/saml/metadata`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSamlIdpMetadata(tt.metadata)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSamlIdpMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
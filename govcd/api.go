@@ -664,6 +664,11 @@ func takeIntAddress(x int) *int {
 	return &x
 }
 
+// takeInt64Address is a helper that returns the address of an `int64`
+func takeInt64Address(x int64) *int64 {
+	return &x
+}
+
 // takeStringPointer is a helper that returns the address of a `string`
 func takeStringPointer(x string) *string {
 	return &x
@@ -716,3 +721,13 @@ func BuildUrnWithUuid(urnPrefix, uuid string) (string, error) {
 func takeFloatAddress(x float64) *float64 {
 	return &x
 }
+
+// stringInSlice is a helper that returns true if `item` is one of the elements in `list`
+func stringInSlice(item string, list []string) bool {
+	for _, element := range list {
+		if element == item {
+			return true
+		}
+	}
+	return false
+}
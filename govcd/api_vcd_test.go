@@ -175,6 +175,12 @@ type TestConfig struct {
 			ExternalNetwork   string `yaml:"externalNetwork"`
 			EdgeGateway       string `yaml:"edgeGateway"`
 			NsxtImportSegment string `yaml:"nsxtImportSegment"`
+
+			NsxtAlbControllerUrl      string `yaml:"nsxtAlbControllerUrl"`
+			NsxtAlbControllerUser     string `yaml:"nsxtAlbControllerUser"`
+			NsxtAlbControllerPassword string `yaml:"nsxtAlbControllerPassword"`
+			NsxtAlbImportableCloud    string `yaml:"nsxtAlbImportableCloud"`
+			NsxtAlbServiceEngineGroup string `yaml:"nsxtAlbServiceEngineGroup"`
 		} `yaml:"nsxt"`
 	} `yaml:"vcd"`
 	Logging struct {
@@ -393,7 +399,6 @@ func AddToCleanupListOpenApi(name, createdBy, openApiEndpoint string) {
 
 // PrependToCleanupListOpenApi prepends an OpenAPI entity OpenApi objects `entityType=OpenApiEntity` and
 // `openApiEndpoint`should be set in format "types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointOrgVdcNetworks + ID"
-//lint:ignore U1000 Not yet used
 func PrependToCleanupListOpenApi(name, createdBy, openApiEndpoint string) {
 	for _, item := range cleanupEntityList {
 		// avoid adding the same item twice
@@ -1804,6 +1809,27 @@ func skipNoNsxtConfiguration(vcd *TestVCD, check *C) {
 	}
 }
 
+// skipNoNsxtAlbConfiguration is a helper to skip NSX-T ALB tests when ALB Controller details are not provided
+func skipNoNsxtAlbConfiguration(vcd *TestVCD, check *C) {
+	skipNoNsxtConfiguration(vcd, check)
+	generalMessage := "Missing NSX-T ALB config: "
+	if vcd.config.VCD.Nsxt.NsxtAlbControllerUrl == "" {
+		check.Skip(generalMessage + "No NSX-T ALB Controller URL specified")
+	}
+	if vcd.config.VCD.Nsxt.NsxtAlbControllerUser == "" {
+		check.Skip(generalMessage + "No NSX-T ALB Controller User specified")
+	}
+	if vcd.config.VCD.Nsxt.NsxtAlbControllerPassword == "" {
+		check.Skip(generalMessage + "No NSX-T ALB Controller Password specified")
+	}
+	if vcd.config.VCD.Nsxt.NsxtAlbImportableCloud == "" {
+		check.Skip(generalMessage + "No NSX-T ALB Importable Cloud specified")
+	}
+	if vcd.config.VCD.Nsxt.NsxtAlbServiceEngineGroup == "" {
+		check.Skip(generalMessage + "No NSX-T ALB Service Engine Group specified")
+	}
+}
+
 // skipOpenApiEndpointTest is a helper to skip tests for particular unsupported OpenAPI endpoints
 func skipOpenApiEndpointTest(ctx context.Context, vcd *TestVCD, check *C, endpoint string) {
 	minimumRequiredApiVersion := endpointMinApiVersions[endpoint]
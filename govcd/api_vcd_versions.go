@@ -47,6 +47,8 @@ var apiVersionToVcdVersion = map[string]string{
 	"33.0": "10.0",
 	"34.0": "10.1",
 	"35.0": "10.2", // Provisional version for non-GA release. It may change later
+	"36.0": "10.3",
+	"37.0": "10.4",
 }
 
 // vcdVersionToApiVersion gets the max supported API version from vCD version
@@ -58,6 +60,8 @@ var vcdVersionToApiVersion = map[string]string{
 	"10.0": "33.0",
 	"10.1": "34.0",
 	"10.2": "35.0", // Provisional version for non-GA release. It may change later
+	"10.3": "36.0",
+	"10.4": "37.0",
 }
 
 // to make vcdVersionToApiVersion used
@@ -7,6 +7,7 @@ package govcd
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -104,13 +105,33 @@ func (cat *Catalog) FindCatalogItem(ctx context.Context, catalogItemName string)
 	return CatalogItem{}, nil
 }
 
+// UploadSignedOva uploads an OVA file to a catalog like UploadOvf. The OVA must contain a manifest
+// listing all its files, and its signature must be made by a certificate which chains to one of the
+// certificates of trustPool. The extracted files are checked before contacting vCD, and the same
+// files are uploaded.
+func (cat *Catalog) UploadSignedOva(ctx context.Context, ovaFileName, itemName, description string, uploadPieceSize int64, trustPool *x509.CertPool) (UploadTask, error) {
+	if trustPool == nil {
+		return UploadTask{}, errors.New("a trust pool is required to check the signature of the OVA")
+	}
+	return cat.uploadOvf(ctx, ovaFileName, itemName, description, uploadPieceSize, trustPool)
+}
+
 // UploadOvf uploads an ova/ovf file to a catalog. This method only uploads bits to vCD spool area.
 // ovaFileName should be the path of OVA or OVF file(not ovf folder) itself. For OVF,
 // user need to make sure all the files that OVF depends on exist and locate under the same folder.
+// When an OVA contains a manifest, or when an OVF has a manifest with the same base name next to it,
+// the files are checked against its digests before contacting vCD. Use UploadSignedOva to also
+// check the signature of an OVA.
 // Returns errors if any occur during upload from vCD or upload process. On upload fail client may need to
 // remove vCD catalog item which waits for files to be uploaded. Files from ova are extracted to system
 // temp folder "govcd+random number" and left for inspection on error.
 func (cat *Catalog) UploadOvf(ctx context.Context, ovaFileName, itemName, description string, uploadPieceSize int64) (UploadTask, error) {
+	return cat.uploadOvf(ctx, ovaFileName, itemName, description, uploadPieceSize, nil)
+}
+
+// uploadOvf uploads an ova/ovf file to a catalog. When trustPool is not nil, the file must be an OVA
+// whose signature is checked on the extracted files.
+func (cat *Catalog) uploadOvf(ctx context.Context, ovaFileName, itemName, description string, uploadPieceSize int64, trustPool *x509.CertPool) (UploadTask, error) {
 
 	//	On a very high level the flow is as follows
 	//	1. Makes a POST call to vCD to create the catalog item (also creates a transfer folder in the spool area and as result will give a sparse catalog item resource XML).
@@ -141,6 +162,9 @@ func (cat *Catalog) UploadOvf(ctx context.Context, ovaFileName, itemName, descri
 	if strings.Contains(fileContentType, "text/xml") {
 		isOvf = true
 	}
+	if isOvf && trustPool != nil {
+		return UploadTask{}, fmt.Errorf("only OVA files can be checked for signature: %s is an OVF descriptor", ovaFileName)
+	}
 	ovfFilePath := ovaFileName
 	tmpDir := path.Dir(ovaFileName)
 	filesAbsPaths := []string{ovfFilePath}
@@ -165,6 +189,14 @@ func (cat *Catalog) UploadOvf(ctx context.Context, ovaFileName, itemName, descri
 		if err != nil {
 			return UploadTask{}, fmt.Errorf("%s. Unpacked files for checking are accessible in: %s", err, tmpDir)
 		}
+		if trustPool != nil {
+			err = util.VerifySignedUnpackedOva(tmpDir, filesAbsPaths, trustPool)
+		} else {
+			err = util.VerifyUnpackedOva(tmpDir, filesAbsPaths)
+		}
+		if err != nil {
+			return UploadTask{}, fmt.Errorf("%s. Unpacked files for checking are accessible in: %s", err, tmpDir)
+		}
 	} else {
 		dir := path.Dir(ovfFilePath)
 		for _, fileItem := range ovfFileDesc.File {
@@ -175,6 +207,10 @@ func (cat *Catalog) UploadOvf(ctx context.Context, ovaFileName, itemName, descri
 			}
 			filesAbsPaths = append(filesAbsPaths, dependFile)
 		}
+		err = verifyOvfManifest(ovfFilePath, filesAbsPaths)
+		if err != nil {
+			return UploadTask{}, err
+		}
 	}
 
 	catalogItemUploadURL, err := findCatalogItemUploadLink(cat, "application/vnd.vmware.vcloud.uploadVAppTemplateParams+xml")
@@ -454,6 +490,22 @@ func findCatalogItemUploadLink(catalog *Catalog, applicationType string) (*url.U
 	return nil, errors.New("catalog upload URL not found")
 }
 
+// verifyOvfManifest checks the OVF descriptor and the files it references against the manifest
+// which has the same base name as the descriptor, if there is one
+func verifyOvfManifest(ovfFilePath string, filesAbsPaths []string) error {
+	manifestPath := strings.TrimSuffix(ovfFilePath, filepath.Ext(ovfFilePath)) + ".mf"
+	_, err := os.Stat(manifestPath)
+	if os.IsNotExist(err) {
+		util.Logger.Printf("[TRACE] no manifest found for OVF %s\n", ovfFilePath)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	paths := append([]string{manifestPath}, filesAbsPaths...)
+	return util.VerifyUnpackedOva(path.Dir(ovfFilePath), paths)
+}
+
 func getExistingCatalogItems(catalog *Catalog) (catalogItemNames []string) {
 	for _, catalogItems := range catalog.Catalog.CatalogItems {
 		for _, catalogItem := range catalogItems.CatalogItem {
@@ -854,3 +906,76 @@ func getOrgInfo(ctx context.Context, client *Client, links types.LinkList, id, n
 func (catalog *Catalog) getOrgInfo(ctx context.Context) (orgInfoType, error) {
 	return getOrgInfo(ctx, catalog.client, catalog.Catalog.Link, catalog.Catalog.ID, catalog.Catalog.Name, "Catalog")
 }
+
+// CaptureVApp captures vapp into the catalog as a new vApp template named itemName and waits until
+// the resulting catalog item is available. When customizeOnInstantiate is true, VMs instantiated
+// from the template will be customized (guest customization) on first power on.
+// The vApp should be powered off to get a consistent template.
+func (cat *Catalog) CaptureVApp(ctx context.Context, vapp *VApp, itemName, description string, customizeOnInstantiate bool) (*CatalogItem, error) {
+	if vapp == nil || vapp.VApp == nil || vapp.VApp.HREF == "" {
+		return nil, fmt.Errorf("vApp to capture must be provided")
+	}
+	if itemName == "" {
+		return nil, fmt.Errorf("catalog item name must be provided")
+	}
+
+	_, err := cat.GetCatalogItemByName(ctx, itemName, true)
+	if err == nil {
+		return nil, fmt.Errorf("catalog item '%s' already exists in catalog %s", itemName, cat.Catalog.Name)
+	}
+	if !ContainsNotFound(err) {
+		return nil, err
+	}
+
+	captureParams := newCaptureVAppParams(vapp, itemName, description, customizeOnInstantiate)
+	err = captureVAppIntoCatalog(ctx, cat.client, cat.Catalog.HREF, captureParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return cat.GetCatalogItemByName(ctx, itemName, true)
+}
+
+// newCaptureVAppParams builds the payload for a captureVApp request
+func newCaptureVAppParams(vapp *VApp, itemName, description string, customizeOnInstantiate bool) *types.CaptureVAppParams {
+	return &types.CaptureVAppParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Ovf:         types.XMLNamespaceOVF,
+		Name:        itemName,
+		Description: description,
+		Source:      &types.Reference{HREF: vapp.VApp.HREF},
+		CustomizationSection: &types.CustomizationSection{
+			Info:                   "VApp template customization section",
+			CustomizeOnInstantiate: customizeOnInstantiate,
+		},
+	}
+}
+
+// captureVAppIntoCatalog sends a captureVApp request to the catalog with the given HREF and waits
+// for the tasks of the resulting vApp template
+func captureVAppIntoCatalog(ctx context.Context, client *Client, catalogHref string, captureParams *types.CaptureVAppParams) error {
+	captureHref, err := url.ParseRequestURI(catalogHref)
+	if err != nil {
+		return fmt.Errorf("error parsing catalog HREF: %s", err)
+	}
+	captureHref.Path += "/action/captureVApp"
+
+	vappTemplate := &types.VAppTemplate{}
+	_, err = client.ExecuteRequest(ctx, captureHref.String(), http.MethodPost,
+		types.MimeCaptureVappParams, "error capturing vApp: %s", captureParams, vappTemplate)
+	if err != nil {
+		return err
+	}
+
+	if vappTemplate.Tasks != nil {
+		task := NewTask(client)
+		for _, taskItem := range vappTemplate.Tasks.Task {
+			task.Task = taskItem
+			err = task.WaitTaskCompletion(ctx)
+			if err != nil {
+				return fmt.Errorf("error capturing vApp into template %s: %s", captureParams.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// CopyItemFrom copies sourceItem, which can be a vApp template or a media, into the catalog with
+// the name newName (or the name of the source item if newName is empty) and waits for the copy to
+// complete. The metadata of the source item and of its vApp template or media are kept.
+func (cat *Catalog) CopyItemFrom(ctx context.Context, sourceItem *CatalogItem, newName string) (*CatalogItem, error) {
+	if newName == "" {
+		newName = sourceItem.CatalogItem.Name
+	}
+	return cat.copyOrMoveItem(ctx, sourceItem, newName, types.RelCopy)
+}
+
+// MoveItemFrom moves sourceItem, which can be a vApp template or a media, into the catalog and
+// waits for the move to complete. The item keeps its name and metadata.
+func (cat *Catalog) MoveItemFrom(ctx context.Context, sourceItem *CatalogItem) (*CatalogItem, error) {
+	return cat.copyOrMoveItem(ctx, sourceItem, sourceItem.CatalogItem.Name, types.RelMove)
+}
+
+// CopyCatalogItem copies sourceItem into the catalog targetCatalogName of the organization
+// targetOrgName, which can differ from the organization of the source item.
+// Only system administrators can copy items across organizations.
+func (vcdClient *VCDClient) CopyCatalogItem(ctx context.Context, sourceItem *CatalogItem, targetOrgName, targetCatalogName, newName string) (*CatalogItem, error) {
+	targetCatalog, err := vcdClient.getCatalogForItemTransfer(ctx, targetOrgName, targetCatalogName)
+	if err != nil {
+		return nil, err
+	}
+	return targetCatalog.CopyItemFrom(ctx, sourceItem, newName)
+}
+
+// MoveCatalogItem moves sourceItem into the catalog targetCatalogName of the organization
+// targetOrgName, which can differ from the organization of the source item.
+// Only system administrators can move items across organizations.
+func (vcdClient *VCDClient) MoveCatalogItem(ctx context.Context, sourceItem *CatalogItem, targetOrgName, targetCatalogName string) (*CatalogItem, error) {
+	targetCatalog, err := vcdClient.getCatalogForItemTransfer(ctx, targetOrgName, targetCatalogName)
+	if err != nil {
+		return nil, err
+	}
+	return targetCatalog.MoveItemFrom(ctx, sourceItem)
+}
+
+// getCatalogForItemTransfer retrieves the destination catalog of a cross-organization copy or move
+func (vcdClient *VCDClient) getCatalogForItemTransfer(ctx context.Context, orgName, catalogName string) (*Catalog, error) {
+	if !vcdClient.Client.IsSysAdmin {
+		return nil, fmt.Errorf("copying or moving catalog items across organizations requires System user")
+	}
+
+	adminOrg, err := vcdClient.GetAdminOrgByName(ctx, orgName)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving organization %s: %s", orgName, err)
+	}
+	return adminOrg.GetCatalogByName(ctx, catalogName, true)
+}
+
+// copyOrMoveItem runs the catalog action (RelCopy or RelMove) for sourceItem, waits for its task and
+// restores the metadata of the source item which was not carried over
+func (cat *Catalog) copyOrMoveItem(ctx context.Context, sourceItem *CatalogItem, newName, action string) (*CatalogItem, error) {
+	if sourceItem == nil || sourceItem.CatalogItem == nil || sourceItem.CatalogItem.HREF == "" {
+		return nil, fmt.Errorf("source catalog item must be provided")
+	}
+	if sourceItem.CatalogItem.Entity == nil {
+		return nil, fmt.Errorf("catalog item %s has no entity", sourceItem.CatalogItem.Name)
+	}
+
+	_, err := cat.GetCatalogItemByName(ctx, newName, true)
+	if err == nil {
+		return nil, fmt.Errorf("catalog item '%s' already exists in catalog %s", newName, cat.Catalog.Name)
+	}
+	if !ContainsNotFound(err) {
+		return nil, err
+	}
+
+	// The source item no longer exists after a move, so its metadata is collected beforehand
+	itemMetadata, err := getMetadata(ctx, cat.client, sourceItem.CatalogItem.HREF)
+	if err != nil {
+		return nil, err
+	}
+	entityMetadata, err := getMetadata(ctx, cat.client, sourceItem.CatalogItem.Entity.HREF)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &types.CopyOrMoveCatalogItemParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        newName,
+		Description: sourceItem.CatalogItem.Description,
+		Source:      &types.Reference{HREF: sourceItem.CatalogItem.HREF},
+	}
+	actionHref := getActionHref(cat.Catalog.Link, cat.Catalog.HREF, action)
+	task, err := cat.client.ExecuteTaskRequest(ctx, actionHref, http.MethodPost,
+		types.MimeCopyOrMoveCatalogItemParams, "error running "+action+" of catalog item: %s", params)
+	if err != nil {
+		return nil, err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error running %s of catalog item %s into catalog %s: %s", action,
+			sourceItem.CatalogItem.Name, cat.Catalog.Name, err)
+	}
+
+	newItem, err := cat.GetCatalogItemByName(ctx, newName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = restoreMetadata(ctx, cat.client, itemMetadata, newItem.CatalogItem.HREF)
+	if err != nil {
+		return newItem, err
+	}
+	err = restoreMetadata(ctx, cat.client, entityMetadata, newItem.CatalogItem.Entity.HREF)
+	if err != nil {
+		return newItem, err
+	}
+	return newItem, nil
+}
+
+// restoreMetadata adds to the entity with the given HREF the entries of sourceMetadata which it
+// doesn't have yet
+func restoreMetadata(ctx context.Context, client *Client, sourceMetadata *types.Metadata, href string) error {
+	targetMetadata, err := getMetadata(ctx, client, href)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range missingMetadataEntries(sourceMetadata, targetMetadata) {
+		metadataValue := &types.MetadataValue{
+			Xmlns:      types.XMLNamespaceVCloud,
+			Xsi:        types.XMLNamespaceXSI,
+			TypedValue: entry.TypedValue,
+		}
+
+		apiEndpoint, err := url.ParseRequestURI(href)
+		if err != nil {
+			return err
+		}
+		apiEndpoint.Path += "/metadata/" + entry.Key
+
+		task, err := client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPut,
+			types.MimeMetaDataValue, "error adding metadata: %s", metadataValue)
+		if err != nil {
+			return err
+		}
+		err = task.WaitTaskCompletion(ctx)
+		if err != nil {
+			return fmt.Errorf("error restoring metadata '%s': %s", entry.Key, err)
+		}
+	}
+	return nil
+}
+
+// getActionHref returns the HREF of an action of an entity (e.g. "copy"), using the link with the
+// same relation when available. Catalog actions are only exposed by the non-admin view of the entity.
+func getActionHref(links types.LinkList, href, action string) string {
+	for _, link := range links {
+		if link.Rel == action {
+			return link.HREF
+		}
+	}
+	return strings.Replace(href, "/api/admin/", "/api/", 1) + "/action/" + action
+}
+
+// missingMetadataEntries returns the entries of source which are not in target. Entries of the
+// SYSTEM domain are managed by VCD and are ignored.
+func missingMetadataEntries(source, target *types.Metadata) []*types.MetadataEntry {
+	if source == nil {
+		return nil
+	}
+
+	existing := make(map[string]bool)
+	if target != nil {
+		for _, entry := range target.MetadataEntry {
+			existing[entry.Key] = true
+		}
+	}
+
+	var missing []*types.MetadataEntry
+	for _, entry := range source.MetadataEntry {
+		if entry.Domain == "SYSTEM" || entry.TypedValue == nil || existing[entry.Key] {
+			continue
+		}
+		missing = append(missing, entry)
+	}
+	return missing
+}
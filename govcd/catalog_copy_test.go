@@ -0,0 +1,106 @@
+// +build catalog functional ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+
+	. "gopkg.in/check.v1"
+)
+
+// Test_CatalogCopyAndMoveItem uploads a media with metadata into a staging catalog, then copies and
+// moves it into a production catalog, checking that the metadata is kept
+func (vcd *TestVCD) Test_CatalogCopyAndMoveItem(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+	skipWhenMediaPathMissing(vcd, check)
+	ctx := context.Background()
+
+	org, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+
+	stagingName := check.TestName() + "-staging"
+	productionName := check.TestName() + "-production"
+	mediaName := check.TestName() + "-media"
+	copyName := check.TestName() + "-copy"
+
+	var catalogs []*Catalog
+	for _, catalogName := range []string{stagingName, productionName} {
+		adminCatalog, err := org.CreateCatalogWithStorageProfile(ctx, catalogName, catalogName, nil)
+		check.Assert(err, IsNil)
+		AddToCleanupList(catalogName, "catalog", vcd.config.VCD.Org, check.TestName())
+		task := NewTask(&vcd.client.Client)
+		task.Task = adminCatalog.AdminCatalog.Tasks.Task[0]
+		err = task.WaitTaskCompletion(ctx)
+		check.Assert(err, IsNil)
+
+		catalog, err := org.GetCatalogByName(ctx, catalogName, true)
+		check.Assert(err, IsNil)
+		catalogs = append(catalogs, catalog)
+	}
+	staging, production := catalogs[0], catalogs[1]
+
+	uploadTask, err := staging.UploadMediaImage(ctx, mediaName, "upload from test", vcd.config.Media.MediaPath, 1024)
+	check.Assert(err, IsNil)
+	err = uploadTask.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+
+	media, err := staging.GetMediaByName(ctx, mediaName, true)
+	check.Assert(err, IsNil)
+	_, err = media.AddMetadata(ctx, "stage", "approved")
+	check.Assert(err, IsNil)
+
+	sourceItem, err := staging.GetCatalogItemByName(ctx, mediaName, true)
+	check.Assert(err, IsNil)
+
+	copiedItem, err := production.CopyItemFrom(ctx, sourceItem, copyName)
+	check.Assert(err, IsNil)
+	check.Assert(copiedItem.CatalogItem.Name, Equals, copyName)
+	checkMediaMetadata(ctx, check, production, copyName, "stage", "approved")
+
+	// The item can't be copied twice with the same name
+	_, err = production.CopyItemFrom(ctx, sourceItem, copyName)
+	check.Assert(err, NotNil)
+
+	movedItem, err := production.MoveItemFrom(ctx, sourceItem)
+	check.Assert(err, IsNil)
+	check.Assert(movedItem.CatalogItem.Name, Equals, mediaName)
+	checkMediaMetadata(ctx, check, production, mediaName, "stage", "approved")
+
+	_, err = staging.GetCatalogItemByName(ctx, mediaName, true)
+	check.Assert(ContainsNotFound(err), Equals, true)
+
+	if !vcd.skipAdminTests {
+		crossOrgName := check.TestName() + "-cross-org"
+		crossOrgItem, err := vcd.client.CopyCatalogItem(ctx, copiedItem, vcd.config.VCD.Org, stagingName, crossOrgName)
+		check.Assert(err, IsNil)
+		check.Assert(crossOrgItem.CatalogItem.Name, Equals, crossOrgName)
+		checkMediaMetadata(ctx, check, staging, crossOrgName, "stage", "approved")
+	}
+
+	for _, catalog := range catalogs {
+		err = catalog.Delete(ctx, true, true)
+		check.Assert(err, IsNil)
+	}
+}
+
+// checkMediaMetadata checks that the media mediaName of catalog has the metadata entry key=value
+func checkMediaMetadata(ctx context.Context, check *C, catalog *Catalog, mediaName, key, value string) {
+	media, err := catalog.GetMediaByName(ctx, mediaName, true)
+	check.Assert(err, IsNil)
+	metadata, err := media.GetMetadata(ctx)
+	check.Assert(err, IsNil)
+
+	found := false
+	for _, entry := range metadata.MetadataEntry {
+		if entry.Key == key {
+			check.Assert(entry.TypedValue.Value, Equals, value)
+			found = true
+		}
+	}
+	check.Assert(found, Equals, true)
+}
@@ -0,0 +1,101 @@
+// +build unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_missingMetadataEntries(t *testing.T) {
+	stage := &types.MetadataEntry{Key: "stage", TypedValue: &types.TypedValue{XsiType: "MetadataStringValue", Value: "approved"}}
+	size := &types.MetadataEntry{Key: "size", TypedValue: &types.TypedValue{XsiType: "MetadataNumberValue", Value: "10"}}
+	system := &types.MetadataEntry{Key: "owner", Domain: "SYSTEM", TypedValue: &types.TypedValue{XsiType: "MetadataStringValue", Value: "ops"}}
+	noValue := &types.MetadataEntry{Key: "empty"}
+
+	tests := []struct {
+		name   string
+		source *types.Metadata
+		target *types.Metadata
+		want   []*types.MetadataEntry
+	}{
+		{
+			name:   "NoSource",
+			target: &types.Metadata{MetadataEntry: []*types.MetadataEntry{stage}},
+			want:   nil,
+		},
+		{
+			name:   "NoTarget",
+			source: &types.Metadata{MetadataEntry: []*types.MetadataEntry{stage, size}},
+			want:   []*types.MetadataEntry{stage, size},
+		},
+		{
+			name:   "AlreadyCopied",
+			source: &types.Metadata{MetadataEntry: []*types.MetadataEntry{stage, size}},
+			target: &types.Metadata{MetadataEntry: []*types.MetadataEntry{stage}},
+			want:   []*types.MetadataEntry{size},
+		},
+		{
+			name:   "SystemAndEmptyEntries",
+			source: &types.Metadata{MetadataEntry: []*types.MetadataEntry{system, noValue, stage}},
+			target: &types.Metadata{},
+			want:   []*types.MetadataEntry{stage},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missingMetadataEntries(tt.source, tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missingMetadataEntries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getActionHref(t *testing.T) {
+	tests := []struct {
+		name   string
+		links  types.LinkList
+		href   string
+		action string
+		want   string
+	}{
+		{
+			name: "ActionLink",
+			links: types.LinkList{
+				{Rel: types.RelUp, HREF: "https://vcd.example.com/api/admin/org/1"},
+				{Rel: types.RelCopy, HREF: "https://vcd.example.com/api/catalog/1/action/copy"},
+			},
+			href:   "https://vcd.example.com/api/admin/catalog/1",
+			action: types.RelCopy,
+			want:   "https://vcd.example.com/api/catalog/1/action/copy",
+		},
+		{
+			name: "OtherLink",
+			links: types.LinkList{
+				{Rel: types.RelCopy, HREF: "https://vcd.example.com/api/catalog/1/action/copy"},
+			},
+			href:   "https://vcd.example.com/api/catalog/1",
+			action: types.RelMove,
+			want:   "https://vcd.example.com/api/catalog/1/action/move",
+		},
+		{
+			name:   "AdminHref",
+			href:   "https://vcd.example.com/api/admin/catalog/1",
+			action: types.RelMove,
+			want:   "https://vcd.example.com/api/catalog/1/action/move",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getActionHref(tt.links, tt.href, tt.action); got != tt.want {
+				t.Errorf("getActionHref() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// Types of the items reported by AdminCatalog.GetSyncStatus
+const (
+	CatalogItemTypeVappTemplate = "vAppTemplate"
+	CatalogItemTypeMedia        = "media"
+)
+
+// catalogItemStatusResolved is the status of a vApp template or media which is fully available
+const catalogItemStatusResolved = "RESOLVED"
+
+// CatalogItemSyncStatus describes the synchronization state of an item of a catalog, as returned by
+// AdminCatalog.GetSyncStatus
+type CatalogItemSyncStatus struct {
+	Name               string
+	Type               string // CatalogItemTypeVappTemplate or CatalogItemTypeMedia
+	Href               string
+	Status             string
+	IsBusy             bool
+	LastSuccessfulSync string // Empty if the item was never synchronized
+	// InSync is true when the item is fully available locally and no operation is running on it
+	InSync bool
+}
+
+// PublishExternally publishes the catalog to external organizations, which can subscribe to it
+// using the URL reported in AdminCatalog.PublishExternalCatalogParams.CatalogPublishedUrl.
+// An empty password leaves the catalog without password protection. When cacheEnabled is true,
+// the content of the catalog is pre-exported to speed up the synchronization of subscribers.
+func (adminCatalog *AdminCatalog) PublishExternally(ctx context.Context, password string, cacheEnabled bool) error {
+	publishParams := &types.PublishExternalCatalogParams{
+		Xmlns:                 types.XMLNamespaceVCloud,
+		IsPublishedExternally: true,
+		Password:              password,
+		IsCachedEnabled:       cacheEnabled,
+	}
+
+	publishHref := adminCatalog.AdminCatalog.HREF + "/action/" + types.RelPublishExternal
+	err := adminCatalog.client.ExecuteRequestWithoutResponse(ctx, publishHref, http.MethodPost,
+		types.MimePublishExternalCatalogParams, "error publishing catalog externally: %s", publishParams)
+	if err != nil {
+		return err
+	}
+
+	return adminCatalog.Refresh(ctx)
+}
+
+// IsSubscribed returns true when the catalog is subscribed to an external catalog
+func (adminCatalog *AdminCatalog) IsSubscribed() bool {
+	subscription := adminCatalog.AdminCatalog.ExternalCatalogSubscription
+	return subscription != nil && subscription.SubscribeToExternalFeeds
+}
+
+// CreateSubscribedCatalog creates a catalog subscribed to the externally published catalog
+// available at subscriptionUrl. When autoDownload is true, the content of the remote catalog is
+// downloaded automatically. Otherwise, only the item definitions are retrieved and each item must be
+// synchronized explicitly (see CatalogItem.Sync).
+// The function waits for the catalog creation to complete.
+func (adminOrg *AdminOrg) CreateSubscribedCatalog(ctx context.Context, name, subscriptionUrl, password string, autoDownload bool) (*AdminCatalog, error) {
+	if subscriptionUrl == "" {
+		return nil, fmt.Errorf("subscription URL must be provided to create subscribed catalog %s", name)
+	}
+
+	createLink := adminOrg.AdminOrg.Link.ForType(types.MimeAdminCatalog, types.RelAdd)
+	if createLink == nil {
+		return nil, fmt.Errorf("creating catalog failed to find url")
+	}
+
+	catalogDefinition := &types.AdminCatalog{
+		Xmlns:   types.XMLNamespaceVCloud,
+		Catalog: types.Catalog{Name: name},
+		ExternalCatalogSubscription: &types.ExternalCatalogSubscription{
+			SubscribeToExternalFeeds: true,
+			Location:                 subscriptionUrl,
+			Password:                 password,
+			LocalCopy:                autoDownload,
+		},
+	}
+
+	adminCatalog := NewAdminCatalog(adminOrg.client)
+	_, err := adminOrg.client.ExecuteRequest(ctx, createLink.HREF, http.MethodPost, types.MimeAdminCatalog,
+		"error creating subscribed catalog: %s", catalogDefinition, adminCatalog.AdminCatalog)
+	if err != nil {
+		return nil, err
+	}
+
+	if adminCatalog.AdminCatalog.Tasks != nil {
+		for _, taskInProgress := range adminCatalog.AdminCatalog.Tasks.Task {
+			task := NewTask(adminOrg.client)
+			task.Task = taskInProgress
+			err = task.WaitTaskCompletion(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("error creating subscribed catalog %s: %s", name, err)
+			}
+		}
+	}
+
+	err = adminCatalog.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return adminCatalog, nil
+}
+
+// Sync synchronizes a subscribed catalog with its external source and waits for the operation to
+// complete. Items of catalogs which were not created with automatic download only get their
+// definition updated.
+func (adminCatalog *AdminCatalog) Sync(ctx context.Context) error {
+	if !adminCatalog.IsSubscribed() {
+		return fmt.Errorf("catalog %s is not subscribed to an external catalog", adminCatalog.AdminCatalog.Name)
+	}
+
+	syncHref := getSyncHref(adminCatalog.AdminCatalog.Link, adminCatalog.AdminCatalog.HREF)
+	task, err := adminCatalog.client.ExecuteTaskRequest(ctx, syncHref, http.MethodPost,
+		"", "error synchronizing catalog: %s", nil)
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return fmt.Errorf("error synchronizing catalog %s: %s", adminCatalog.AdminCatalog.Name, err)
+	}
+
+	return adminCatalog.Refresh(ctx)
+}
+
+// Sync synchronizes the item of a subscribed catalog with its external source, downloading its
+// content, and waits for the operation to complete
+func (catalogItem *CatalogItem) Sync(ctx context.Context) error {
+	syncHref := getSyncHref(catalogItem.CatalogItem.Link, catalogItem.CatalogItem.HREF)
+	task, err := catalogItem.client.ExecuteTaskRequest(ctx, syncHref, http.MethodPost,
+		"", "error synchronizing catalog item: %s", nil)
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return fmt.Errorf("error synchronizing catalog item %s: %s", catalogItem.CatalogItem.Name, err)
+	}
+	return nil
+}
+
+// GetSyncStatus returns the synchronization state of the vApp templates and media of the catalog,
+// sorted by name
+func (adminCatalog *AdminCatalog) GetSyncStatus(ctx context.Context) ([]CatalogItemSyncStatus, error) {
+	// Items are filtered by catalog reference, as catalogs of different organizations can have the same name
+	catalogType := adminCatalog.AdminCatalog.Catalog
+	catalogType.HREF = strings.Replace(catalogType.HREF, "/api/admin/", "/api/", 1)
+
+	vappTemplates, err := queryVappTemplateList(ctx, adminCatalog.client, "catalog", catalogType.HREF)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := NewCatalog(adminCatalog.client)
+	catalog.Catalog = &catalogType
+	mediaRecords, err := catalog.QueryMediaList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCatalogSyncStatus(vappTemplates, mediaRecords), nil
+}
+
+// GetItemsOutOfSync returns the items of the catalog which are not in sync with their source
+func (adminCatalog *AdminCatalog) GetItemsOutOfSync(ctx context.Context) ([]CatalogItemSyncStatus, error) {
+	statuses, err := adminCatalog.GetSyncStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var outOfSync []CatalogItemSyncStatus
+	for _, status := range statuses {
+		if !status.InSync {
+			util.Logger.Printf("[TRACE] catalog item %s (%s) is out of sync: status %s, busy %t",
+				status.Name, status.Type, status.Status, status.IsBusy)
+			outOfSync = append(outOfSync, status)
+		}
+	}
+	return outOfSync, nil
+}
+
+// buildCatalogSyncStatus combines the query records of vApp templates and media into a list of
+// synchronization statuses, sorted by name and type
+func buildCatalogSyncStatus(vappTemplates []*types.QueryResultVappTemplateType, mediaRecords []*types.MediaRecordType) []CatalogItemSyncStatus {
+	var statuses []CatalogItemSyncStatus
+	for _, vappTemplate := range vappTemplates {
+		statuses = append(statuses, CatalogItemSyncStatus{
+			Name:               vappTemplate.Name,
+			Type:               CatalogItemTypeVappTemplate,
+			Href:               vappTemplate.HREF,
+			Status:             vappTemplate.Status,
+			IsBusy:             vappTemplate.IsBusy,
+			LastSuccessfulSync: vappTemplate.LastSuccessfulSync,
+			InSync:             vappTemplate.Status == catalogItemStatusResolved && !vappTemplate.IsBusy,
+		})
+	}
+	for _, media := range mediaRecords {
+		statuses = append(statuses, CatalogItemSyncStatus{
+			Name:               media.Name,
+			Type:               CatalogItemTypeMedia,
+			Href:               media.HREF,
+			Status:             media.Status,
+			IsBusy:             media.IsBusy,
+			LastSuccessfulSync: media.LastSuccessfulSync,
+			InSync:             media.Status == catalogItemStatusResolved && !media.IsBusy,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Name == statuses[j].Name {
+			return statuses[i].Type < statuses[j].Type
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// getSyncHref returns the HREF of the sync action of an entity, using its "sync" link when
+// available. The action is only exposed by the non-admin view of the entity.
+func getSyncHref(links types.LinkList, href string) string {
+	for _, link := range links {
+		if link.Rel == types.RelSync {
+			return link.HREF
+		}
+	}
+	return strings.Replace(href, "/api/admin/", "/api/", 1) + "/action/" + types.RelSync
+}
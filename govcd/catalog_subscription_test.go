@@ -0,0 +1,88 @@
+// +build catalog functional ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+
+	. "gopkg.in/check.v1"
+)
+
+// Test_CatalogPublishAndSubscribe publishes a catalog externally, subscribes to it from a second
+// catalog of the same organization, and synchronizes the subscribed catalog and its items
+func (vcd *TestVCD) Test_CatalogPublishAndSubscribe(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+	skipWhenMediaPathMissing(vcd, check)
+	ctx := context.Background()
+
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+
+	publisherName := check.TestName() + "-publisher"
+	subscriberName := check.TestName() + "-subscriber"
+	mediaName := check.TestName() + "-media"
+
+	publisher, err := adminOrg.CreateCatalogWithStorageProfile(ctx, publisherName, publisherName, nil)
+	check.Assert(err, IsNil)
+	AddToCleanupList(publisherName, "catalog", vcd.config.VCD.Org, check.TestName())
+	task := NewTask(&vcd.client.Client)
+	task.Task = publisher.AdminCatalog.Tasks.Task[0]
+	err = task.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+
+	catalog := NewCatalog(&vcd.client.Client)
+	catalog.Catalog = &publisher.AdminCatalog.Catalog
+	uploadTask, err := catalog.UploadMediaImage(ctx, mediaName, "upload from test", vcd.config.Media.MediaPath, 1024)
+	check.Assert(err, IsNil)
+	err = uploadTask.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+
+	err = publisher.PublishExternally(ctx, "", false)
+	check.Assert(err, IsNil)
+	check.Assert(publisher.AdminCatalog.PublishExternalCatalogParams, NotNil)
+	check.Assert(publisher.AdminCatalog.PublishExternalCatalogParams.IsPublishedExternally, Equals, true)
+	publishedUrl := publisher.AdminCatalog.PublishExternalCatalogParams.CatalogPublishedUrl
+	check.Assert(publishedUrl, Not(Equals), "")
+
+	// Without automatic download, the subscribed items are only downloaded on demand
+	subscriber, err := adminOrg.CreateSubscribedCatalog(ctx, subscriberName, publishedUrl, "", false)
+	check.Assert(err, IsNil)
+	AddToCleanupList(subscriberName, "catalog", vcd.config.VCD.Org, check.TestName())
+	check.Assert(subscriber.IsSubscribed(), Equals, true)
+	check.Assert(subscriber.AdminCatalog.ExternalCatalogSubscription.Location, Equals, publishedUrl)
+
+	err = subscriber.Sync(ctx)
+	check.Assert(err, IsNil)
+
+	subscribedCatalog, err := adminOrg.GetCatalogByName(ctx, subscriberName, true)
+	check.Assert(err, IsNil)
+	catalogItem, err := subscribedCatalog.GetCatalogItemByName(ctx, mediaName, true)
+	check.Assert(err, IsNil)
+	err = catalogItem.Sync(ctx)
+	check.Assert(err, IsNil)
+
+	statuses, err := subscriber.GetSyncStatus(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(len(statuses), Equals, 1)
+	check.Assert(statuses[0].Name, Equals, mediaName)
+	check.Assert(statuses[0].Type, Equals, CatalogItemTypeMedia)
+	check.Assert(statuses[0].InSync, Equals, true)
+
+	outOfSync, err := subscriber.GetItemsOutOfSync(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(len(outOfSync), Equals, 0)
+
+	// A catalog which is not subscribed can't be synchronized
+	err = publisher.Sync(ctx)
+	check.Assert(err, NotNil)
+
+	err = subscriber.Delete(ctx, true, true)
+	check.Assert(err, IsNil)
+	err = publisher.Delete(ctx, true, true)
+	check.Assert(err, IsNil)
+}
@@ -0,0 +1,79 @@
+// +build unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_buildCatalogSyncStatus(t *testing.T) {
+	vappTemplates := []*types.QueryResultVappTemplateType{
+		{Name: "photon", HREF: "https://vcd.example.com/api/vAppTemplate/vappTemplate-1", Status: "RESOLVED",
+			LastSuccessfulSync: "2021-03-01T10:00:00.000Z"},
+		{Name: "centos", HREF: "https://vcd.example.com/api/vAppTemplate/vappTemplate-2", Status: "RESOLVED",
+			IsBusy: true},
+	}
+	mediaRecords := []*types.MediaRecordType{
+		{Name: "photon", HREF: "https://vcd.example.com/api/media/1", Status: "UNRESOLVED"},
+	}
+
+	expected := []CatalogItemSyncStatus{
+		{Name: "centos", Type: CatalogItemTypeVappTemplate, Href: "https://vcd.example.com/api/vAppTemplate/vappTemplate-2",
+			Status: "RESOLVED", IsBusy: true},
+		{Name: "photon", Type: CatalogItemTypeMedia, Href: "https://vcd.example.com/api/media/1", Status: "UNRESOLVED"},
+		{Name: "photon", Type: CatalogItemTypeVappTemplate, Href: "https://vcd.example.com/api/vAppTemplate/vappTemplate-1",
+			Status: "RESOLVED", LastSuccessfulSync: "2021-03-01T10:00:00.000Z", InSync: true},
+	}
+
+	got := buildCatalogSyncStatus(vappTemplates, mediaRecords)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("buildCatalogSyncStatus() = %#v, want %#v", got, expected)
+	}
+
+	if got := buildCatalogSyncStatus(nil, nil); got != nil {
+		t.Errorf("buildCatalogSyncStatus() of an empty catalog = %#v, want nil", got)
+	}
+}
+
+func Test_getSyncHref(t *testing.T) {
+	tests := []struct {
+		name  string
+		links types.LinkList
+		href  string
+		want  string
+	}{
+		{
+			name: "SyncLink",
+			links: types.LinkList{
+				{Rel: types.RelUp, HREF: "https://vcd.example.com/api/admin/org/1"},
+				{Rel: types.RelSync, HREF: "https://vcd.example.com/api/catalog/1/action/sync"},
+			},
+			href: "https://vcd.example.com/api/admin/catalog/1",
+			want: "https://vcd.example.com/api/catalog/1/action/sync",
+		},
+		{
+			name: "AdminHref",
+			href: "https://vcd.example.com/api/admin/catalog/1",
+			want: "https://vcd.example.com/api/catalog/1/action/sync",
+		},
+		{
+			name: "TenantHref",
+			href: "https://vcd.example.com/api/catalogItem/1",
+			want: "https://vcd.example.com/api/catalogItem/1/action/sync",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSyncHref(tt.links, tt.href); got != tt.want {
+				t.Errorf("getSyncHref() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
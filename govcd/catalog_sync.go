@@ -0,0 +1,376 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// CatalogSyncChecksumKey is the metadata key in which SyncCatalogFromDirectory stores the SHA256
+// checksum of the file a catalog item was uploaded from
+const CatalogSyncChecksumKey = "catalog_sync_sha256"
+
+// CatalogSyncActionType is the operation planned by SyncCatalogFromDirectory for a catalog item
+type CatalogSyncActionType string
+
+const (
+	CatalogSyncCreate    CatalogSyncActionType = "create"    // The file has no catalog item yet
+	CatalogSyncUpdate    CatalogSyncActionType = "update"    // The file changed, its catalog item is replaced
+	CatalogSyncDelete    CatalogSyncActionType = "delete"    // The catalog item has no file
+	CatalogSyncUnchanged CatalogSyncActionType = "unchanged" // The catalog item matches its file
+	// The catalog item was not uploaded by a synchronization and is kept, whether it has a file or not
+	CatalogSyncUnmanaged CatalogSyncActionType = "unmanaged"
+)
+
+// CatalogSyncOptions are the settings of SyncCatalogFromDirectory
+type CatalogSyncOptions struct {
+	// DryRun only computes the plan, without changing the catalog
+	DryRun bool
+	// DeleteMissing removes the catalog items which have no corresponding file in the directory.
+	// Only items uploaded by a synchronization, which have the CatalogSyncChecksumKey metadata, are
+	// removed. The others are reported as CatalogSyncUnmanaged.
+	DeleteMissing bool
+	// AdoptUnmanaged replaces the catalog items which were not uploaded by a synchronization with the
+	// file of the same name. By default they are reported as CatalogSyncUnmanaged and kept.
+	AdoptUnmanaged bool
+	// UploadPieceSize is the size of the upload chunks. Defaults to 1MB
+	UploadPieceSize int64
+}
+
+// CatalogSyncAction is one operation of a catalog synchronization
+type CatalogSyncAction struct {
+	Action   CatalogSyncActionType
+	ItemName string
+	ItemType string // CatalogItemTypeVappTemplate or CatalogItemTypeMedia
+	FileName string // Empty when the catalog item has no file
+	Checksum string // SHA256 checksum of the file. Empty when the catalog item has no file
+	Error    error  // Set when the action failed
+	// ItemDeleted is true when the previous catalog item was deleted. For a failed CatalogSyncUpdate,
+	// it means that the item is no longer in the catalog.
+	ItemDeleted bool
+}
+
+// CatalogSyncReport is the result of SyncCatalogFromDirectory
+type CatalogSyncReport struct {
+	DryRun  bool
+	Actions []CatalogSyncAction // Sorted by item name
+}
+
+// Changes returns the actions which modify the catalog
+func (report *CatalogSyncReport) Changes() []CatalogSyncAction {
+	var changes []CatalogSyncAction
+	for _, action := range report.Actions {
+		if action.Action != CatalogSyncUnchanged && action.Action != CatalogSyncUnmanaged {
+			changes = append(changes, action)
+		}
+	}
+	return changes
+}
+
+// Failures returns the actions which failed
+func (report *CatalogSyncReport) Failures() []CatalogSyncAction {
+	var failures []CatalogSyncAction
+	for _, action := range report.Actions {
+		if action.Error != nil {
+			failures = append(failures, action)
+		}
+	}
+	return failures
+}
+
+// syncFileSystem gives access to the files mirrored by SyncCatalogFromDirectory
+type syncFileSystem interface {
+	// ListFiles returns the names of the regular files of dir
+	ListFiles(dir string) ([]string, error)
+	Open(path string) (io.ReadCloser, error)
+}
+
+// osFileSystem is the syncFileSystem of the local disk
+type osFileSystem struct{}
+
+func (osFileSystem) ListFiles(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.Mode().IsRegular() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func (osFileSystem) Open(path string) (io.ReadCloser, error) {
+	return os.Open(filepath.Clean(path))
+}
+
+// catalogSyncItem is an existing catalog item, as considered by the synchronization plan
+type catalogSyncItem struct {
+	Name     string
+	Href     string
+	Type     string
+	Checksum string // Value of CatalogSyncChecksumKey. Empty if the item was not uploaded by a synchronization
+}
+
+// SyncCatalogFromDirectory makes the catalog mirror the OVA (.ova) and ISO (.iso) files of dir. Each
+// file is stored as a vApp template or media named after the file without its extension, and its
+// SHA256 checksum is saved in the metadata of the catalog item under CatalogSyncChecksumKey. Only new
+// files and files whose checksum changed are uploaded. Changed items are deleted before being
+// uploaded again: if the upload fails, the action reports ItemDeleted and the item is missing from
+// the catalog until the next synchronization. Catalog items which were not uploaded by a
+// synchronization are only replaced with options.AdoptUnmanaged. Other files are ignored.
+// With options.DeleteMissing, catalog items uploaded by a synchronization and without a
+// corresponding file are deleted.
+// With options.DryRun, the returned report contains the plan and the catalog is not modified.
+// Failed actions don't stop the synchronization: they are recorded in the report and summarised in
+// the returned error.
+func SyncCatalogFromDirectory(ctx context.Context, catalog *Catalog, dir string, options CatalogSyncOptions) (*CatalogSyncReport, error) {
+	items, err := getCatalogSyncItems(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	actions, err := planCatalogSync(osFileSystem{}, dir, items, options)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CatalogSyncReport{DryRun: options.DryRun, Actions: actions}
+	if options.DryRun {
+		return report, nil
+	}
+
+	uploadPieceSize := options.UploadPieceSize
+	if uploadPieceSize <= 0 {
+		uploadPieceSize = defaultPieceSize
+	}
+
+	var failures []string
+	for i := range report.Actions {
+		action := &report.Actions[i]
+		if action.Action == CatalogSyncUnchanged || action.Action == CatalogSyncUnmanaged {
+			continue
+		}
+		action.ItemDeleted, action.Error = runCatalogSyncAction(ctx, catalog, dir, *action, uploadPieceSize)
+		if action.Error != nil {
+			util.Logger.Printf("[TRACE] error synchronizing catalog item %s: %s", action.ItemName, action.Error)
+			failures = append(failures, fmt.Sprintf("%s %s: %s", action.Action, action.ItemName, action.Error))
+		}
+	}
+
+	if len(failures) > 0 {
+		return report, fmt.Errorf("%d catalog synchronization actions failed: %s", len(failures),
+			strings.Join(failures, "; "))
+	}
+	return report, nil
+}
+
+// getCatalogSyncItems retrieves the vApp templates and media of the catalog, with their checksums
+func getCatalogSyncItems(ctx context.Context, catalog *Catalog) ([]catalogSyncItem, error) {
+	records, err := catalog.QueryCatalogItemList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []catalogSyncItem
+	for _, record := range records {
+		var itemType string
+		switch strings.ToLower(record.EntityType) {
+		case strings.ToLower(CatalogItemTypeVappTemplate):
+			itemType = CatalogItemTypeVappTemplate
+		case CatalogItemTypeMedia:
+			itemType = CatalogItemTypeMedia
+		default:
+			continue
+		}
+
+		metadata, err := getMetadata(ctx, catalog.client, record.HREF)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving metadata of catalog item %s: %s", record.Name, err)
+		}
+		item := catalogSyncItem{Name: record.Name, Href: record.HREF, Type: itemType}
+		for _, entry := range metadata.MetadataEntry {
+			if entry.Key == CatalogSyncChecksumKey && entry.TypedValue != nil {
+				item.Checksum = entry.TypedValue.Value
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// planCatalogSync compares the files of dir with the catalog items and returns the actions needed
+// to make the catalog mirror the directory, sorted by item name
+func planCatalogSync(fileSystem syncFileSystem, dir string, items []catalogSyncItem, options CatalogSyncOptions) ([]CatalogSyncAction, error) {
+	fileNames, err := fileSystem.ListFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error listing files of %s: %s", dir, err)
+	}
+
+	existingItems := make(map[string]catalogSyncItem)
+	for _, item := range items {
+		existingItems[item.Name] = item
+	}
+
+	var actions []CatalogSyncAction
+	plannedItems := make(map[string]string)
+	for _, fileName := range fileNames {
+		itemType := catalogSyncItemType(fileName)
+		if itemType == "" {
+			continue
+		}
+		itemName := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+		if previousFile, found := plannedItems[itemName]; found {
+			return nil, fmt.Errorf("files %s and %s would both be stored as catalog item %s", previousFile, fileName, itemName)
+		}
+		plannedItems[itemName] = fileName
+
+		checksum, err := fileChecksum(fileSystem, filepath.Join(dir, fileName))
+		if err != nil {
+			return nil, err
+		}
+
+		action := CatalogSyncAction{
+			Action:   CatalogSyncCreate,
+			ItemName: itemName,
+			ItemType: itemType,
+			FileName: fileName,
+			Checksum: checksum,
+		}
+		if item, found := existingItems[itemName]; found {
+			switch {
+			case item.Checksum == "" && !options.AdoptUnmanaged:
+				action.Action = CatalogSyncUnmanaged
+			case item.Type == itemType && item.Checksum == checksum:
+				action.Action = CatalogSyncUnchanged
+			default:
+				action.Action = CatalogSyncUpdate
+			}
+		}
+		actions = append(actions, action)
+	}
+
+	if options.DeleteMissing {
+		for _, item := range items {
+			if _, found := plannedItems[item.Name]; !found {
+				action := CatalogSyncAction{
+					Action:   CatalogSyncDelete,
+					ItemName: item.Name,
+					ItemType: item.Type,
+				}
+				// Items which were not uploaded by a synchronization may belong to someone else
+				if item.Checksum == "" {
+					action.Action = CatalogSyncUnmanaged
+				}
+				actions = append(actions, action)
+			}
+		}
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool { return actions[i].ItemName < actions[j].ItemName })
+	return actions, nil
+}
+
+// catalogSyncItemType returns the type of catalog item stored for a file, or an empty string if
+// the file is not synchronized
+func catalogSyncItemType(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".ova":
+		return CatalogItemTypeVappTemplate
+	case ".iso":
+		return CatalogItemTypeMedia
+	}
+	return ""
+}
+
+// fileChecksum returns the hex encoded SHA256 checksum of a file
+func fileChecksum(fileSystem syncFileSystem, path string) (string, error) {
+	file, err := fileSystem.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error opening %s: %s", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %s", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// runCatalogSyncAction applies one planned action to the catalog. It returns whether the existing
+// catalog item was deleted.
+func runCatalogSyncAction(ctx context.Context, catalog *Catalog, dir string, action CatalogSyncAction, uploadPieceSize int64) (bool, error) {
+	deleted := false
+	if action.Action == CatalogSyncUpdate || action.Action == CatalogSyncDelete {
+		catalogItem, err := catalog.GetCatalogItemByName(ctx, action.ItemName, true)
+		if err != nil {
+			return false, err
+		}
+		err = catalogItem.Delete(ctx)
+		if err != nil {
+			return false, err
+		}
+		if action.Action == CatalogSyncDelete {
+			return true, nil
+		}
+		deleted = true
+	}
+
+	err := uploadCatalogSyncItem(ctx, catalog, dir, action, uploadPieceSize)
+	if err != nil && deleted {
+		return deleted, fmt.Errorf("catalog item %s was deleted, but uploading its replacement failed: %s", action.ItemName, err)
+	}
+	return deleted, err
+}
+
+// uploadCatalogSyncItem uploads the file of an action and stores its checksum in the metadata of
+// the new catalog item
+func uploadCatalogSyncItem(ctx context.Context, catalog *Catalog, dir string, action CatalogSyncAction, uploadPieceSize int64) error {
+	// The catalog must know its current items, as uploads refuse to overwrite existing ones
+	err := catalog.Refresh(ctx)
+	if err != nil {
+		return err
+	}
+
+	filePath := filepath.Join(dir, action.FileName)
+	var uploadTask UploadTask
+	if action.ItemType == CatalogItemTypeMedia {
+		uploadTask, err = catalog.UploadMediaImage(ctx, action.ItemName, "", filePath, uploadPieceSize)
+	} else {
+		uploadTask, err = catalog.UploadOvf(ctx, filePath, action.ItemName, "", uploadPieceSize)
+	}
+	if err != nil {
+		return err
+	}
+	err = uploadTask.WaitTaskCompletion(ctx)
+	if err != nil {
+		return err
+	}
+
+	catalogItem, err := catalog.GetCatalogItemByName(ctx, action.ItemName, true)
+	if err != nil {
+		return err
+	}
+	task, err := addMetadata(ctx, catalog.client, CatalogSyncChecksumKey, action.Checksum, catalogItem.CatalogItem.HREF)
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion(ctx)
+}
@@ -0,0 +1,93 @@
+// +build catalog functional ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+// Test_SyncCatalogFromDirectory mirrors a directory containing a media into a new catalog, checking
+// the dry run, the upload, the lack of changes on a second run and the deletion of missing items
+func (vcd *TestVCD) Test_SyncCatalogFromDirectory(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+	skipWhenMediaPathMissing(vcd, check)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "govcd-catalog-sync")
+	check.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	mediaName := check.TestName() + "-media"
+	content, err := ioutil.ReadFile(vcd.config.Media.MediaPath)
+	check.Assert(err, IsNil)
+	err = ioutil.WriteFile(filepath.Join(dir, mediaName+".iso"), content, 0600)
+	check.Assert(err, IsNil)
+
+	org, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+	catalogName := check.TestName()
+	adminCatalog, err := org.CreateCatalogWithStorageProfile(ctx, catalogName, catalogName, nil)
+	check.Assert(err, IsNil)
+	AddToCleanupList(catalogName, "catalog", vcd.config.VCD.Org, check.TestName())
+	task := NewTask(&vcd.client.Client)
+	task.Task = adminCatalog.AdminCatalog.Tasks.Task[0]
+	err = task.WaitTaskCompletion(ctx)
+	check.Assert(err, IsNil)
+	catalog, err := org.GetCatalogByName(ctx, catalogName, true)
+	check.Assert(err, IsNil)
+
+	report, err := SyncCatalogFromDirectory(ctx, catalog, dir, CatalogSyncOptions{DryRun: true})
+	check.Assert(err, IsNil)
+	check.Assert(report.DryRun, Equals, true)
+	check.Assert(len(report.Actions), Equals, 1)
+	check.Assert(report.Actions[0].Action, Equals, CatalogSyncCreate)
+	check.Assert(report.Actions[0].ItemName, Equals, mediaName)
+	check.Assert(report.Actions[0].ItemType, Equals, CatalogItemTypeMedia)
+	_, err = catalog.GetCatalogItemByName(ctx, mediaName, true)
+	check.Assert(ContainsNotFound(err), Equals, true)
+
+	report, err = SyncCatalogFromDirectory(ctx, catalog, dir, CatalogSyncOptions{})
+	check.Assert(err, IsNil)
+	check.Assert(len(report.Changes()), Equals, 1)
+	check.Assert(len(report.Failures()), Equals, 0)
+
+	catalogItem, err := catalog.GetCatalogItemByName(ctx, mediaName, true)
+	check.Assert(err, IsNil)
+	metadata, err := getMetadata(ctx, catalog.client, catalogItem.CatalogItem.HREF)
+	check.Assert(err, IsNil)
+	foundChecksum := false
+	for _, entry := range metadata.MetadataEntry {
+		if entry.Key == CatalogSyncChecksumKey {
+			check.Assert(entry.TypedValue.Value, Equals, report.Actions[0].Checksum)
+			foundChecksum = true
+		}
+	}
+	check.Assert(foundChecksum, Equals, true)
+
+	report, err = SyncCatalogFromDirectory(ctx, catalog, dir, CatalogSyncOptions{})
+	check.Assert(err, IsNil)
+	check.Assert(len(report.Actions), Equals, 1)
+	check.Assert(report.Actions[0].Action, Equals, CatalogSyncUnchanged)
+
+	err = os.Remove(filepath.Join(dir, mediaName+".iso"))
+	check.Assert(err, IsNil)
+	report, err = SyncCatalogFromDirectory(ctx, catalog, dir, CatalogSyncOptions{DeleteMissing: true})
+	check.Assert(err, IsNil)
+	check.Assert(len(report.Actions), Equals, 1)
+	check.Assert(report.Actions[0].Action, Equals, CatalogSyncDelete)
+	_, err = catalog.GetCatalogItemByName(ctx, mediaName, true)
+	check.Assert(ContainsNotFound(err), Equals, true)
+
+	err = catalog.Delete(ctx, true, true)
+	check.Assert(err, IsNil)
+}
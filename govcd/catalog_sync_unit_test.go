@@ -0,0 +1,182 @@
+// +build unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// fakeFileSystem is an in-memory syncFileSystem, mapping file paths to their content
+type fakeFileSystem map[string]string
+
+func (fileSystem fakeFileSystem) ListFiles(dir string) ([]string, error) {
+	var names []string
+	for path := range fileSystem {
+		if filepath.Dir(path) == dir {
+			names = append(names, filepath.Base(path))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (fileSystem fakeFileSystem) Open(path string) (io.ReadCloser, error) {
+	content, found := fileSystem[path]
+	if !found {
+		return nil, fmt.Errorf("file %s not found", path)
+	}
+	return ioutil.NopCloser(strings.NewReader(content)), nil
+}
+
+func Test_planCatalogSync(t *testing.T) {
+	fileSystem := fakeFileSystem{
+		"/artifacts/photon.ova":  "photon",
+		"/artifacts/centos.ova":  "centos",
+		"/artifacts/tools.iso":   "tools",
+		"/artifacts/README.md":   "ignored",
+		"/artifacts/old/old.ova": "in a sub-directory",
+	}
+
+	photon, err := fileChecksum(fileSystem, "/artifacts/photon.ova")
+	if err != nil {
+		t.Fatalf("error computing checksum: %s", err)
+	}
+	if photon != "db44daf2e965f230f9693880ee41395b74b3761914509d494aca20e78e9bce3b" {
+		t.Fatalf("unexpected SHA256 checksum %s", photon)
+	}
+	centos, _ := fileChecksum(fileSystem, "/artifacts/centos.ova")
+	tools, _ := fileChecksum(fileSystem, "/artifacts/tools.iso")
+
+	items := []catalogSyncItem{
+		{Name: "photon", Type: CatalogItemTypeVappTemplate, Checksum: photon},
+		{Name: "centos", Type: CatalogItemTypeVappTemplate, Checksum: "outdated"},
+		{Name: "legacy", Type: CatalogItemTypeMedia, Checksum: "outdated"},
+		{Name: "shared", Type: CatalogItemTypeVappTemplate},
+	}
+
+	tests := []struct {
+		name    string
+		items   []catalogSyncItem
+		options CatalogSyncOptions
+		want    []CatalogSyncAction
+	}{
+		{
+			name: "EmptyCatalog",
+			want: []CatalogSyncAction{
+				{Action: CatalogSyncCreate, ItemName: "centos", ItemType: CatalogItemTypeVappTemplate, FileName: "centos.ova", Checksum: centos},
+				{Action: CatalogSyncCreate, ItemName: "photon", ItemType: CatalogItemTypeVappTemplate, FileName: "photon.ova", Checksum: photon},
+				{Action: CatalogSyncCreate, ItemName: "tools", ItemType: CatalogItemTypeMedia, FileName: "tools.iso", Checksum: tools},
+			},
+		},
+		{
+			name:  "KeepMissing",
+			items: items,
+			want: []CatalogSyncAction{
+				{Action: CatalogSyncUpdate, ItemName: "centos", ItemType: CatalogItemTypeVappTemplate, FileName: "centos.ova", Checksum: centos},
+				{Action: CatalogSyncUnchanged, ItemName: "photon", ItemType: CatalogItemTypeVappTemplate, FileName: "photon.ova", Checksum: photon},
+				{Action: CatalogSyncCreate, ItemName: "tools", ItemType: CatalogItemTypeMedia, FileName: "tools.iso", Checksum: tools},
+			},
+		},
+		{
+			name:    "DeleteMissing",
+			items:   items,
+			options: CatalogSyncOptions{DeleteMissing: true},
+			want: []CatalogSyncAction{
+				{Action: CatalogSyncUpdate, ItemName: "centos", ItemType: CatalogItemTypeVappTemplate, FileName: "centos.ova", Checksum: centos},
+				{Action: CatalogSyncDelete, ItemName: "legacy", ItemType: CatalogItemTypeMedia},
+				{Action: CatalogSyncUnchanged, ItemName: "photon", ItemType: CatalogItemTypeVappTemplate, FileName: "photon.ova", Checksum: photon},
+				{Action: CatalogSyncUnmanaged, ItemName: "shared", ItemType: CatalogItemTypeVappTemplate},
+				{Action: CatalogSyncCreate, ItemName: "tools", ItemType: CatalogItemTypeMedia, FileName: "tools.iso", Checksum: tools},
+			},
+		},
+		{
+			name: "TypeChanged",
+			items: []catalogSyncItem{
+				{Name: "tools", Type: CatalogItemTypeVappTemplate, Checksum: tools},
+			},
+			want: []CatalogSyncAction{
+				{Action: CatalogSyncCreate, ItemName: "centos", ItemType: CatalogItemTypeVappTemplate, FileName: "centos.ova", Checksum: centos},
+				{Action: CatalogSyncCreate, ItemName: "photon", ItemType: CatalogItemTypeVappTemplate, FileName: "photon.ova", Checksum: photon},
+				{Action: CatalogSyncUpdate, ItemName: "tools", ItemType: CatalogItemTypeMedia, FileName: "tools.iso", Checksum: tools},
+			},
+		},
+		{
+			// An item uploaded manually is not replaced by a file of the same name
+			name: "UnmanagedWithFile",
+			items: []catalogSyncItem{
+				{Name: "photon", Type: CatalogItemTypeVappTemplate},
+			},
+			want: []CatalogSyncAction{
+				{Action: CatalogSyncCreate, ItemName: "centos", ItemType: CatalogItemTypeVappTemplate, FileName: "centos.ova", Checksum: centos},
+				{Action: CatalogSyncUnmanaged, ItemName: "photon", ItemType: CatalogItemTypeVappTemplate, FileName: "photon.ova", Checksum: photon},
+				{Action: CatalogSyncCreate, ItemName: "tools", ItemType: CatalogItemTypeMedia, FileName: "tools.iso", Checksum: tools},
+			},
+		},
+		{
+			name: "AdoptUnmanaged",
+			items: []catalogSyncItem{
+				{Name: "photon", Type: CatalogItemTypeVappTemplate},
+			},
+			options: CatalogSyncOptions{AdoptUnmanaged: true},
+			want: []CatalogSyncAction{
+				{Action: CatalogSyncCreate, ItemName: "centos", ItemType: CatalogItemTypeVappTemplate, FileName: "centos.ova", Checksum: centos},
+				{Action: CatalogSyncUpdate, ItemName: "photon", ItemType: CatalogItemTypeVappTemplate, FileName: "photon.ova", Checksum: photon},
+				{Action: CatalogSyncCreate, ItemName: "tools", ItemType: CatalogItemTypeMedia, FileName: "tools.iso", Checksum: tools},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := planCatalogSync(fileSystem, "/artifacts", tt.items, tt.options)
+			if err != nil {
+				t.Fatalf("planCatalogSync() returned error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planCatalogSync() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_planCatalogSyncDuplicateNames(t *testing.T) {
+	fileSystem := fakeFileSystem{
+		"/artifacts/photon.ova": "template",
+		"/artifacts/photon.iso": "media",
+	}
+	_, err := planCatalogSync(fileSystem, "/artifacts", nil, CatalogSyncOptions{})
+	if err == nil {
+		t.Errorf("planCatalogSync() should fail when two files map to the same catalog item")
+	}
+}
+
+func TestCatalogSyncReport(t *testing.T) {
+	report := CatalogSyncReport{Actions: []CatalogSyncAction{
+		{Action: CatalogSyncCreate, ItemName: "centos"},
+		{Action: CatalogSyncUnchanged, ItemName: "photon"},
+		{Action: CatalogSyncUnmanaged, ItemName: "shared"},
+		{Action: CatalogSyncUpdate, ItemName: "tools", ItemDeleted: true, Error: fmt.Errorf("upload failed")},
+	}}
+
+	var changes []string
+	for _, action := range report.Changes() {
+		changes = append(changes, action.ItemName)
+	}
+	if !reflect.DeepEqual(changes, []string{"centos", "tools"}) {
+		t.Errorf("Changes() = %v, want [centos tools]", changes)
+	}
+	failures := report.Failures()
+	if len(failures) != 1 || failures[0].ItemName != "tools" || !failures[0].ItemDeleted {
+		t.Errorf("Failures() = %+v, want the deleted tools item", failures)
+	}
+}
@@ -616,3 +616,45 @@ func (vcd *TestVCD) TestGetVappTemplateByHref(check *C) {
 	check.Assert(vappTemplate.VAppTemplate.Type, Equals, types.MimeVAppTemplate)
 	check.Assert(vappTemplate.VAppTemplate.Name, Equals, catalogItem.CatalogItem.Name)
 }
+
+// Test_CatalogCaptureVApp captures the test vApp into the test catalog and then overwrites the
+// resulting catalog item with a new capture, checking that the item ID is kept
+func (vcd *TestVCD) Test_CatalogCaptureVApp(check *C) {
+	fmt.Printf("Running: %s\n", check.TestName())
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	vapp := vcd.findFirstVapp(ctx)
+	if vapp.VApp == nil {
+		check.Skip("Skipping test because no vApp was found")
+	}
+
+	catalog, err := vcd.org.GetCatalogByName(ctx, vcd.config.VCD.Catalog.Name, false)
+	check.Assert(err, IsNil)
+
+	itemName := check.TestName()
+	catalogItem, err := catalog.CaptureVApp(ctx, &vapp, itemName, "captured from test", true)
+	check.Assert(err, IsNil)
+	AddToCleanupList(itemName, "catalogItem", vcd.org.Org.Name+"|"+vcd.config.VCD.Catalog.Name, check.TestName())
+	check.Assert(catalogItem.CatalogItem.Name, Equals, itemName)
+
+	vappTemplate, err := catalogItem.GetVAppTemplate(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(vappTemplate.VAppTemplate.Children, NotNil)
+	check.Assert(len(vappTemplate.VAppTemplate.Children.VM), Equals, len(vapp.VApp.Children.VM))
+
+	// Capturing with the same name again must fail
+	_, err = catalog.CaptureVApp(ctx, &vapp, itemName, "", true)
+	check.Assert(err, NotNil)
+
+	if vcd.client.Client.APIVCDMaxVersionIs(ctx, ">= 33.0") {
+		itemId := catalogItem.CatalogItem.ID
+		err = catalogItem.ReplaceWithCapture(ctx, &vapp, false)
+		check.Assert(err, IsNil)
+		check.Assert(catalogItem.CatalogItem.ID, Equals, itemId)
+		check.Assert(catalogItem.CatalogItem.Description, Equals, "captured from test")
+	}
+
+	err = catalogItem.Delete(ctx)
+	check.Assert(err, IsNil)
+}
@@ -0,0 +1,108 @@
+// +build unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/ovf"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Test_UploadSignedOva checks that OVAs which can't be verified are rejected before contacting vCD.
+// The catalog has no client, so any request to vCD would panic.
+func Test_UploadSignedOva(t *testing.T) {
+	catalog := &Catalog{Catalog: &types.Catalog{Name: "catalog"}}
+
+	tests := []struct {
+		name      string
+		ova       string
+		trustPool *x509.CertPool
+		wantErr   string
+	}{
+		{
+			name:    "NoTrustPool",
+			ova:     "../test-resources/vapp_with_3_vms.ova",
+			wantErr: "a trust pool is required",
+		},
+		{
+			name:      "NotSigned",
+			ova:       "../test-resources/vapp_with_3_vms.ova",
+			trustPool: x509.NewCertPool(),
+			wantErr:   "is not signed",
+		},
+		{
+			name:      "NoManifest",
+			ova:       "../test-resources/test_vapp_template.ova",
+			trustPool: x509.NewCertPool(),
+			wantErr:   "does not contain a manifest",
+		},
+		{
+			name:      "Ovf",
+			ova:       "../test-resources/test_vapp_template_ovf/descriptor.ovf",
+			trustPool: x509.NewCertPool(),
+			wantErr:   "only OVA files can be checked for signature",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := catalog.UploadSignedOva(context.Background(), tt.ova, "item", "description", defaultPieceSize, tt.trustPool)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("UploadSignedOva() = %v, want error containing %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_verifyOvfManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "govcd-ovf")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	descriptorPath := filepath.Join(dir, "descriptor.ovf")
+	diskPath := filepath.Join(dir, "disk.vmdk")
+	files := map[string][]byte{descriptorPath: []byte("<Envelope/>"), diskPath: []byte("disk content")}
+	manifest := ovf.Manifest{}
+	for filePath, content := range files {
+		err = ioutil.WriteFile(filePath, content, 0600)
+		if err != nil {
+			t.Fatalf("error writing %s: %s", filePath, err)
+		}
+		_ = manifest.Set(filepath.Base(filePath), content)
+	}
+	filesAbsPaths := []string{descriptorPath, diskPath}
+
+	// An OVF without manifest is accepted
+	if err = verifyOvfManifest(descriptorPath, filesAbsPaths); err != nil {
+		t.Errorf("verifyOvfManifest() without manifest returned error: %s", err)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "descriptor.mf"), manifest.Marshal(), 0600)
+	if err != nil {
+		t.Fatalf("error writing manifest: %s", err)
+	}
+	if err = verifyOvfManifest(descriptorPath, filesAbsPaths); err != nil {
+		t.Errorf("verifyOvfManifest() returned error: %s", err)
+	}
+
+	err = ioutil.WriteFile(diskPath, []byte("other content"), 0600)
+	if err != nil {
+		t.Fatalf("error writing disk: %s", err)
+	}
+	err = verifyOvfManifest(descriptorPath, filesAbsPaths)
+	if err == nil || !strings.Contains(err.Error(), "does not match the manifest") {
+		t.Errorf("verifyOvfManifest() = %v, want digest mismatch", err)
+	}
+}
@@ -51,6 +51,40 @@ func (catalogItem *CatalogItem) Delete(ctx context.Context) error {
 		"", "error deleting Catalog item: %s", nil)
 }
 
+// ReplaceWithCapture captures vapp and overwrites the vApp template of this catalog item in place.
+// The catalog item keeps its ID, name, description and metadata. It requires VCD 10.0+ (API 33.0).
+func (catalogItem *CatalogItem) ReplaceWithCapture(ctx context.Context, vapp *VApp, customizeOnInstantiate bool) error {
+	if vapp == nil || vapp.VApp == nil || vapp.VApp.HREF == "" {
+		return fmt.Errorf("vApp to capture must be provided")
+	}
+	if catalogItem.client.APIVCDMaxVersionIs(ctx, "< 33.0") {
+		return fmt.Errorf("replacing a catalog item with a captured vApp requires at least VCD 10.0 (API 33.0)")
+	}
+
+	catalogLink := catalogItem.CatalogItem.Link.ForType(types.MimeCatalog, types.RelUp)
+	if catalogLink == nil {
+		return fmt.Errorf("no catalog link found in catalog item %s", catalogItem.CatalogItem.Name)
+	}
+
+	captureParams := newCaptureVAppParams(vapp, catalogItem.CatalogItem.Name, catalogItem.CatalogItem.Description,
+		customizeOnInstantiate)
+	captureParams.TargetCatalogItem = &types.Reference{HREF: catalogItem.CatalogItem.HREF}
+
+	err := captureVAppIntoCatalog(ctx, catalogItem.client, catalogLink.HREF, captureParams)
+	if err != nil {
+		return err
+	}
+
+	refreshedItem := &types.CatalogItem{}
+	_, err = catalogItem.client.ExecuteRequest(ctx, catalogItem.CatalogItem.HREF, http.MethodGet,
+		"", "error retrieving catalog item: %s", nil, refreshedItem)
+	if err != nil {
+		return err
+	}
+	catalogItem.CatalogItem = refreshedItem
+	return nil
+}
+
 // queryCatalogItemList returns a list of Catalog Item for the given parent
 func queryCatalogItemList(ctx context.Context, client *Client, parentField, parentValue string) ([]*types.QueryResultCatalogItemType, error) {
 
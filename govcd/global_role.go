@@ -0,0 +1,239 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// GlobalRole is a role defined by the provider which can be published to tenants. Once published,
+// it is available as a read-only role in the tenant organizations.
+type GlobalRole struct {
+	GlobalRole *types.GlobalRole
+	client     *Client
+}
+
+// GetAllGlobalRoles retrieves all global roles. Query parameters can be supplied to perform
+// additional filtering
+func (vcdClient *VCDClient) GetAllGlobalRoles(ctx context.Context, queryParameters url.Values) ([]*GlobalRole, error) {
+	client := &vcdClient.Client
+	if !client.IsSysAdmin {
+		return nil, fmt.Errorf("reading global roles requires System user")
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointGlobalRoles
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.GlobalRole{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wrap all typeResponses into GlobalRole types with client
+	wrappedResponses := make([]*GlobalRole, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &GlobalRole{
+			GlobalRole: typeResponses[sliceIndex],
+			client:     client,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetGlobalRoleByName retrieves a global role by name
+func (vcdClient *VCDClient) GetGlobalRoleByName(ctx context.Context, name string) (*GlobalRole, error) {
+	queryParameters := url.Values{}
+	queryParameters.Add("filter", "name=="+name)
+
+	globalRoles, err := vcdClient.GetAllGlobalRoles(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error reading global role with name '%s': %s", name, err)
+	}
+
+	if len(globalRoles) == 0 {
+		return nil, fmt.Errorf("%s: could not find global role with name '%s'", ErrorEntityNotFound, name)
+	}
+
+	if len(globalRoles) > 1 {
+		return nil, fmt.Errorf("found more than 1 global role with name '%s'", name)
+	}
+
+	return globalRoles[0], nil
+}
+
+// GetGlobalRoleById retrieves a global role by ID
+func (vcdClient *VCDClient) GetGlobalRoleById(ctx context.Context, id string) (*GlobalRole, error) {
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointGlobalRoles
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("empty global role id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	globalRole := &GlobalRole{
+		GlobalRole: &types.GlobalRole{},
+		client:     client,
+	}
+
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, globalRole.GlobalRole)
+	if err != nil {
+		return nil, err
+	}
+
+	return globalRole, nil
+}
+
+// CreateGlobalRole creates a new global role
+func (vcdClient *VCDClient) CreateGlobalRole(ctx context.Context, newGlobalRole *types.GlobalRole) (*GlobalRole, error) {
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointGlobalRoles
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	returnGlobalRole := &GlobalRole{
+		GlobalRole: &types.GlobalRole{},
+		client:     client,
+	}
+
+	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, newGlobalRole, returnGlobalRole.GlobalRole)
+	if err != nil {
+		return nil, fmt.Errorf("error creating global role: %s", err)
+	}
+
+	return returnGlobalRole, nil
+}
+
+// Update updates an existing global role
+func (globalRole *GlobalRole) Update(ctx context.Context) (*GlobalRole, error) {
+	client := globalRole.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointGlobalRoles
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if globalRole.GlobalRole.ID == "" {
+		return nil, fmt.Errorf("cannot update global role without id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, globalRole.GlobalRole.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	returnGlobalRole := &GlobalRole{
+		GlobalRole: &types.GlobalRole{},
+		client:     client,
+	}
+
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, globalRole.GlobalRole, returnGlobalRole.GlobalRole)
+	if err != nil {
+		return nil, fmt.Errorf("error updating global role: %s", err)
+	}
+
+	return returnGlobalRole, nil
+}
+
+// Delete deletes the global role
+func (globalRole *GlobalRole) Delete(ctx context.Context) error {
+	client := globalRole.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointGlobalRoles
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if globalRole.GlobalRole.ID == "" {
+		return fmt.Errorf("cannot delete global role without id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, globalRole.GlobalRole.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting global role: %s", err)
+	}
+
+	return nil
+}
+
+// GetRights retrieves the rights of the global role
+func (globalRole *GlobalRole) GetRights(ctx context.Context, queryParameters url.Values) ([]*types.Right, error) {
+	return getRights(ctx, globalRole.client, types.OpenApiEndpointGlobalRoleRights, globalRole.GlobalRole.ID, queryParameters)
+}
+
+// AddRights adds the given rights to the global role
+func (globalRole *GlobalRole) AddRights(ctx context.Context, newRights []types.OpenApiReference) error {
+	return addRights(ctx, globalRole.client, types.OpenApiEndpointGlobalRoleRights, globalRole.GlobalRole.ID, newRights)
+}
+
+// RemoveRights removes the given rights from the global role. It fails if any of them is not
+// part of the global role.
+func (globalRole *GlobalRole) RemoveRights(ctx context.Context, removedRights []types.OpenApiReference) error {
+	return removeRights(ctx, globalRole.client, types.OpenApiEndpointGlobalRoleRights, globalRole.GlobalRole.ID, removedRights)
+}
+
+// SetRights replaces all rights of the global role with the given ones
+func (globalRole *GlobalRole) SetRights(ctx context.Context, rights []types.OpenApiReference) error {
+	return setRights(ctx, globalRole.client, types.OpenApiEndpointGlobalRoleRights, globalRole.GlobalRole.ID, rights)
+}
+
+// GetTenants retrieves the organizations to which the global role is published
+func (globalRole *GlobalRole) GetTenants(ctx context.Context, queryParameters url.Values) ([]types.OpenApiReference, error) {
+	return getTenants(ctx, globalRole.client, types.OpenApiEndpointGlobalRoleTenants, globalRole.GlobalRole.ID, queryParameters)
+}
+
+// PublishTenants publishes the global role to the given organizations
+func (globalRole *GlobalRole) PublishTenants(ctx context.Context, tenants []types.OpenApiReference) error {
+	return publishTenants(ctx, globalRole.client, types.OpenApiEndpointGlobalRoleTenants, globalRole.GlobalRole.ID, "publish", tenants)
+}
+
+// UnpublishTenants removes the global role from the given organizations
+func (globalRole *GlobalRole) UnpublishTenants(ctx context.Context, tenants []types.OpenApiReference) error {
+	return publishTenants(ctx, globalRole.client, types.OpenApiEndpointGlobalRoleTenants, globalRole.GlobalRole.ID, "unpublish", tenants)
+}
+
+// PublishAllTenants publishes the global role to all organizations, including future ones
+func (globalRole *GlobalRole) PublishAllTenants(ctx context.Context) error {
+	return publishTenants(ctx, globalRole.client, types.OpenApiEndpointGlobalRoleTenants, globalRole.GlobalRole.ID, "publishAll", nil)
+}
+
+// UnpublishAllTenants removes the global role from all organizations
+func (globalRole *GlobalRole) UnpublishAllTenants(ctx context.Context) error {
+	return publishTenants(ctx, globalRole.client, types.OpenApiEndpointGlobalRoleTenants, globalRole.GlobalRole.ID, "unpublishAll", nil)
+}
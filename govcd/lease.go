@@ -0,0 +1,153 @@
+package govcd
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// GetLease retrieves the lease settings of the vApp
+func (vapp *VApp) GetLease(ctx context.Context) (*types.LeaseSettingsSection, error) {
+	if vapp.VApp.HREF == "" {
+		return nil, fmt.Errorf("cannot retrieve lease, vApp HREF is empty")
+	}
+	return getLeaseSettings(ctx, vapp.client, vapp.VApp.HREF)
+}
+
+// RenewLease sets the runtime (deployment) and storage lease of the vApp. The lease expiration
+// dates are recalculated from the time of the request. A value of 0 means that the lease never
+// expires, if allowed by the Org lease policy.
+func (vapp *VApp) RenewLease(ctx context.Context, deploymentLeaseInSeconds, storageLeaseInSeconds int) error {
+	if vapp.VApp.HREF == "" {
+		return fmt.Errorf("cannot renew lease, vApp HREF is empty")
+	}
+	if deploymentLeaseInSeconds < 0 || storageLeaseInSeconds < 0 {
+		return fmt.Errorf("lease values must not be negative")
+	}
+
+	leaseSettings := newUpdateLeaseSettingsSection(vapp.VApp.HREF)
+	leaseSettings.DeploymentLeaseInSeconds = &deploymentLeaseInSeconds
+	leaseSettings.StorageLeaseInSeconds = &storageLeaseInSeconds
+
+	err := updateLeaseSettings(ctx, vapp.client, leaseSettings, "vApp "+vapp.VApp.Name)
+	if err != nil {
+		return err
+	}
+	return vapp.Refresh(ctx)
+}
+
+// GetLease retrieves the lease settings of the vApp template. Only the storage lease applies to
+// vApp templates.
+func (vAppTemplate *VAppTemplate) GetLease(ctx context.Context) (*types.LeaseSettingsSection, error) {
+	if vAppTemplate.VAppTemplate == nil || vAppTemplate.VAppTemplate.HREF == "" {
+		return nil, fmt.Errorf("cannot retrieve lease, vApp template HREF is empty")
+	}
+	return getLeaseSettings(ctx, vAppTemplate.client, vAppTemplate.VAppTemplate.HREF)
+}
+
+// RenewLease sets the storage lease of the vApp template. A value of 0 means that the lease never
+// expires, if allowed by the Org lease policy.
+func (vAppTemplate *VAppTemplate) RenewLease(ctx context.Context, storageLeaseInSeconds int) error {
+	if vAppTemplate.VAppTemplate == nil || vAppTemplate.VAppTemplate.HREF == "" {
+		return fmt.Errorf("cannot renew lease, vApp template HREF is empty")
+	}
+	if storageLeaseInSeconds < 0 {
+		return fmt.Errorf("lease value must not be negative")
+	}
+
+	leaseSettings := newUpdateLeaseSettingsSection(vAppTemplate.VAppTemplate.HREF)
+	leaseSettings.StorageLeaseInSeconds = &storageLeaseInSeconds
+
+	err := updateLeaseSettings(ctx, vAppTemplate.client, leaseSettings, "vApp template "+vAppTemplate.VAppTemplate.Name)
+	if err != nil {
+		return err
+	}
+	return vAppTemplate.Refresh(ctx)
+}
+
+// ListExpiringVApps returns the vApps of the VDC whose runtime or storage lease expires within the
+// given duration from now. vApps with already expired leases are included as well, while vApps
+// with leases which never expire are not.
+func (vdc *Vdc) ListExpiringVApps(ctx context.Context, within time.Duration) ([]*types.QueryResultVAppRecordType, error) {
+	queryType := vdc.client.GetQueryType(types.QtVapp)
+	results, err := vdc.client.cumulativeQuery(ctx, queryType, nil, map[string]string{
+		"type":   queryType,
+		"filter": fmt.Sprintf("vdc==%s", url.QueryEscape(vdc.Vdc.ID)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying vApps: %s", err)
+	}
+
+	vappList := results.Results.VAppRecord
+	if vdc.client.IsSysAdmin {
+		vappList = results.Results.AdminVAppRecord
+	}
+
+	return filterExpiringVApps(vappList, time.Now(), within)
+}
+
+// filterExpiringVApps returns the records whose runtime or storage lease expires before now+within
+func filterExpiringVApps(vappList []*types.QueryResultVAppRecordType, now time.Time, within time.Duration) ([]*types.QueryResultVAppRecordType, error) {
+	deadline := now.Add(within)
+	var expiring []*types.QueryResultVAppRecordType
+	for _, vapp := range vappList {
+		for _, leaseDate := range []string{vapp.AutoUndeployDate, vapp.AutoDeleteDate} {
+			if leaseDate == "" {
+				continue
+			}
+			expiration, err := time.Parse(time.RFC3339, leaseDate)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing lease expiration '%s' of vApp %s: %s", leaseDate, vapp.Name, err)
+			}
+			if !expiration.After(deadline) {
+				expiring = append(expiring, vapp)
+				break
+			}
+		}
+	}
+	return expiring, nil
+}
+
+// newUpdateLeaseSettingsSection creates an empty lease update payload for the given entity
+func newUpdateLeaseSettingsSection(entityHref string) *types.UpdateLeaseSettingsSection {
+	return &types.UpdateLeaseSettingsSection{
+		HREF:     entityHref + "/leaseSettingsSection/",
+		XmlnsOvf: types.XMLNamespaceOVF,
+		Xmlns:    types.XMLNamespaceVCloud,
+		OVFInfo:  "Lease section settings",
+		Type:     types.MimeLeaseSettingSection,
+	}
+}
+
+// getLeaseSettings retrieves the lease settings section of a vApp or vApp template
+func getLeaseSettings(ctx context.Context, client *Client, entityHref string) (*types.LeaseSettingsSection, error) {
+	leaseSettings := &types.LeaseSettingsSection{}
+	_, err := client.ExecuteRequest(ctx, entityHref+"/leaseSettingsSection/", http.MethodGet,
+		types.MimeLeaseSettingSection, "error retrieving lease settings: %s", nil, leaseSettings)
+	if err != nil {
+		return nil, err
+	}
+	return leaseSettings, nil
+}
+
+// updateLeaseSettings sends the lease settings payload and waits for the task to complete
+func updateLeaseSettings(ctx context.Context, client *Client, leaseSettings *types.UpdateLeaseSettingsSection, entityName string) error {
+	task, err := client.ExecuteTaskRequest(ctx, leaseSettings.HREF, http.MethodPut,
+		types.MimeLeaseSettingSection, "error updating lease settings: %s", leaseSettings)
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return fmt.Errorf("error updating lease of %s: %s", entityName, err)
+	}
+	return nil
+}
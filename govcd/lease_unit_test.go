@@ -0,0 +1,63 @@
+// +build unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_filterExpiringVApps(t *testing.T) {
+	now := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+	vappList := []*types.QueryResultVAppRecordType{
+		{Name: "never-expires"},
+		{Name: "undeploy-soon", AutoUndeployDate: "2021-05-01T18:00:00.000Z"},
+		{Name: "delete-soon", AutoUndeployDate: "2021-06-01T12:00:00.000Z", AutoDeleteDate: "2021-05-02T11:00:00.000+02:00"},
+		{Name: "already-expired", AutoDeleteDate: "2021-04-01T12:00:00Z"},
+		{Name: "expires-later", AutoUndeployDate: "2021-05-10T12:00:00.000Z", AutoDeleteDate: "2021-06-10T12:00:00.000Z"},
+	}
+
+	got, err := filterExpiringVApps(vappList, now, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("filterExpiringVApps() unexpected error: %s", err)
+	}
+	var names []string
+	for _, vapp := range got {
+		names = append(names, vapp.Name)
+	}
+	want := "undeploy-soon,delete-soon,already-expired"
+	if strings.Join(names, ",") != want {
+		t.Errorf("filterExpiringVApps() = %v, want %s", names, want)
+	}
+
+	_, err = filterExpiringVApps([]*types.QueryResultVAppRecordType{{Name: "bad", AutoDeleteDate: "tomorrow"}}, now, time.Hour)
+	if err == nil {
+		t.Errorf("filterExpiringVApps() expected error for invalid date")
+	}
+}
+
+// Test_UpdateLeaseSettingsSectionZero checks that a lease of 0 (never expires) is sent explicitly
+func Test_UpdateLeaseSettingsSectionZero(t *testing.T) {
+	leaseSettings := newUpdateLeaseSettingsSection("https://vcd/api/vApp/vapp-1")
+	zero := 0
+	leaseSettings.DeploymentLeaseInSeconds = &zero
+
+	payload, err := xml.Marshal(leaseSettings)
+	if err != nil {
+		t.Fatalf("error marshalling lease settings: %s", err)
+	}
+	if !strings.Contains(string(payload), "<DeploymentLeaseInSeconds>0</DeploymentLeaseInSeconds>") {
+		t.Errorf("deployment lease of 0 not found in payload %s", payload)
+	}
+	if strings.Contains(string(payload), "StorageLeaseInSeconds") {
+		t.Errorf("unset storage lease found in payload %s", payload)
+	}
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// NsxtAlbImportableCloud allows user to list importable NSX-T ALB Clouds. Each importable cloud can only be imported
+// once. It has a flag AlreadyImported which hints if it is already consumed or not.
+type NsxtAlbImportableCloud struct {
+	NsxtAlbImportableCloud *types.NsxtAlbImportableCloud
+	client                 *Client
+}
+
+// NsxtAlbCloud helps to use the virtual infrastructure provided by NSX Advanced Load Balancer, register NSX-T Cloud
+// instances with VMware Cloud Director by consuming NsxtAlbImportableCloud.
+type NsxtAlbCloud struct {
+	NsxtAlbCloud *types.NsxtAlbCloud
+	client       *Client
+}
+
+// GetAllAlbImportableClouds returns importable NSX-T ALB Clouds for specified ALB Controller ID.
+func (vcdClient *VCDClient) GetAllAlbImportableClouds(ctx context.Context, parentAlbControllerUrn string, queryParameters url.Values) ([]*NsxtAlbImportableCloud, error) {
+	return getAllAlbImportableClouds(ctx, &vcdClient.Client, parentAlbControllerUrn, queryParameters)
+}
+
+// GetAlbImportableClouds is a convenience method to list importable NSX-T ALB Clouds for this ALB Controller
+func (nsxtAlbController *NsxtAlbController) GetAlbImportableClouds(ctx context.Context, queryParameters url.Values) ([]*NsxtAlbImportableCloud, error) {
+	return getAllAlbImportableClouds(ctx, nsxtAlbController.client, nsxtAlbController.NsxtAlbController.ID, queryParameters)
+}
+
+// GetAlbImportableCloudByName returns importable NSX-T ALB Cloud by Name for specified ALB Controller ID.
+//
+// Note. Filtering is performed on client side because the endpoint does not support filtering by name.
+func (vcdClient *VCDClient) GetAlbImportableCloudByName(ctx context.Context, parentAlbControllerUrn, name string) (*NsxtAlbImportableCloud, error) {
+	albImportableClouds, err := vcdClient.GetAllAlbImportableClouds(ctx, parentAlbControllerUrn, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Cloud by Name '%s': %s", name, err)
+	}
+
+	filteredAlbImportableClouds := make([]*NsxtAlbImportableCloud, 0)
+	for _, importableCloud := range albImportableClouds {
+		if importableCloud.NsxtAlbImportableCloud.DisplayName == name {
+			filteredAlbImportableClouds = append(filteredAlbImportableClouds, importableCloud)
+		}
+	}
+
+	if len(filteredAlbImportableClouds) == 0 {
+		return nil, fmt.Errorf("%s: could not find NSX-T ALB Importable Cloud by Name '%s'", ErrorEntityNotFound, name)
+	}
+
+	if len(filteredAlbImportableClouds) > 1 {
+		return nil, fmt.Errorf("found more than 1 NSX-T ALB Importable Cloud by Name '%s'", name)
+	}
+
+	return filteredAlbImportableClouds[0], nil
+}
+
+// GetAllAlbClouds returns all configured NSX-T ALB Clouds
+func (vcdClient *VCDClient) GetAllAlbClouds(ctx context.Context, queryParameters url.Values) ([]*NsxtAlbCloud, error) {
+	client := &vcdClient.Client
+	if !client.IsSysAdmin {
+		return nil, fmt.Errorf("reading NSX-T ALB Clouds requires System user")
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbCloud
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.NsxtAlbCloud{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedResponses := make([]*NsxtAlbCloud, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtAlbCloud{
+			NsxtAlbCloud: typeResponses[sliceIndex],
+			client:       client,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetAlbCloudByName returns NSX-T ALB Cloud by name
+func (vcdClient *VCDClient) GetAlbCloudByName(ctx context.Context, name string) (*NsxtAlbCloud, error) {
+	queryParameters := url.Values{}
+	queryParameters.Add("filter", "name=="+name)
+
+	albClouds, err := vcdClient.GetAllAlbClouds(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error reading NSX-T ALB Cloud with Name '%s': %s", name, err)
+	}
+
+	if len(albClouds) == 0 {
+		return nil, fmt.Errorf("%s: could not find NSX-T ALB Cloud with Name '%s'", ErrorEntityNotFound, name)
+	}
+
+	if len(albClouds) > 1 {
+		return nil, fmt.Errorf("found more than 1 NSX-T ALB Cloud with Name '%s'", name)
+	}
+
+	return albClouds[0], nil
+}
+
+// GetAlbCloudById returns NSX-T ALB Cloud by ID
+func (vcdClient *VCDClient) GetAlbCloudById(ctx context.Context, id string) (*NsxtAlbCloud, error) {
+	client := &vcdClient.Client
+	if !client.IsSysAdmin {
+		return nil, fmt.Errorf("reading NSX-T ALB Clouds requires System user")
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("ID is required to lookup NSX-T ALB Cloud by ID")
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbCloud
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponse := &types.NsxtAlbCloud{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, typeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NsxtAlbCloud{
+		NsxtAlbCloud: typeResponse,
+		client:       client,
+	}, nil
+}
+
+// CreateAlbCloud creates NSX-T ALB Cloud based on supplied configuration. It consumes one of
+// NsxtAlbImportableCloud by specifying its ID in LoadBalancerCloudBacking.BackingId.
+func (vcdClient *VCDClient) CreateAlbCloud(ctx context.Context, albCloudConfig *types.NsxtAlbCloud) (*NsxtAlbCloud, error) {
+	client := &vcdClient.Client
+	if !client.IsSysAdmin {
+		return nil, fmt.Errorf("creating NSX-T ALB Clouds requires System user")
+	}
+
+	if err := validateCreateAlbCloud(albCloudConfig); err != nil {
+		return nil, err
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbCloud
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &NsxtAlbCloud{
+		NsxtAlbCloud: &types.NsxtAlbCloud{},
+		client:       client,
+	}
+
+	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, albCloudConfig, returnObject.NsxtAlbCloud)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T ALB Cloud: %s", err)
+	}
+
+	return returnObject, nil
+}
+
+// Delete removes NSX-T ALB Cloud. The underlying importable cloud becomes available for import again.
+func (nsxtAlbCloud *NsxtAlbCloud) Delete(ctx context.Context) error {
+	client := nsxtAlbCloud.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbCloud
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if nsxtAlbCloud.NsxtAlbCloud.ID == "" {
+		return fmt.Errorf("cannot delete NSX-T ALB Cloud without ID")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, nsxtAlbCloud.NsxtAlbCloud.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting NSX-T ALB Cloud: %s", err)
+	}
+
+	return nil
+}
+
+// getAllAlbImportableClouds is a private parent for wrapped functions:
+// func (vcdClient *VCDClient) GetAllAlbImportableClouds(parentAlbControllerUrn string, queryParameters url.Values) ([]*NsxtAlbImportableCloud, error)
+// func (nsxtAlbController *NsxtAlbController) GetAlbImportableClouds(queryParameters url.Values) ([]*NsxtAlbImportableCloud, error)
+func getAllAlbImportableClouds(ctx context.Context, client *Client, parentAlbControllerUrn string, queryParameters url.Values) ([]*NsxtAlbImportableCloud, error) {
+	if parentAlbControllerUrn == "" {
+		return nil, fmt.Errorf("parent ALB Controller ID is required")
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbImportableClouds
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Importable clouds must be listed in the context of a particular ALB Controller
+	queryParams := queryParameterFilterAnd("_context=="+parentAlbControllerUrn, queryParameters)
+
+	typeResponses := []*types.NsxtAlbImportableCloud{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParams, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedResponses := make([]*NsxtAlbImportableCloud, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtAlbImportableCloud{
+			NsxtAlbImportableCloud: typeResponses[sliceIndex],
+			client:                 client,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+func validateCreateAlbCloud(albCloudConfig *types.NsxtAlbCloud) error {
+	if albCloudConfig == nil {
+		return fmt.Errorf("NSX-T ALB Cloud configuration cannot be nil")
+	}
+
+	if albCloudConfig.Name == "" {
+		return fmt.Errorf("NSX-T ALB Cloud Name cannot be empty")
+	}
+
+	if albCloudConfig.LoadBalancerCloudBacking.BackingId == "" {
+		return fmt.Errorf("NSX-T ALB Cloud LoadBalancerCloudBacking.BackingId (importable cloud ID) cannot be empty")
+	}
+
+	if albCloudConfig.LoadBalancerCloudBacking.LoadBalancerControllerRef.ID == "" {
+		return fmt.Errorf("NSX-T ALB Cloud LoadBalancerCloudBacking.LoadBalancerControllerRef.ID cannot be empty")
+	}
+
+	if albCloudConfig.NetworkPoolRef == nil || albCloudConfig.NetworkPoolRef.ID == "" {
+		return fmt.Errorf("NSX-T ALB Cloud NetworkPoolRef.ID cannot be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,296 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// NsxtAlbPool defines configuration of a single NSX-T ALB Pool. Pools contain reference to member IPs which will be
+// serving traffic for a virtual service (NsxtAlbVirtualService).
+type NsxtAlbPool struct {
+	NsxtAlbPool *types.NsxtAlbPool
+	client      *Client
+}
+
+// GetAllAlbPoolSummaries retrieves partial information for type `NsxtAlbPool`, but it is the only way to retrieve
+// all ALB Pools in an Edge Gateway. Use GetAllAlbPools to retrieve complete objects.
+func (egw *NsxtEdgeGateway) GetAllAlbPoolSummaries(ctx context.Context, queryParameters url.Values) ([]*NsxtAlbPool, error) {
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbPoolSummaries
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.NsxtAlbPool{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedResponses := make([]*NsxtAlbPool, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtAlbPool{
+			NsxtAlbPool: typeResponses[sliceIndex],
+			client:      client,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetAllAlbPools uses GetAllAlbPoolSummaries behind the scenes and then fetches complete child objects one by one.
+//
+// Note. Because it retrieves every pool individually, it is much more expensive than GetAllAlbPoolSummaries.
+func (egw *NsxtEdgeGateway) GetAllAlbPools(ctx context.Context, queryParameters url.Values) ([]*NsxtAlbPool, error) {
+	allPoolSummaries, err := egw.GetAllAlbPoolSummaries(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving all ALB Pool summaries: %s", err)
+	}
+
+	allPools := make([]*NsxtAlbPool, len(allPoolSummaries))
+	for index, poolSummary := range allPoolSummaries {
+		allPools[index], err = egw.GetAlbPoolById(ctx, poolSummary.NsxtAlbPool.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving complete ALB Pool: %s", err)
+		}
+	}
+
+	return allPools, nil
+}
+
+// GetAlbPoolByName retrieves ALB Pool by Name in this Edge Gateway
+func (egw *NsxtEdgeGateway) GetAlbPoolByName(ctx context.Context, name string) (*NsxtAlbPool, error) {
+	queryParameters := url.Values{}
+	queryParameters.Add("filter", "name=="+name)
+
+	allAlbPools, err := egw.GetAllAlbPoolSummaries(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving ALB Pool with Name '%s': %s", name, err)
+	}
+
+	if len(allAlbPools) == 0 {
+		return nil, fmt.Errorf("%s: could not find ALB Pool with Name '%s'", ErrorEntityNotFound, name)
+	}
+
+	if len(allAlbPools) > 1 {
+		return nil, fmt.Errorf("found more than 1 ALB Pool with Name '%s'", name)
+	}
+
+	// Summaries contain only partial data, therefore the complete object is retrieved by ID
+	return egw.GetAlbPoolById(ctx, allAlbPools[0].NsxtAlbPool.ID)
+}
+
+// GetAlbPoolById retrieves complete ALB Pool by ID
+func (egw *NsxtEdgeGateway) GetAlbPoolById(ctx context.Context, id string) (*NsxtAlbPool, error) {
+	if id == "" {
+		return nil, fmt.Errorf("ID is required to lookup NSX-T ALB Pool by ID")
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbPools
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponse := &types.NsxtAlbPool{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, typeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if typeResponse.GatewayRef.ID != egw.EdgeGateway.ID {
+		return nil, fmt.Errorf("%s: ALB Pool with ID '%s' does not belong to Edge Gateway '%s'",
+			ErrorEntityNotFound, id, egw.EdgeGateway.ID)
+	}
+
+	return &NsxtAlbPool{
+		NsxtAlbPool: typeResponse,
+		client:      client,
+	}, nil
+}
+
+// CreateAlbPool creates NSX-T ALB Pool in this Edge Gateway based on supplied albPoolConfig. GatewayRef is set
+// automatically. Name must be set and all members must have an IP address.
+func (egw *NsxtEdgeGateway) CreateAlbPool(ctx context.Context, albPoolConfig *types.NsxtAlbPool) (*NsxtAlbPool, error) {
+	if albPoolConfig == nil {
+		return nil, fmt.Errorf("ALB Pool configuration cannot be nil")
+	}
+
+	// Pool always belongs to the Edge Gateway it is created in
+	albPoolConfig.GatewayRef = types.OpenApiReference{ID: egw.EdgeGateway.ID}
+
+	if err := validateCreateAlbPool(albPoolConfig); err != nil {
+		return nil, err
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbPools
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &NsxtAlbPool{
+		NsxtAlbPool: &types.NsxtAlbPool{},
+		client:      client,
+	}
+
+	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, albPoolConfig, returnObject.NsxtAlbPool)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T ALB Pool: %s", err)
+	}
+
+	return returnObject, nil
+}
+
+// Update updates NSX-T ALB Pool based on supplied albPoolConfig. ID must be set.
+func (nsxtAlbPool *NsxtAlbPool) Update(ctx context.Context, albPoolConfig *types.NsxtAlbPool) (*NsxtAlbPool, error) {
+	if albPoolConfig == nil {
+		return nil, fmt.Errorf("ALB Pool configuration cannot be nil")
+	}
+
+	if albPoolConfig.ID == "" {
+		return nil, fmt.Errorf("cannot update NSX-T ALB Pool without ID")
+	}
+
+	if err := validateUpdateAlbPool(albPoolConfig); err != nil {
+		return nil, err
+	}
+
+	client := nsxtAlbPool.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbPools
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, albPoolConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responseAlbPool := &NsxtAlbPool{
+		NsxtAlbPool: &types.NsxtAlbPool{},
+		client:      client,
+	}
+
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, albPoolConfig, responseAlbPool.NsxtAlbPool)
+	if err != nil {
+		return nil, fmt.Errorf("error updating NSX-T ALB Pool: %s", err)
+	}
+
+	return responseAlbPool, nil
+}
+
+// Delete deletes NSX-T ALB Pool
+func (nsxtAlbPool *NsxtAlbPool) Delete(ctx context.Context) error {
+	client := nsxtAlbPool.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbPools
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if nsxtAlbPool.NsxtAlbPool.ID == "" {
+		return fmt.Errorf("cannot delete NSX-T ALB Pool without ID")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, nsxtAlbPool.NsxtAlbPool.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting NSX-T ALB Pool: %s", err)
+	}
+
+	return nil
+}
+
+// albPoolHealthMonitorTypes lists health monitor types accepted by NSX-T ALB Pools
+var albPoolHealthMonitorTypes = []string{"HTTP", "HTTPS", "TCP", "UDP", "PING"}
+
+// albPoolPersistenceTypes lists persistence profile types accepted by NSX-T ALB Pools and whether they require Value
+var albPoolPersistenceTypes = map[string]bool{
+	"CLIENT_IP":          false,
+	"HTTP_COOKIE":        true,
+	"CUSTOM_HTTP_HEADER": true,
+	"APP_COOKIE":         true,
+	"TLS":                false,
+}
+
+func validateCreateAlbPool(albPoolConfig *types.NsxtAlbPool) error {
+	if albPoolConfig.Name == "" {
+		return fmt.Errorf("NSX-T ALB Pool Name cannot be empty")
+	}
+
+	if albPoolConfig.GatewayRef.ID == "" {
+		return fmt.Errorf("NSX-T ALB Pool GatewayRef.ID cannot be empty")
+	}
+
+	for _, member := range albPoolConfig.Members {
+		if net.ParseIP(member.IpAddress) == nil {
+			return fmt.Errorf("NSX-T ALB Pool Member must have a valid IpAddress set. Got '%s'", member.IpAddress)
+		}
+		if member.Port < 0 || member.Port > 65535 {
+			return fmt.Errorf("NSX-T ALB Pool Member '%s' has invalid Port %d", member.IpAddress, member.Port)
+		}
+	}
+
+	for _, healthMonitor := range albPoolConfig.HealthMonitors {
+		if !stringInSlice(healthMonitor.Type, albPoolHealthMonitorTypes) {
+			return fmt.Errorf("NSX-T ALB Pool Health Monitor Type must be one of %v. Got '%s'",
+				albPoolHealthMonitorTypes, healthMonitor.Type)
+		}
+	}
+
+	if albPoolConfig.PersistenceProfile != nil {
+		valueRequired, ok := albPoolPersistenceTypes[albPoolConfig.PersistenceProfile.Type]
+		if !ok {
+			return fmt.Errorf("NSX-T ALB Pool Persistence Profile Type '%s' is not supported",
+				albPoolConfig.PersistenceProfile.Type)
+		}
+		if valueRequired && albPoolConfig.PersistenceProfile.Value == "" {
+			return fmt.Errorf("NSX-T ALB Pool Persistence Profile Type '%s' requires Value",
+				albPoolConfig.PersistenceProfile.Type)
+		}
+	}
+
+	if albPoolConfig.CommonNameCheckEnabled != nil && *albPoolConfig.CommonNameCheckEnabled &&
+		len(albPoolConfig.CaCertificateRefs) == 0 {
+		return fmt.Errorf("NSX-T ALB Pool CommonNameCheckEnabled requires CaCertificateRefs")
+	}
+
+	return nil
+}
+
+func validateUpdateAlbPool(albPoolConfig *types.NsxtAlbPool) error {
+	// Update and create have the same requirements for now
+	return validateCreateAlbPool(albPoolConfig)
+}
@@ -0,0 +1,497 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// NsxtAlbImportableServiceEngineGroups provides capability to list all Importable Service Engine Groups available in
+// ALB Controller so that they can be consumed by NsxtAlbServiceEngineGroup
+type NsxtAlbImportableServiceEngineGroups struct {
+	NsxtAlbImportableServiceEngineGroups *types.NsxtAlbImportableServiceEngineGroups
+	client                               *Client
+}
+
+// NsxtAlbServiceEngineGroup provides virtual service management capabilities for tenants. This entity can be created
+// by referencing a backing importable service engine group - NsxtAlbImportableServiceEngineGroups.
+type NsxtAlbServiceEngineGroup struct {
+	NsxtAlbServiceEngineGroup *types.NsxtAlbServiceEngineGroup
+	client                    *Client
+}
+
+// NsxtAlbServiceEngineGroupAssignment handles Service Engine Group assignment to NSX-T Edge Gateway
+type NsxtAlbServiceEngineGroupAssignment struct {
+	NsxtAlbServiceEngineGroupAssignment *types.NsxtAlbServiceEngineGroupAssignment
+	client                              *Client
+}
+
+// GetAllAlbImportableServiceEngineGroups lists all Importable Service Engine Groups available in ALB Controller for a
+// particular NSX-T ALB Cloud (specified by parentAlbCloudUrn)
+func (vcdClient *VCDClient) GetAllAlbImportableServiceEngineGroups(ctx context.Context, parentAlbCloudUrn string, queryParameters url.Values) ([]*NsxtAlbImportableServiceEngineGroups, error) {
+	if parentAlbCloudUrn == "" {
+		return nil, fmt.Errorf("parent ALB Cloud ID is required")
+	}
+
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbImportableServiceEngineGroups
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Importable Service Engine Groups must be listed in the context of a particular NSX-T ALB Cloud
+	queryParams := queryParameterFilterAnd("_context=="+parentAlbCloudUrn, queryParameters)
+
+	typeResponses := []*types.NsxtAlbImportableServiceEngineGroups{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParams, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedResponses := make([]*NsxtAlbImportableServiceEngineGroups, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtAlbImportableServiceEngineGroups{
+			NsxtAlbImportableServiceEngineGroups: typeResponses[sliceIndex],
+			client:                               client,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetAlbImportableServiceEngineGroupByName returns importable Service Engine Group by Name for a particular NSX-T ALB
+// Cloud (specified by parentAlbCloudUrn)
+//
+// Note. Filtering is performed on client side because the endpoint does not support filtering by name.
+func (vcdClient *VCDClient) GetAlbImportableServiceEngineGroupByName(ctx context.Context, parentAlbCloudUrn, name string) (*NsxtAlbImportableServiceEngineGroups, error) {
+	albImportableSeGroups, err := vcdClient.GetAllAlbImportableServiceEngineGroups(ctx, parentAlbCloudUrn, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error finding NSX-T ALB Importable Service Engine Group by Name '%s': %s", name, err)
+	}
+
+	filteredAlbImportableSeGroups := make([]*NsxtAlbImportableServiceEngineGroups, 0)
+	for _, importableSeGroup := range albImportableSeGroups {
+		if importableSeGroup.NsxtAlbImportableServiceEngineGroups.DisplayName == name {
+			filteredAlbImportableSeGroups = append(filteredAlbImportableSeGroups, importableSeGroup)
+		}
+	}
+
+	if len(filteredAlbImportableSeGroups) == 0 {
+		return nil, fmt.Errorf("%s: could not find NSX-T ALB Importable Service Engine Group by Name '%s'",
+			ErrorEntityNotFound, name)
+	}
+
+	if len(filteredAlbImportableSeGroups) > 1 {
+		return nil, fmt.Errorf("found more than 1 NSX-T ALB Importable Service Engine Group by Name '%s'", name)
+	}
+
+	return filteredAlbImportableSeGroups[0], nil
+}
+
+// GetAllAlbServiceEngineGroups returns all Service Engine Groups. Optional context parameter (e.g. NSX-T Edge Gateway
+// ID) can be used to retrieve only Service Engine Groups which are available in that context.
+func (vcdClient *VCDClient) GetAllAlbServiceEngineGroups(ctx context.Context, contextUrn string, queryParameters url.Values) ([]*NsxtAlbServiceEngineGroup, error) {
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbServiceEngineGroups
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := copyOrNewUrlValues(queryParameters)
+	if contextUrn != "" {
+		queryParams = queryParameterFilterAnd("_context=="+contextUrn, queryParams)
+	}
+
+	typeResponses := []*types.NsxtAlbServiceEngineGroup{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParams, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedResponses := make([]*NsxtAlbServiceEngineGroup, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtAlbServiceEngineGroup{
+			NsxtAlbServiceEngineGroup: typeResponses[sliceIndex],
+			client:                    client,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetAlbServiceEngineGroupByName returns NSX-T ALB Service Engine Group by Name. Optional context parameter can be
+// used to limit the lookup.
+func (vcdClient *VCDClient) GetAlbServiceEngineGroupByName(ctx context.Context, contextUrn, name string) (*NsxtAlbServiceEngineGroup, error) {
+	queryParameters := url.Values{}
+	queryParameters.Add("filter", "name=="+name)
+
+	albSeGroups, err := vcdClient.GetAllAlbServiceEngineGroups(ctx, contextUrn, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error reading NSX-T ALB Service Engine Group with Name '%s': %s", name, err)
+	}
+
+	if len(albSeGroups) == 0 {
+		return nil, fmt.Errorf("%s: could not find NSX-T ALB Service Engine Group with Name '%s'",
+			ErrorEntityNotFound, name)
+	}
+
+	if len(albSeGroups) > 1 {
+		return nil, fmt.Errorf("found more than 1 NSX-T ALB Service Engine Group with Name '%s'", name)
+	}
+
+	return albSeGroups[0], nil
+}
+
+// GetAlbServiceEngineGroupById returns NSX-T ALB Service Engine Group by ID
+func (vcdClient *VCDClient) GetAlbServiceEngineGroupById(ctx context.Context, id string) (*NsxtAlbServiceEngineGroup, error) {
+	client := &vcdClient.Client
+	if id == "" {
+		return nil, fmt.Errorf("ID is required to lookup NSX-T ALB Service Engine Group by ID")
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbServiceEngineGroups
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponse := &types.NsxtAlbServiceEngineGroup{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, typeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NsxtAlbServiceEngineGroup{
+		NsxtAlbServiceEngineGroup: typeResponse,
+		client:                    client,
+	}, nil
+}
+
+// CreateNsxtAlbServiceEngineGroup creates NSX-T ALB Service Engine Group by consuming one of
+// NsxtAlbImportableServiceEngineGroups (its ID must be set in ServiceEngineGroupBacking.BackingId)
+func (vcdClient *VCDClient) CreateNsxtAlbServiceEngineGroup(ctx context.Context, albServiceEngineGroup *types.NsxtAlbServiceEngineGroup) (*NsxtAlbServiceEngineGroup, error) {
+	client := &vcdClient.Client
+	if !client.IsSysAdmin {
+		return nil, fmt.Errorf("creating NSX-T ALB Service Engine Group requires System user")
+	}
+
+	if err := validateCreateAlbServiceEngineGroup(albServiceEngineGroup); err != nil {
+		return nil, err
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbServiceEngineGroups
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &NsxtAlbServiceEngineGroup{
+		NsxtAlbServiceEngineGroup: &types.NsxtAlbServiceEngineGroup{},
+		client:                    client,
+	}
+
+	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, albServiceEngineGroup, returnObject.NsxtAlbServiceEngineGroup)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T ALB Service Engine Group: %s", err)
+	}
+
+	return returnObject, nil
+}
+
+// Update updates existing ALB Service Engine Group with new supplied albSEGroupConfig configuration
+func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) Update(ctx context.Context, albSEGroupConfig *types.NsxtAlbServiceEngineGroup) (*NsxtAlbServiceEngineGroup, error) {
+	client := nsxtAlbServiceEngineGroup.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbServiceEngineGroups
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if albSEGroupConfig.ID == "" {
+		return nil, fmt.Errorf("cannot update NSX-T ALB Service Engine Group without ID")
+	}
+
+	if err := validateCreateAlbServiceEngineGroup(albSEGroupConfig); err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, albSEGroupConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responseAlbController := &NsxtAlbServiceEngineGroup{
+		NsxtAlbServiceEngineGroup: &types.NsxtAlbServiceEngineGroup{},
+		client:                    client,
+	}
+
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, albSEGroupConfig, responseAlbController.NsxtAlbServiceEngineGroup)
+	if err != nil {
+		return nil, fmt.Errorf("error updating NSX-T ALB Service Engine Group: %s", err)
+	}
+
+	return responseAlbController, nil
+}
+
+// Delete deletes NSX-T ALB Service Engine Group.
+func (nsxtAlbServiceEngineGroup *NsxtAlbServiceEngineGroup) Delete(ctx context.Context) error {
+	client := nsxtAlbServiceEngineGroup.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbServiceEngineGroups
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup.ID == "" {
+		return fmt.Errorf("cannot delete NSX-T ALB Service Engine Group without ID")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, nsxtAlbServiceEngineGroup.NsxtAlbServiceEngineGroup.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting NSX-T ALB Service Engine Group: %s", err)
+	}
+
+	return nil
+}
+
+// GetAllAlbServiceEngineGroupAssignments retrieves all NSX-T ALB Service Engine Group assignments. Query parameters
+// can be used to filter by Edge Gateway (e.g. "gatewayRef.id==urn:vcloud:gateway:...") or by Service Engine Group
+// (e.g. "serviceEngineGroupRef.id==urn:vcloud:serviceEngineGroup:...").
+func (vcdClient *VCDClient) GetAllAlbServiceEngineGroupAssignments(ctx context.Context, queryParameters url.Values) ([]*NsxtAlbServiceEngineGroupAssignment, error) {
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbServiceEngineGroupAssignments
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.NsxtAlbServiceEngineGroupAssignment{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedResponses := make([]*NsxtAlbServiceEngineGroupAssignment, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtAlbServiceEngineGroupAssignment{
+			NsxtAlbServiceEngineGroupAssignment: typeResponses[sliceIndex],
+			client:                              client,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetAlbServiceEngineGroupAssignmentById retrieves NSX-T ALB Service Engine Group assignment by ID
+func (vcdClient *VCDClient) GetAlbServiceEngineGroupAssignmentById(ctx context.Context, id string) (*NsxtAlbServiceEngineGroupAssignment, error) {
+	client := &vcdClient.Client
+	if id == "" {
+		return nil, fmt.Errorf("ID is required to lookup NSX-T ALB Service Engine Group Assignment by ID")
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbServiceEngineGroupAssignments
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponse := &types.NsxtAlbServiceEngineGroupAssignment{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, typeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NsxtAlbServiceEngineGroupAssignment{
+		NsxtAlbServiceEngineGroupAssignment: typeResponse,
+		client:                              client,
+	}, nil
+}
+
+// CreateAlbServiceEngineGroupAssignment assigns Service Engine Group to NSX-T Edge Gateway
+func (vcdClient *VCDClient) CreateAlbServiceEngineGroupAssignment(ctx context.Context, assignmentConfig *types.NsxtAlbServiceEngineGroupAssignment) (*NsxtAlbServiceEngineGroupAssignment, error) {
+	client := &vcdClient.Client
+	if !client.IsSysAdmin {
+		return nil, fmt.Errorf("creating NSX-T ALB Service Engine Group Assignment requires System user")
+	}
+
+	if err := validateAlbServiceEngineGroupAssignment(assignmentConfig); err != nil {
+		return nil, err
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbServiceEngineGroupAssignments
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &NsxtAlbServiceEngineGroupAssignment{
+		NsxtAlbServiceEngineGroupAssignment: &types.NsxtAlbServiceEngineGroupAssignment{},
+		client:                              client,
+	}
+
+	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, assignmentConfig, returnObject.NsxtAlbServiceEngineGroupAssignment)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T ALB Service Engine Group Assignment: %s", err)
+	}
+
+	return returnObject, nil
+}
+
+// Update updates NSX-T ALB Service Engine Group Assignment (e.g. changes MinVirtualServices, MaxVirtualServices for
+// SHARED Service Engine Groups)
+func (assignment *NsxtAlbServiceEngineGroupAssignment) Update(ctx context.Context, assignmentConfig *types.NsxtAlbServiceEngineGroupAssignment) (*NsxtAlbServiceEngineGroupAssignment, error) {
+	client := assignment.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbServiceEngineGroupAssignments
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if assignmentConfig.ID == "" {
+		return nil, fmt.Errorf("cannot update NSX-T ALB Service Engine Group Assignment without ID")
+	}
+
+	if err := validateAlbServiceEngineGroupAssignment(assignmentConfig); err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, assignmentConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responseAssignment := &NsxtAlbServiceEngineGroupAssignment{
+		NsxtAlbServiceEngineGroupAssignment: &types.NsxtAlbServiceEngineGroupAssignment{},
+		client:                              client,
+	}
+
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, assignmentConfig, responseAssignment.NsxtAlbServiceEngineGroupAssignment)
+	if err != nil {
+		return nil, fmt.Errorf("error updating NSX-T ALB Service Engine Group Assignment: %s", err)
+	}
+
+	return responseAssignment, nil
+}
+
+// Delete removes Service Engine Group assignment from NSX-T Edge Gateway
+func (assignment *NsxtAlbServiceEngineGroupAssignment) Delete(ctx context.Context) error {
+	client := assignment.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbServiceEngineGroupAssignments
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if assignment.NsxtAlbServiceEngineGroupAssignment.ID == "" {
+		return fmt.Errorf("cannot delete NSX-T ALB Service Engine Group Assignment without ID")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, assignment.NsxtAlbServiceEngineGroupAssignment.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting NSX-T ALB Service Engine Group Assignment: %s", err)
+	}
+
+	return nil
+}
+
+func validateCreateAlbServiceEngineGroup(albServiceEngineGroup *types.NsxtAlbServiceEngineGroup) error {
+	if albServiceEngineGroup == nil {
+		return fmt.Errorf("NSX-T ALB Service Engine Group configuration cannot be nil")
+	}
+
+	if albServiceEngineGroup.Name == "" {
+		return fmt.Errorf("NSX-T ALB Service Engine Group Name cannot be empty")
+	}
+
+	if albServiceEngineGroup.ServiceEngineGroupBacking.BackingId == "" {
+		return fmt.Errorf("NSX-T ALB Service Engine Group ServiceEngineGroupBacking.BackingId cannot be empty")
+	}
+
+	if albServiceEngineGroup.ServiceEngineGroupBacking.LoadBalancerCloudRef == nil ||
+		albServiceEngineGroup.ServiceEngineGroupBacking.LoadBalancerCloudRef.ID == "" {
+		return fmt.Errorf("NSX-T ALB Service Engine Group ServiceEngineGroupBacking.LoadBalancerCloudRef.ID cannot be empty")
+	}
+
+	if albServiceEngineGroup.ReservationType != "" && albServiceEngineGroup.ReservationType != "DEDICATED" &&
+		albServiceEngineGroup.ReservationType != "SHARED" {
+		return fmt.Errorf("NSX-T ALB Service Engine Group ReservationType must be one of 'DEDICATED', 'SHARED'. Got '%s'",
+			albServiceEngineGroup.ReservationType)
+	}
+
+	return nil
+}
+
+func validateAlbServiceEngineGroupAssignment(assignmentConfig *types.NsxtAlbServiceEngineGroupAssignment) error {
+	if assignmentConfig == nil {
+		return fmt.Errorf("NSX-T ALB Service Engine Group Assignment configuration cannot be nil")
+	}
+
+	if assignmentConfig.GatewayRef == nil || assignmentConfig.GatewayRef.ID == "" {
+		return fmt.Errorf("NSX-T ALB Service Engine Group Assignment GatewayRef.ID cannot be empty")
+	}
+
+	if assignmentConfig.ServiceEngineGroupRef == nil || assignmentConfig.ServiceEngineGroupRef.ID == "" {
+		return fmt.Errorf("NSX-T ALB Service Engine Group Assignment ServiceEngineGroupRef.ID cannot be empty")
+	}
+
+	if assignmentConfig.MinVirtualServices != nil && assignmentConfig.MaxVirtualServices != nil &&
+		*assignmentConfig.MinVirtualServices > *assignmentConfig.MaxVirtualServices {
+		return fmt.Errorf("NSX-T ALB Service Engine Group Assignment MinVirtualServices (%d) cannot be greater than "+
+			"MaxVirtualServices (%d)", *assignmentConfig.MinVirtualServices, *assignmentConfig.MaxVirtualServices)
+	}
+
+	return nil
+}
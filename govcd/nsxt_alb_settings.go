@@ -0,0 +1,79 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// GetAlbSettings retrieves NSX-T ALB settings for a particular Edge Gateway
+func (egw *NsxtEdgeGateway) GetAlbSettings(ctx context.Context) (*types.NsxtAlbConfig, error) {
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbEdgeGateway
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponse := &types.NsxtAlbConfig{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, typeResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T ALB settings for Edge Gateway '%s': %s",
+			egw.EdgeGateway.Name, err)
+	}
+
+	return typeResponse, nil
+}
+
+// UpdateAlbSettings updates NSX-T ALB settings for a particular Edge Gateway. It can be used to enable ALB on an
+// Edge Gateway (types.NsxtAlbConfig.Enabled=true) and set the service network definition.
+func (egw *NsxtEdgeGateway) UpdateAlbSettings(ctx context.Context, config *types.NsxtAlbConfig) (*types.NsxtAlbConfig, error) {
+	if !egw.client.IsSysAdmin {
+		return nil, fmt.Errorf("only System Administrator can update Edge Gateway ALB settings")
+	}
+
+	if config == nil {
+		return nil, fmt.Errorf("NSX-T ALB settings cannot be nil")
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbEdgeGateway
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponse := &types.NsxtAlbConfig{}
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, config, typeResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error updating NSX-T ALB settings for Edge Gateway '%s': %s",
+			egw.EdgeGateway.Name, err)
+	}
+
+	return typeResponse, nil
+}
+
+// DisableAlb is a shortcut wrapping UpdateAlbSettings which disables ALB configuration on an Edge Gateway
+func (egw *NsxtEdgeGateway) DisableAlb(ctx context.Context) error {
+	_, err := egw.UpdateAlbSettings(ctx, &types.NsxtAlbConfig{Enabled: false})
+	if err != nil {
+		return fmt.Errorf("error disabling NSX-T ALB: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+// +build network nsxt functional openapi ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"fmt"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+// Test_NsxtAlb tests the complete NSX-T ALB setup flow:
+// * Provider part - ALB Controller, ALB Cloud, Service Engine Group, Service Engine Group assignment to Edge Gateway
+// * Tenant part - ALB Pool and ALB Virtual Service in an ALB enabled NSX-T Edge Gateway
+func (vcd *TestVCD) Test_NsxtAlb(check *C) {
+	if vcd.skipAdminTests {
+		check.Skip(fmt.Sprintf(TestRequiresSysAdminPrivileges, check.TestName()))
+	}
+	skipNoNsxtAlbConfiguration(vcd, check)
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointAlbController)
+
+	// Provider part
+	controller := createAlbController(vcd, check)
+	albCloud := createAlbCloud(vcd, check, controller)
+	seGroup := createAlbServiceEngineGroup(vcd, check, albCloud)
+
+	edge, err := vcd.nsxtVdc.GetNsxtEdgeGatewayByName(ctx, vcd.config.VCD.Nsxt.EdgeGateway)
+	check.Assert(err, IsNil)
+
+	albSettings, err := edge.UpdateAlbSettings(ctx, &types.NsxtAlbConfig{Enabled: true})
+	check.Assert(err, IsNil)
+	check.Assert(albSettings.Enabled, Equals, true)
+
+	assignment, err := vcd.client.CreateAlbServiceEngineGroupAssignment(ctx, &types.NsxtAlbServiceEngineGroupAssignment{
+		GatewayRef:            &types.OpenApiReference{ID: edge.EdgeGateway.ID},
+		ServiceEngineGroupRef: &types.OpenApiReference{ID: seGroup.NsxtAlbServiceEngineGroup.ID},
+	})
+	check.Assert(err, IsNil)
+	PrependToCleanupListOpenApi(assignment.NsxtAlbServiceEngineGroupAssignment.ID, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointAlbServiceEngineGroupAssignments+assignment.NsxtAlbServiceEngineGroupAssignment.ID)
+
+	// Tenant part
+	poolConfig := &types.NsxtAlbPool{
+		Name:    check.TestName(),
+		Enabled: takeBoolPointer(true),
+		Members: []types.NsxtAlbPoolMember{
+			{Enabled: true, IpAddress: "1.1.1.1"},
+			{Enabled: false, IpAddress: "1.1.1.2", Port: 8080},
+		},
+		HealthMonitors:     []types.NsxtAlbPoolHealthMonitor{{Type: "HTTP"}},
+		PersistenceProfile: &types.NsxtAlbPoolPersistenceProfile{Type: "CLIENT_IP"},
+	}
+	pool, err := edge.CreateAlbPool(ctx, poolConfig)
+	check.Assert(err, IsNil)
+	check.Assert(pool.NsxtAlbPool.Name, Equals, poolConfig.Name)
+	check.Assert(len(pool.NsxtAlbPool.Members), Equals, 2)
+	PrependToCleanupListOpenApi(pool.NsxtAlbPool.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointAlbPools+pool.NsxtAlbPool.ID)
+
+	poolByName, err := edge.GetAlbPoolByName(ctx, pool.NsxtAlbPool.Name)
+	check.Assert(err, IsNil)
+	check.Assert(poolByName.NsxtAlbPool.ID, Equals, pool.NsxtAlbPool.ID)
+
+	pool.NsxtAlbPool.Algorithm = "ROUND_ROBIN"
+	updatedPool, err := pool.Update(ctx, pool.NsxtAlbPool)
+	check.Assert(err, IsNil)
+	check.Assert(updatedPool.NsxtAlbPool.Algorithm, Equals, "ROUND_ROBIN")
+
+	virtualServiceConfig := &types.NsxtAlbVirtualService{
+		Name:                  check.TestName(),
+		Enabled:               takeBoolPointer(true),
+		ApplicationProfile:    types.NsxtAlbVirtualServiceApplicationProfile{SystemDefined: true, Type: "HTTP"},
+		LoadBalancerPoolRef:   types.OpenApiReference{ID: pool.NsxtAlbPool.ID},
+		ServiceEngineGroupRef: types.OpenApiReference{ID: seGroup.NsxtAlbServiceEngineGroup.ID},
+		ServicePorts:          []types.NsxtAlbVirtualServicePort{{PortStart: takeIntAddress(80)}},
+		VirtualIpAddress:      edge.EdgeGateway.EdgeGatewayUplinks[0].Subnets.Values[0].PrimaryIP,
+	}
+	virtualService, err := edge.CreateAlbVirtualService(ctx, virtualServiceConfig)
+	check.Assert(err, IsNil)
+	check.Assert(virtualService.NsxtAlbVirtualService.Name, Equals, virtualServiceConfig.Name)
+	PrependToCleanupListOpenApi(virtualService.NsxtAlbVirtualService.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointAlbVirtualServices+virtualService.NsxtAlbVirtualService.ID)
+
+	allVirtualServices, err := edge.GetAllAlbVirtualServices(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(allVirtualServices) > 0, Equals, true)
+
+	// Cleanup in reverse order
+	err = virtualService.Delete(ctx)
+	check.Assert(err, IsNil)
+	err = pool.Delete(ctx)
+	check.Assert(err, IsNil)
+	err = assignment.Delete(ctx)
+	check.Assert(err, IsNil)
+	err = edge.DisableAlb(ctx)
+	check.Assert(err, IsNil)
+	err = seGroup.Delete(ctx)
+	check.Assert(err, IsNil)
+	err = albCloud.Delete(ctx)
+	check.Assert(err, IsNil)
+	err = controller.Delete(ctx)
+	check.Assert(err, IsNil)
+}
+
+func createAlbController(vcd *TestVCD, check *C) *NsxtAlbController {
+	controller, err := vcd.client.CreateNsxtAlbController(ctx, &types.NsxtAlbController{
+		Name:        check.TestName(),
+		Description: "NSX-T ALB Controller for testing",
+		Url:         vcd.config.VCD.Nsxt.NsxtAlbControllerUrl,
+		Username:    vcd.config.VCD.Nsxt.NsxtAlbControllerUser,
+		Password:    vcd.config.VCD.Nsxt.NsxtAlbControllerPassword,
+		LicenseType: "ENTERPRISE",
+	})
+	check.Assert(err, IsNil)
+	PrependToCleanupListOpenApi(controller.NsxtAlbController.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointAlbController+controller.NsxtAlbController.ID)
+
+	controllerByUrl, err := vcd.client.GetAlbControllerByUrl(ctx, vcd.config.VCD.Nsxt.NsxtAlbControllerUrl)
+	check.Assert(err, IsNil)
+	check.Assert(controllerByUrl.NsxtAlbController.ID, Equals, controller.NsxtAlbController.ID)
+
+	return controller
+}
+
+func createAlbCloud(vcd *TestVCD, check *C, controller *NsxtAlbController) *NsxtAlbCloud {
+	importableCloud, err := vcd.client.GetAlbImportableCloudByName(ctx, controller.NsxtAlbController.ID,
+		vcd.config.VCD.Nsxt.NsxtAlbImportableCloud)
+	check.Assert(err, IsNil)
+
+	albCloud, err := vcd.client.CreateAlbCloud(ctx, &types.NsxtAlbCloud{
+		Name: check.TestName(),
+		LoadBalancerCloudBacking: types.NsxtAlbCloudBacking{
+			BackingId:                 importableCloud.NsxtAlbImportableCloud.ID,
+			LoadBalancerControllerRef: types.OpenApiReference{ID: controller.NsxtAlbController.ID},
+		},
+		NetworkPoolRef: importableCloud.NsxtAlbImportableCloud.NetworkPoolRef,
+	})
+	check.Assert(err, IsNil)
+	PrependToCleanupListOpenApi(albCloud.NsxtAlbCloud.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointAlbCloud+albCloud.NsxtAlbCloud.ID)
+
+	return albCloud
+}
+
+func createAlbServiceEngineGroup(vcd *TestVCD, check *C, albCloud *NsxtAlbCloud) *NsxtAlbServiceEngineGroup {
+	importableSeGroup, err := vcd.client.GetAlbImportableServiceEngineGroupByName(ctx, albCloud.NsxtAlbCloud.ID,
+		vcd.config.VCD.Nsxt.NsxtAlbServiceEngineGroup)
+	check.Assert(err, IsNil)
+
+	seGroup, err := vcd.client.CreateNsxtAlbServiceEngineGroup(ctx, &types.NsxtAlbServiceEngineGroup{
+		Name:            check.TestName(),
+		ReservationType: "DEDICATED",
+		ServiceEngineGroupBacking: types.NsxtAlbServiceEngineGroupBacking{
+			BackingId:            importableSeGroup.NsxtAlbImportableServiceEngineGroups.ID,
+			LoadBalancerCloudRef: &types.OpenApiReference{ID: albCloud.NsxtAlbCloud.ID},
+		},
+	})
+	check.Assert(err, IsNil)
+	PrependToCleanupListOpenApi(seGroup.NsxtAlbServiceEngineGroup.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointAlbServiceEngineGroups+seGroup.NsxtAlbServiceEngineGroup.ID)
+
+	return seGroup
+}
@@ -0,0 +1,155 @@
+// +build unit nsxt ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_validateCreateAlbPool(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *types.NsxtAlbPool
+		wantErr bool
+	}{
+		{
+			name:    "NoName",
+			config:  &types.NsxtAlbPool{GatewayRef: types.OpenApiReference{ID: "urn:vcloud:gateway:1"}},
+			wantErr: true,
+		},
+		{
+			name:    "NoGateway",
+			config:  &types.NsxtAlbPool{Name: "pool"},
+			wantErr: true,
+		},
+		{
+			name: "InvalidMemberIp",
+			config: &types.NsxtAlbPool{Name: "pool", GatewayRef: types.OpenApiReference{ID: "urn:vcloud:gateway:1"},
+				Members: []types.NsxtAlbPoolMember{{IpAddress: "1.1.1"}}},
+			wantErr: true,
+		},
+		{
+			name: "InvalidHealthMonitor",
+			config: &types.NsxtAlbPool{Name: "pool", GatewayRef: types.OpenApiReference{ID: "urn:vcloud:gateway:1"},
+				HealthMonitors: []types.NsxtAlbPoolHealthMonitor{{Type: "ICMP"}}},
+			wantErr: true,
+		},
+		{
+			name: "PersistenceWithoutRequiredValue",
+			config: &types.NsxtAlbPool{Name: "pool", GatewayRef: types.OpenApiReference{ID: "urn:vcloud:gateway:1"},
+				PersistenceProfile: &types.NsxtAlbPoolPersistenceProfile{Type: "HTTP_COOKIE"}},
+			wantErr: true,
+		},
+		{
+			name: "CommonNameCheckWithoutCertificates",
+			config: &types.NsxtAlbPool{Name: "pool", GatewayRef: types.OpenApiReference{ID: "urn:vcloud:gateway:1"},
+				CommonNameCheckEnabled: takeBoolPointer(true)},
+			wantErr: true,
+		},
+		{
+			name: "Valid",
+			config: &types.NsxtAlbPool{Name: "pool", GatewayRef: types.OpenApiReference{ID: "urn:vcloud:gateway:1"},
+				Members:            []types.NsxtAlbPoolMember{{IpAddress: "1.1.1.1", Port: 80}},
+				HealthMonitors:     []types.NsxtAlbPoolHealthMonitor{{Type: "HTTP"}},
+				PersistenceProfile: &types.NsxtAlbPoolPersistenceProfile{Type: "APP_COOKIE", Value: "JSESSIONID"}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCreateAlbPool(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCreateAlbPool() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateCreateAlbVirtualService(t *testing.T) {
+	validConfig := func() *types.NsxtAlbVirtualService {
+		return &types.NsxtAlbVirtualService{
+			Name:                  "vs",
+			GatewayRef:            types.OpenApiReference{ID: "urn:vcloud:gateway:1"},
+			LoadBalancerPoolRef:   types.OpenApiReference{ID: "urn:vcloud:loadBalancerPool:1"},
+			ServiceEngineGroupRef: types.OpenApiReference{ID: "urn:vcloud:serviceEngineGroup:1"},
+			ApplicationProfile:    types.NsxtAlbVirtualServiceApplicationProfile{Type: "HTTP"},
+			ServicePorts:          []types.NsxtAlbVirtualServicePort{{PortStart: takeIntAddress(80)}},
+			VirtualIpAddress:      "10.10.10.10",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(config *types.NsxtAlbVirtualService)
+		wantErr bool
+	}{
+		{
+			name:    "Valid",
+			mutate:  func(config *types.NsxtAlbVirtualService) {},
+			wantErr: false,
+		},
+		{
+			name:    "NoPool",
+			mutate:  func(config *types.NsxtAlbVirtualService) { config.LoadBalancerPoolRef.ID = "" },
+			wantErr: true,
+		},
+		{
+			name:    "InvalidVip",
+			mutate:  func(config *types.NsxtAlbVirtualService) { config.VirtualIpAddress = "10.10.10" },
+			wantErr: true,
+		},
+		{
+			name:    "HttpsWithoutCertificate",
+			mutate:  func(config *types.NsxtAlbVirtualService) { config.ApplicationProfile.Type = "HTTPS" },
+			wantErr: true,
+		},
+		{
+			name: "HttpsWithCertificate",
+			mutate: func(config *types.NsxtAlbVirtualService) {
+				config.ApplicationProfile.Type = "HTTPS"
+				config.CertificateRef = &types.OpenApiReference{ID: "urn:vcloud:certificateLibraryItem:1"}
+			},
+			wantErr: false,
+		},
+		{
+			name:    "UnknownApplicationProfile",
+			mutate:  func(config *types.NsxtAlbVirtualService) { config.ApplicationProfile.Type = "FTP" },
+			wantErr: true,
+		},
+		{
+			name:    "NoServicePorts",
+			mutate:  func(config *types.NsxtAlbVirtualService) { config.ServicePorts = nil },
+			wantErr: true,
+		},
+		{
+			name: "InvalidPortRange",
+			mutate: func(config *types.NsxtAlbVirtualService) {
+				config.ServicePorts[0].PortEnd = takeIntAddress(79)
+			},
+			wantErr: true,
+		},
+		{
+			name: "SslPortWithoutCertificate",
+			mutate: func(config *types.NsxtAlbVirtualService) {
+				config.ServicePorts[0].SslEnabled = takeBoolPointer(true)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validConfig()
+			tt.mutate(config)
+			err := validateCreateAlbVirtualService(config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCreateAlbVirtualService() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
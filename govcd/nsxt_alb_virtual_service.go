@@ -0,0 +1,307 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// NsxtAlbVirtualService combines Load Balancer Pools with Service Engine Groups and exposes a virtual service on
+// defined VIP (virtual IP address) while optionally allowing to use encrypted traffic
+type NsxtAlbVirtualService struct {
+	NsxtAlbVirtualService *types.NsxtAlbVirtualService
+	client                *Client
+}
+
+// GetAllAlbVirtualServiceSummaries retrieves partial information for type `NsxtAlbVirtualService`, but it is the only
+// way to retrieve all ALB Virtual Services in an Edge Gateway. Use GetAllAlbVirtualServices to retrieve complete
+// objects.
+func (egw *NsxtEdgeGateway) GetAllAlbVirtualServiceSummaries(ctx context.Context, queryParameters url.Values) ([]*NsxtAlbVirtualService, error) {
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbVirtualServiceSummaries
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.NsxtAlbVirtualService{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedResponses := make([]*NsxtAlbVirtualService, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtAlbVirtualService{
+			NsxtAlbVirtualService: typeResponses[sliceIndex],
+			client:                client,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetAllAlbVirtualServices uses GetAllAlbVirtualServiceSummaries behind the scenes and then fetches complete child
+// objects one by one.
+//
+// Note. Because it retrieves every virtual service individually, it is much more expensive than
+// GetAllAlbVirtualServiceSummaries.
+func (egw *NsxtEdgeGateway) GetAllAlbVirtualServices(ctx context.Context, queryParameters url.Values) ([]*NsxtAlbVirtualService, error) {
+	allSummaries, err := egw.GetAllAlbVirtualServiceSummaries(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving all ALB Virtual Service summaries: %s", err)
+	}
+
+	allVirtualServices := make([]*NsxtAlbVirtualService, len(allSummaries))
+	for index, summary := range allSummaries {
+		allVirtualServices[index], err = egw.GetAlbVirtualServiceById(ctx, summary.NsxtAlbVirtualService.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving complete ALB Virtual Service: %s", err)
+		}
+	}
+
+	return allVirtualServices, nil
+}
+
+// GetAlbVirtualServiceByName retrieves ALB Virtual Service by Name in this Edge Gateway
+func (egw *NsxtEdgeGateway) GetAlbVirtualServiceByName(ctx context.Context, name string) (*NsxtAlbVirtualService, error) {
+	queryParameters := url.Values{}
+	queryParameters.Add("filter", "name=="+name)
+
+	allVirtualServices, err := egw.GetAllAlbVirtualServiceSummaries(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving ALB Virtual Service with Name '%s': %s", name, err)
+	}
+
+	if len(allVirtualServices) == 0 {
+		return nil, fmt.Errorf("%s: could not find ALB Virtual Service with Name '%s'", ErrorEntityNotFound, name)
+	}
+
+	if len(allVirtualServices) > 1 {
+		return nil, fmt.Errorf("found more than 1 ALB Virtual Service with Name '%s'", name)
+	}
+
+	// Summaries contain only partial data, therefore the complete object is retrieved by ID
+	return egw.GetAlbVirtualServiceById(ctx, allVirtualServices[0].NsxtAlbVirtualService.ID)
+}
+
+// GetAlbVirtualServiceById retrieves complete ALB Virtual Service by ID
+func (egw *NsxtEdgeGateway) GetAlbVirtualServiceById(ctx context.Context, id string) (*NsxtAlbVirtualService, error) {
+	if id == "" {
+		return nil, fmt.Errorf("ID is required to lookup NSX-T ALB Virtual Service by ID")
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbVirtualServices
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponse := &types.NsxtAlbVirtualService{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, typeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if typeResponse.GatewayRef.ID != egw.EdgeGateway.ID {
+		return nil, fmt.Errorf("%s: ALB Virtual Service with ID '%s' does not belong to Edge Gateway '%s'",
+			ErrorEntityNotFound, id, egw.EdgeGateway.ID)
+	}
+
+	return &NsxtAlbVirtualService{
+		NsxtAlbVirtualService: typeResponse,
+		client:                client,
+	}, nil
+}
+
+// CreateAlbVirtualService creates NSX-T ALB Virtual Service in this Edge Gateway based on supplied
+// albVirtualServiceConfig. GatewayRef is set automatically.
+func (egw *NsxtEdgeGateway) CreateAlbVirtualService(ctx context.Context, albVirtualServiceConfig *types.NsxtAlbVirtualService) (*NsxtAlbVirtualService, error) {
+	if albVirtualServiceConfig == nil {
+		return nil, fmt.Errorf("ALB Virtual Service configuration cannot be nil")
+	}
+
+	// Virtual Service always belongs to the Edge Gateway it is created in
+	albVirtualServiceConfig.GatewayRef = types.OpenApiReference{ID: egw.EdgeGateway.ID}
+
+	if err := validateCreateAlbVirtualService(albVirtualServiceConfig); err != nil {
+		return nil, err
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbVirtualServices
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &NsxtAlbVirtualService{
+		NsxtAlbVirtualService: &types.NsxtAlbVirtualService{},
+		client:                client,
+	}
+
+	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, albVirtualServiceConfig, returnObject.NsxtAlbVirtualService)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T ALB Virtual Service: %s", err)
+	}
+
+	return returnObject, nil
+}
+
+// Update updates NSX-T ALB Virtual Service based on supplied albVirtualServiceConfig. ID must be set.
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) Update(ctx context.Context, albVirtualServiceConfig *types.NsxtAlbVirtualService) (*NsxtAlbVirtualService, error) {
+	if albVirtualServiceConfig == nil {
+		return nil, fmt.Errorf("ALB Virtual Service configuration cannot be nil")
+	}
+
+	if albVirtualServiceConfig.ID == "" {
+		return nil, fmt.Errorf("cannot update NSX-T ALB Virtual Service without ID")
+	}
+
+	if err := validateUpdateAlbVirtualService(albVirtualServiceConfig); err != nil {
+		return nil, err
+	}
+
+	client := nsxtAlbVirtualService.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbVirtualServices
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, albVirtualServiceConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responseAlbVirtualService := &NsxtAlbVirtualService{
+		NsxtAlbVirtualService: &types.NsxtAlbVirtualService{},
+		client:                client,
+	}
+
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, albVirtualServiceConfig, responseAlbVirtualService.NsxtAlbVirtualService)
+	if err != nil {
+		return nil, fmt.Errorf("error updating NSX-T ALB Virtual Service: %s", err)
+	}
+
+	return responseAlbVirtualService, nil
+}
+
+// Delete deletes NSX-T ALB Virtual Service
+func (nsxtAlbVirtualService *NsxtAlbVirtualService) Delete(ctx context.Context) error {
+	client := nsxtAlbVirtualService.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointAlbVirtualServices
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if nsxtAlbVirtualService.NsxtAlbVirtualService.ID == "" {
+		return fmt.Errorf("cannot delete NSX-T ALB Virtual Service without ID")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, nsxtAlbVirtualService.NsxtAlbVirtualService.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting NSX-T ALB Virtual Service: %s", err)
+	}
+
+	return nil
+}
+
+// albVirtualServiceApplicationProfileTypes lists application profile types and whether they require a certificate
+var albVirtualServiceApplicationProfileTypes = map[string]bool{
+	"HTTP":   false,
+	"HTTPS":  true,
+	"L4":     false,
+	"L4_TLS": true,
+}
+
+func validateCreateAlbVirtualService(albVirtualServiceConfig *types.NsxtAlbVirtualService) error {
+	if albVirtualServiceConfig.Name == "" {
+		return fmt.Errorf("NSX-T ALB Virtual Service Name cannot be empty")
+	}
+
+	if albVirtualServiceConfig.GatewayRef.ID == "" {
+		return fmt.Errorf("NSX-T ALB Virtual Service GatewayRef.ID cannot be empty")
+	}
+
+	if albVirtualServiceConfig.LoadBalancerPoolRef.ID == "" {
+		return fmt.Errorf("NSX-T ALB Virtual Service LoadBalancerPoolRef.ID cannot be empty")
+	}
+
+	if albVirtualServiceConfig.ServiceEngineGroupRef.ID == "" {
+		return fmt.Errorf("NSX-T ALB Virtual Service ServiceEngineGroupRef.ID cannot be empty")
+	}
+
+	if net.ParseIP(albVirtualServiceConfig.VirtualIpAddress) == nil {
+		return fmt.Errorf("NSX-T ALB Virtual Service must have a valid VirtualIpAddress. Got '%s'",
+			albVirtualServiceConfig.VirtualIpAddress)
+	}
+
+	certificateRequired, ok := albVirtualServiceApplicationProfileTypes[albVirtualServiceConfig.ApplicationProfile.Type]
+	if !ok {
+		return fmt.Errorf("NSX-T ALB Virtual Service ApplicationProfile.Type '%s' is not supported",
+			albVirtualServiceConfig.ApplicationProfile.Type)
+	}
+
+	hasCertificate := albVirtualServiceConfig.CertificateRef != nil && albVirtualServiceConfig.CertificateRef.ID != ""
+	if certificateRequired && !hasCertificate {
+		return fmt.Errorf("NSX-T ALB Virtual Service ApplicationProfile.Type '%s' requires CertificateRef",
+			albVirtualServiceConfig.ApplicationProfile.Type)
+	}
+
+	if len(albVirtualServiceConfig.ServicePorts) == 0 {
+		return fmt.Errorf("NSX-T ALB Virtual Service must have at least one ServicePort")
+	}
+
+	for _, servicePort := range albVirtualServiceConfig.ServicePorts {
+		if servicePort.PortStart == nil {
+			return fmt.Errorf("NSX-T ALB Virtual Service ServicePort must have PortStart set")
+		}
+		if *servicePort.PortStart < 1 || *servicePort.PortStart > 65535 {
+			return fmt.Errorf("NSX-T ALB Virtual Service ServicePort has invalid PortStart %d", *servicePort.PortStart)
+		}
+		if servicePort.PortEnd != nil && *servicePort.PortEnd < *servicePort.PortStart {
+			return fmt.Errorf("NSX-T ALB Virtual Service ServicePort PortEnd (%d) cannot be lower than PortStart (%d)",
+				*servicePort.PortEnd, *servicePort.PortStart)
+		}
+		if servicePort.SslEnabled != nil && *servicePort.SslEnabled && !hasCertificate {
+			return fmt.Errorf("NSX-T ALB Virtual Service ServicePort with SslEnabled requires CertificateRef")
+		}
+	}
+
+	return nil
+}
+
+func validateUpdateAlbVirtualService(albVirtualServiceConfig *types.NsxtAlbVirtualService) error {
+	// Update and create have the same requirements for now
+	return validateCreateAlbVirtualService(albVirtualServiceConfig)
+}
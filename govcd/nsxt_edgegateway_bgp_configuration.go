@@ -0,0 +1,115 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// GetBgpConfiguration retrieves BGP Configuration for NSX-T Edge Gateway
+func (egw *NsxtEdgeGateway) GetBgpConfiguration(ctx context.Context) (*types.EdgeBgpConfig, error) {
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpConfig
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponse := &types.EdgeBgpConfig{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, typeResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving BGP configuration for Edge Gateway '%s': %s",
+			egw.EdgeGateway.Name, err)
+	}
+
+	return typeResponse, nil
+}
+
+// UpdateBgpConfiguration updates BGP Configuration for NSX-T Edge Gateway
+//
+// Note. Update of BGP configuration requires version field to be sent back. If it is not set in bgpConfig, the latest
+// version is retrieved automatically.
+func (egw *NsxtEdgeGateway) UpdateBgpConfiguration(ctx context.Context, bgpConfig *types.EdgeBgpConfig) (*types.EdgeBgpConfig, error) {
+	if err := validateEdgeBgpConfig(bgpConfig); err != nil {
+		return nil, err
+	}
+
+	if bgpConfig.Version.Version == 0 {
+		currentConfig, err := egw.GetBgpConfiguration(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving current BGP configuration version: %s", err)
+		}
+		bgpConfig.Version = currentConfig.Version
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpConfig
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponse := &types.EdgeBgpConfig{}
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, bgpConfig, typeResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error updating BGP configuration for Edge Gateway '%s': %s",
+			egw.EdgeGateway.Name, err)
+	}
+
+	return typeResponse, nil
+}
+
+// DisableBgpConfiguration is a shortcut wrapping UpdateBgpConfiguration which disables BGP configuration on an Edge
+// Gateway
+func (egw *NsxtEdgeGateway) DisableBgpConfiguration(ctx context.Context) error {
+	_, err := egw.UpdateBgpConfiguration(ctx, &types.EdgeBgpConfig{Enabled: false})
+	if err != nil {
+		return fmt.Errorf("error disabling BGP configuration: %s", err)
+	}
+
+	return nil
+}
+
+// bgpAsNumberRegexp matches autonomous system numbers in ASPLAIN (e.g. "65000") or ASDOT (e.g. "1.10") notation
+var bgpAsNumberRegexp = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// bgpGracefulRestartModes lists graceful restart modes accepted by NSX-T Edge Gateway BGP service
+var bgpGracefulRestartModes = []string{"DISABLE", "HELPER_ONLY", "GRACEFUL_AND_HELPER"}
+
+func validateEdgeBgpConfig(bgpConfig *types.EdgeBgpConfig) error {
+	if bgpConfig == nil {
+		return fmt.Errorf("BGP configuration cannot be nil")
+	}
+
+	if bgpConfig.LocalASNumber != "" && !bgpAsNumberRegexp.MatchString(bgpConfig.LocalASNumber) {
+		return fmt.Errorf("BGP LocalASNumber must be in ASPLAIN or ASDOT format. Got '%s'", bgpConfig.LocalASNumber)
+	}
+
+	if bgpConfig.GracefulRestart != nil {
+		if !stringInSlice(bgpConfig.GracefulRestart.Mode, bgpGracefulRestartModes) {
+			return fmt.Errorf("BGP graceful restart Mode must be one of %v. Got '%s'",
+				bgpGracefulRestartModes, bgpConfig.GracefulRestart.Mode)
+		}
+		if bgpConfig.GracefulRestart.RestartTimer < 0 || bgpConfig.GracefulRestart.StaleRouteTimer < 0 {
+			return fmt.Errorf("BGP graceful restart timers cannot be negative")
+		}
+	}
+
+	return nil
+}
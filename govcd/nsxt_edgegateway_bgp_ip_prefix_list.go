@@ -0,0 +1,238 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// EdgeBgpIpPrefixList represents NSX-T Edge Gateway BGP IP Prefix List which can be used as a route filter for BGP
+// Neighbors
+type EdgeBgpIpPrefixList struct {
+	EdgeBgpIpPrefixList *types.EdgeBgpIpPrefixList
+	client              *Client
+	// edgeGatewayId is stored here so that pointer receiver functions can embed edge gateway ID into path
+	edgeGatewayId string
+}
+
+// GetAllBgpIpPrefixLists retrieves all BGP IP Prefix Lists in NSX-T Edge Gateway
+func (egw *NsxtEdgeGateway) GetAllBgpIpPrefixLists(ctx context.Context, queryParameters url.Values) ([]*EdgeBgpIpPrefixList, error) {
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpConfigPrefixLists
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.EdgeBgpIpPrefixList{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedResponses := make([]*EdgeBgpIpPrefixList, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &EdgeBgpIpPrefixList{
+			EdgeBgpIpPrefixList: typeResponses[sliceIndex],
+			client:              client,
+			edgeGatewayId:       egw.EdgeGateway.ID,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetBgpIpPrefixListByName retrieves BGP IP Prefix List by Name
+func (egw *NsxtEdgeGateway) GetBgpIpPrefixListByName(ctx context.Context, name string) (*EdgeBgpIpPrefixList, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+
+	queryParameters := url.Values{}
+	queryParameters.Add("filter", "name=="+name)
+
+	allIpPrefixLists, err := egw.GetAllBgpIpPrefixLists(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP IP Prefix List with Name '%s': %s", name, err)
+	}
+
+	if len(allIpPrefixLists) == 0 {
+		return nil, fmt.Errorf("%s: could not find NSX-T Edge Gateway BGP IP Prefix List with Name '%s'",
+			ErrorEntityNotFound, name)
+	}
+
+	if len(allIpPrefixLists) > 1 {
+		return nil, fmt.Errorf("found more than 1 NSX-T Edge Gateway BGP IP Prefix List with Name '%s'", name)
+	}
+
+	return allIpPrefixLists[0], nil
+}
+
+// GetBgpIpPrefixListById retrieves BGP IP Prefix List by ID
+func (egw *NsxtEdgeGateway) GetBgpIpPrefixListById(ctx context.Context, id string) (*EdgeBgpIpPrefixList, error) {
+	if id == "" {
+		return nil, fmt.Errorf("ID is required to lookup NSX-T Edge Gateway BGP IP Prefix List by ID")
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpConfigPrefixLists
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID), id)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponse := &types.EdgeBgpIpPrefixList{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, typeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EdgeBgpIpPrefixList{
+		EdgeBgpIpPrefixList: typeResponse,
+		client:              client,
+		edgeGatewayId:       egw.EdgeGateway.ID,
+	}, nil
+}
+
+// CreateBgpIpPrefixList creates BGP IP Prefix List with the given configuration
+//
+// Note. The API does not return ID of created BGP IP Prefix List in the task, therefore it is looked up by Name after
+// the task completes.
+func (egw *NsxtEdgeGateway) CreateBgpIpPrefixList(ctx context.Context, bgpIpPrefixListConfig *types.EdgeBgpIpPrefixList) (*EdgeBgpIpPrefixList, error) {
+	if err := validateEdgeBgpIpPrefixList(bgpIpPrefixListConfig); err != nil {
+		return nil, err
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpConfigPrefixLists
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := client.OpenApiPostItemAsync(ctx, minimumApiVersion, urlRef, nil, bgpIpPrefixListConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+	}
+
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+	}
+
+	return egw.GetBgpIpPrefixListByName(ctx, bgpIpPrefixListConfig.Name)
+}
+
+// Update updates existing BGP IP Prefix List with new configuration. ID must be set.
+func (bgpIpPrefixList *EdgeBgpIpPrefixList) Update(ctx context.Context, bgpIpPrefixListConfig *types.EdgeBgpIpPrefixList) (*EdgeBgpIpPrefixList, error) {
+	if err := validateEdgeBgpIpPrefixList(bgpIpPrefixListConfig); err != nil {
+		return nil, err
+	}
+
+	if bgpIpPrefixListConfig.ID == "" {
+		return nil, fmt.Errorf("cannot update NSX-T Edge Gateway BGP IP Prefix List without ID")
+	}
+
+	client := bgpIpPrefixList.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpConfigPrefixLists
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, bgpIpPrefixList.edgeGatewayId), bgpIpPrefixListConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &EdgeBgpIpPrefixList{
+		EdgeBgpIpPrefixList: &types.EdgeBgpIpPrefixList{},
+		client:              client,
+		edgeGatewayId:       bgpIpPrefixList.edgeGatewayId,
+	}
+
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, bgpIpPrefixListConfig, returnObject.EdgeBgpIpPrefixList)
+	if err != nil {
+		return nil, fmt.Errorf("error updating NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+	}
+
+	return returnObject, nil
+}
+
+// Delete deletes BGP IP Prefix List
+func (bgpIpPrefixList *EdgeBgpIpPrefixList) Delete(ctx context.Context) error {
+	client := bgpIpPrefixList.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpConfigPrefixLists
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if bgpIpPrefixList.EdgeBgpIpPrefixList.ID == "" {
+		return fmt.Errorf("cannot delete NSX-T Edge Gateway BGP IP Prefix List without ID")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, bgpIpPrefixList.edgeGatewayId), bgpIpPrefixList.EdgeBgpIpPrefixList.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting NSX-T Edge Gateway BGP IP Prefix List: %s", err)
+	}
+
+	return nil
+}
+
+// bgpIpPrefixListActions lists actions accepted by BGP IP Prefix List prefixes
+var bgpIpPrefixListActions = []string{"PERMIT", "DENY"}
+
+func validateEdgeBgpIpPrefixList(bgpIpPrefixListConfig *types.EdgeBgpIpPrefixList) error {
+	if bgpIpPrefixListConfig == nil {
+		return fmt.Errorf("BGP IP Prefix List configuration cannot be nil")
+	}
+
+	if bgpIpPrefixListConfig.Name == "" {
+		return fmt.Errorf("BGP IP Prefix List Name cannot be empty")
+	}
+
+	for _, prefix := range bgpIpPrefixListConfig.Prefixes {
+		if prefix.Network != "ANY" {
+			if _, _, err := net.ParseCIDR(prefix.Network); err != nil {
+				return fmt.Errorf("BGP IP Prefix List Network must be a valid CIDR or 'ANY'. Got '%s'", prefix.Network)
+			}
+		}
+		if !stringInSlice(prefix.Action, bgpIpPrefixListActions) {
+			return fmt.Errorf("BGP IP Prefix List Action must be one of %v. Got '%s'", bgpIpPrefixListActions, prefix.Action)
+		}
+		if prefix.GreaterThan != nil && prefix.LessThan != nil && *prefix.GreaterThan > *prefix.LessThan {
+			return fmt.Errorf("BGP IP Prefix List prefix '%s' GreaterThan (%d) cannot be bigger than LessThan (%d)",
+				prefix.Network, *prefix.GreaterThan, *prefix.LessThan)
+		}
+	}
+
+	return nil
+}
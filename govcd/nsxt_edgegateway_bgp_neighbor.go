@@ -0,0 +1,260 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// EdgeBgpNeighbor represents NSX-T Edge Gateway BGP Neighbor
+type EdgeBgpNeighbor struct {
+	EdgeBgpNeighbor *types.EdgeBgpNeighbor
+	client          *Client
+	// edgeGatewayId is stored here so that pointer receiver functions can embed edge gateway ID into path
+	edgeGatewayId string
+}
+
+// GetAllBgpNeighbors retrieves all BGP Neighbors in NSX-T Edge Gateway
+func (egw *NsxtEdgeGateway) GetAllBgpNeighbors(ctx context.Context, queryParameters url.Values) ([]*EdgeBgpNeighbor, error) {
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpNeighbor
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.EdgeBgpNeighbor{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedResponses := make([]*EdgeBgpNeighbor, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &EdgeBgpNeighbor{
+			EdgeBgpNeighbor: typeResponses[sliceIndex],
+			client:          client,
+			edgeGatewayId:   egw.EdgeGateway.ID,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetBgpNeighborByIp retrieves BGP Neighbor by its neighbor IP address
+//
+// Note. The API does not support filtering by neighborAddress, therefore filtering is performed on the client side.
+func (egw *NsxtEdgeGateway) GetBgpNeighborByIp(ctx context.Context, neighborIpAddress string) (*EdgeBgpNeighbor, error) {
+	if neighborIpAddress == "" {
+		return nil, fmt.Errorf("neighbor IP address cannot be empty")
+	}
+
+	allBgpNeighbors, err := egw.GetAllBgpNeighbors(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway BGP Neighbor with IP '%s': %s", neighborIpAddress, err)
+	}
+
+	var foundNeighbors []*EdgeBgpNeighbor
+	for _, bgpNeighbor := range allBgpNeighbors {
+		if bgpNeighbor.EdgeBgpNeighbor.NeighborAddress == neighborIpAddress {
+			foundNeighbors = append(foundNeighbors, bgpNeighbor)
+		}
+	}
+
+	if len(foundNeighbors) == 0 {
+		return nil, fmt.Errorf("%s: could not find NSX-T Edge Gateway BGP Neighbor with IP '%s'",
+			ErrorEntityNotFound, neighborIpAddress)
+	}
+
+	if len(foundNeighbors) > 1 {
+		return nil, fmt.Errorf("found more than 1 NSX-T Edge Gateway BGP Neighbor with IP '%s'", neighborIpAddress)
+	}
+
+	return foundNeighbors[0], nil
+}
+
+// GetBgpNeighborById retrieves BGP Neighbor by ID
+func (egw *NsxtEdgeGateway) GetBgpNeighborById(ctx context.Context, id string) (*EdgeBgpNeighbor, error) {
+	if id == "" {
+		return nil, fmt.Errorf("ID is required to lookup NSX-T Edge Gateway BGP Neighbor by ID")
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpNeighbor
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID), id)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponse := &types.EdgeBgpNeighbor{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, typeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EdgeBgpNeighbor{
+		EdgeBgpNeighbor: typeResponse,
+		client:          client,
+		edgeGatewayId:   egw.EdgeGateway.ID,
+	}, nil
+}
+
+// CreateBgpNeighbor creates BGP Neighbor with the given configuration. Route filters can be applied by setting
+// InRoutesFilterRef and OutRoutesFilterRef to existing IP Prefix Lists (EdgeBgpIpPrefixList).
+//
+// Note. The API does not return ID of created BGP Neighbor in the task, therefore it is looked up by neighbor IP
+// address after the task completes.
+func (egw *NsxtEdgeGateway) CreateBgpNeighbor(ctx context.Context, bgpNeighborConfig *types.EdgeBgpNeighbor) (*EdgeBgpNeighbor, error) {
+	if err := validateEdgeBgpNeighbor(bgpNeighborConfig); err != nil {
+		return nil, err
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpNeighbor
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := client.OpenApiPostItemAsync(ctx, minimumApiVersion, urlRef, nil, bgpNeighborConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP Neighbor: %s", err)
+	}
+
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway BGP Neighbor: %s", err)
+	}
+
+	return egw.GetBgpNeighborByIp(ctx, bgpNeighborConfig.NeighborAddress)
+}
+
+// Update updates existing BGP Neighbor with new configuration. ID must be set.
+func (bgpNeighbor *EdgeBgpNeighbor) Update(ctx context.Context, bgpNeighborConfig *types.EdgeBgpNeighbor) (*EdgeBgpNeighbor, error) {
+	if err := validateEdgeBgpNeighbor(bgpNeighborConfig); err != nil {
+		return nil, err
+	}
+
+	if bgpNeighborConfig.ID == "" {
+		return nil, fmt.Errorf("cannot update NSX-T Edge Gateway BGP Neighbor without ID")
+	}
+
+	client := bgpNeighbor.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpNeighbor
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, bgpNeighbor.edgeGatewayId), bgpNeighborConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &EdgeBgpNeighbor{
+		EdgeBgpNeighbor: &types.EdgeBgpNeighbor{},
+		client:          client,
+		edgeGatewayId:   bgpNeighbor.edgeGatewayId,
+	}
+
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, bgpNeighborConfig, returnObject.EdgeBgpNeighbor)
+	if err != nil {
+		return nil, fmt.Errorf("error updating NSX-T Edge Gateway BGP Neighbor: %s", err)
+	}
+
+	return returnObject, nil
+}
+
+// Delete deletes BGP Neighbor
+func (bgpNeighbor *EdgeBgpNeighbor) Delete(ctx context.Context) error {
+	client := bgpNeighbor.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeBgpNeighbor
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if bgpNeighbor.EdgeBgpNeighbor.ID == "" {
+		return fmt.Errorf("cannot delete NSX-T Edge Gateway BGP Neighbor without ID")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, bgpNeighbor.edgeGatewayId), bgpNeighbor.EdgeBgpNeighbor.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting NSX-T Edge Gateway BGP Neighbor: %s", err)
+	}
+
+	return nil
+}
+
+// bgpNeighborIpAddressTypeFilters lists IP address type filtering values accepted by BGP Neighbors
+var bgpNeighborIpAddressTypeFilters = []string{"IPV4", "IPV6", "DISABLED"}
+
+func validateEdgeBgpNeighbor(bgpNeighborConfig *types.EdgeBgpNeighbor) error {
+	if bgpNeighborConfig == nil {
+		return fmt.Errorf("BGP Neighbor configuration cannot be nil")
+	}
+
+	if net.ParseIP(bgpNeighborConfig.NeighborAddress) == nil {
+		return fmt.Errorf("BGP Neighbor must have a valid NeighborAddress. Got '%s'", bgpNeighborConfig.NeighborAddress)
+	}
+
+	if !bgpAsNumberRegexp.MatchString(bgpNeighborConfig.RemoteASNumber) {
+		return fmt.Errorf("BGP Neighbor RemoteASNumber must be in ASPLAIN or ASDOT format. Got '%s'",
+			bgpNeighborConfig.RemoteASNumber)
+	}
+
+	if bgpNeighborConfig.GracefulRestartMode != "" &&
+		!stringInSlice(bgpNeighborConfig.GracefulRestartMode, bgpGracefulRestartModes) {
+		return fmt.Errorf("BGP Neighbor GracefulRestartMode must be one of %v. Got '%s'",
+			bgpGracefulRestartModes, bgpNeighborConfig.GracefulRestartMode)
+	}
+
+	if bgpNeighborConfig.IpAddressTypeFiltering != "" &&
+		!stringInSlice(bgpNeighborConfig.IpAddressTypeFiltering, bgpNeighborIpAddressTypeFilters) {
+		return fmt.Errorf("BGP Neighbor IpAddressTypeFiltering must be one of %v. Got '%s'",
+			bgpNeighborIpAddressTypeFilters, bgpNeighborConfig.IpAddressTypeFiltering)
+	}
+
+	if bgpNeighborConfig.KeepAliveTimer > 0 && bgpNeighborConfig.HoldDownTimer > 0 &&
+		bgpNeighborConfig.HoldDownTimer < bgpNeighborConfig.KeepAliveTimer {
+		return fmt.Errorf("BGP Neighbor HoldDownTimer (%d) cannot be lower than KeepAliveTimer (%d)",
+			bgpNeighborConfig.HoldDownTimer, bgpNeighborConfig.KeepAliveTimer)
+	}
+
+	if bgpNeighborConfig.InRoutesFilterRef != nil && bgpNeighborConfig.InRoutesFilterRef.ID == "" {
+		return fmt.Errorf("BGP Neighbor InRoutesFilterRef must have ID set")
+	}
+
+	if bgpNeighborConfig.OutRoutesFilterRef != nil && bgpNeighborConfig.OutRoutesFilterRef.ID == "" {
+		return fmt.Errorf("BGP Neighbor OutRoutesFilterRef must have ID set")
+	}
+
+	return nil
+}
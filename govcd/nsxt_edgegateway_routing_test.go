@@ -0,0 +1,156 @@
+// +build network nsxt functional openapi ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+func (vcd *TestVCD) Test_NsxtEdgeGatewayStaticRoute(check *C) {
+	skipNoNsxtConfiguration(vcd, check)
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointEdgeGatewayStaticRoutes)
+
+	edge, err := vcd.nsxtVdc.GetNsxtEdgeGatewayByName(ctx, vcd.config.VCD.Nsxt.EdgeGateway)
+	check.Assert(err, IsNil)
+
+	staticRouteConfig := &types.NsxtEdgeGatewayStaticRoute{
+		Name:        check.TestName(),
+		Description: "static route description",
+		NetworkCidr: "1.1.1.0/24",
+		NextHops: []types.NsxtEdgeGatewayStaticRouteNextHops{
+			{IPAddress: edge.EdgeGateway.EdgeGatewayUplinks[0].Subnets.Values[0].Gateway, AdminDistance: 4},
+		},
+	}
+
+	staticRoute, err := edge.CreateStaticRoute(ctx, staticRouteConfig)
+	check.Assert(err, IsNil)
+	check.Assert(staticRoute.NsxtEdgeGatewayStaticRoute.ID, Not(Equals), "")
+	openApiEndpoint := types.OpenApiPathVersion1_0_0 +
+		types.OpenApiEndpointEdgeGatewayStaticRoutes + staticRoute.NsxtEdgeGatewayStaticRoute.ID
+	PrependToCleanupListOpenApi(staticRoute.NsxtEdgeGatewayStaticRoute.Name, check.TestName(), openApiEndpoint)
+
+	staticRouteByName, err := edge.GetStaticRouteByName(ctx, staticRouteConfig.Name)
+	check.Assert(err, IsNil)
+	check.Assert(staticRouteByName.NsxtEdgeGatewayStaticRoute.ID, Equals, staticRoute.NsxtEdgeGatewayStaticRoute.ID)
+
+	staticRouteByCidr, err := edge.GetStaticRouteByNetworkCidr(ctx, staticRouteConfig.NetworkCidr)
+	check.Assert(err, IsNil)
+	check.Assert(staticRouteByCidr.NsxtEdgeGatewayStaticRoute.ID, Equals, staticRoute.NsxtEdgeGatewayStaticRoute.ID)
+
+	staticRoute.NsxtEdgeGatewayStaticRoute.NextHops[0].AdminDistance = 10
+	updatedStaticRoute, err := staticRoute.Update(ctx, staticRoute.NsxtEdgeGatewayStaticRoute)
+	check.Assert(err, IsNil)
+	check.Assert(updatedStaticRoute.NsxtEdgeGatewayStaticRoute.NextHops[0].AdminDistance, Equals, 10)
+
+	err = staticRoute.Delete(ctx)
+	check.Assert(err, IsNil)
+
+	_, err = edge.GetStaticRouteByName(ctx, staticRouteConfig.Name)
+	check.Assert(ContainsNotFound(err), Equals, true)
+}
+
+func (vcd *TestVCD) Test_NsxtEdgeGatewayBgp(check *C) {
+	skipNoNsxtConfiguration(vcd, check)
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointEdgeBgpConfig)
+
+	edge, err := vcd.nsxtVdc.GetNsxtEdgeGatewayByName(ctx, vcd.config.VCD.Nsxt.EdgeGateway)
+	check.Assert(err, IsNil)
+
+	// BGP configuration
+	initialBgpConfig, err := edge.GetBgpConfiguration(ctx)
+	check.Assert(err, IsNil)
+
+	bgpConfig := &types.EdgeBgpConfig{
+		Enabled:         true,
+		Ecmp:            true,
+		LocalASNumber:   "65420",
+		GracefulRestart: &types.EdgeBgpGracefulRestartConfig{Mode: "HELPER_ONLY", RestartTimer: 190, StaleRouteTimer: 600},
+	}
+	updatedBgpConfig, err := edge.UpdateBgpConfiguration(ctx, bgpConfig)
+	check.Assert(err, IsNil)
+	check.Assert(updatedBgpConfig.Enabled, Equals, true)
+	check.Assert(updatedBgpConfig.Ecmp, Equals, true)
+	check.Assert(updatedBgpConfig.LocalASNumber, Equals, "65420")
+	check.Assert(updatedBgpConfig.GracefulRestart.Mode, Equals, "HELPER_ONLY")
+
+	// IP Prefix List used as a route filter
+	ipPrefixList, err := edge.CreateBgpIpPrefixList(ctx, &types.EdgeBgpIpPrefixList{
+		Name: check.TestName(),
+		Prefixes: []types.EdgeBgpConfigPrefixListPrefixes{
+			{Network: "10.10.10.0/24", Action: "PERMIT", GreaterThan: takeIntAddress(25), LessThan: takeIntAddress(27)},
+			{Network: "ANY", Action: "DENY"},
+		},
+	})
+	check.Assert(err, IsNil)
+	PrependToCleanupListOpenApi(ipPrefixList.EdgeBgpIpPrefixList.Name, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointEdgeBgpConfigPrefixLists+ipPrefixList.EdgeBgpIpPrefixList.ID)
+	check.Assert(len(ipPrefixList.EdgeBgpIpPrefixList.Prefixes), Equals, 2)
+
+	// BGP Neighbor with route filters
+	bgpNeighbor, err := edge.CreateBgpNeighbor(ctx, &types.EdgeBgpNeighbor{
+		NeighborAddress:    "11.11.11.11",
+		RemoteASNumber:     "65000",
+		KeepAliveTimer:     80,
+		HoldDownTimer:      241,
+		AllowASIn:          true,
+		InRoutesFilterRef:  &types.OpenApiReference{ID: ipPrefixList.EdgeBgpIpPrefixList.ID},
+		OutRoutesFilterRef: &types.OpenApiReference{ID: ipPrefixList.EdgeBgpIpPrefixList.ID},
+	})
+	check.Assert(err, IsNil)
+	PrependToCleanupListOpenApi(bgpNeighbor.EdgeBgpNeighbor.NeighborAddress, check.TestName(),
+		types.OpenApiPathVersion1_0_0+types.OpenApiEndpointEdgeBgpNeighbor+bgpNeighbor.EdgeBgpNeighbor.ID)
+	check.Assert(bgpNeighbor.EdgeBgpNeighbor.InRoutesFilterRef.ID, Equals, ipPrefixList.EdgeBgpIpPrefixList.ID)
+
+	bgpNeighborById, err := edge.GetBgpNeighborById(ctx, bgpNeighbor.EdgeBgpNeighbor.ID)
+	check.Assert(err, IsNil)
+	check.Assert(bgpNeighborById.EdgeBgpNeighbor.NeighborAddress, Equals, "11.11.11.11")
+
+	bgpNeighbor.EdgeBgpNeighbor.RemoteASNumber = "65001"
+	bgpNeighbor.EdgeBgpNeighbor.OutRoutesFilterRef = nil
+	updatedBgpNeighbor, err := bgpNeighbor.Update(ctx, bgpNeighbor.EdgeBgpNeighbor)
+	check.Assert(err, IsNil)
+	check.Assert(updatedBgpNeighbor.EdgeBgpNeighbor.RemoteASNumber, Equals, "65001")
+	check.Assert(updatedBgpNeighbor.EdgeBgpNeighbor.OutRoutesFilterRef, IsNil)
+
+	err = bgpNeighbor.Delete(ctx)
+	check.Assert(err, IsNil)
+	err = ipPrefixList.Delete(ctx)
+	check.Assert(err, IsNil)
+
+	// Restore initial BGP configuration
+	initialBgpConfig.Version = types.EdgeBgpConfigVersion{}
+	_, err = edge.UpdateBgpConfiguration(ctx, initialBgpConfig)
+	check.Assert(err, IsNil)
+}
+
+func (vcd *TestVCD) Test_NsxtEdgeGatewayRouteAdvertisement(check *C) {
+	skipNoNsxtConfiguration(vcd, check)
+	skipOpenApiEndpointTest(ctx, vcd, check, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointNsxtRouteAdvertisement)
+
+	edge, err := vcd.nsxtVdc.GetNsxtEdgeGatewayByName(ctx, vcd.config.VCD.Nsxt.EdgeGateway)
+	check.Assert(err, IsNil)
+
+	subnets := []string{"192.168.1.0/24", "192.168.2.0/24"}
+	routeAdvertisement, err := edge.UpdateNsxtRouteAdvertisement(ctx, true, subnets)
+	check.Assert(err, IsNil)
+	check.Assert(routeAdvertisement.Enable, Equals, true)
+	check.Assert(len(routeAdvertisement.Subnets), Equals, 2)
+
+	routeAdvertisement, err = edge.GetNsxtRouteAdvertisement(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(routeAdvertisement.Enable, Equals, true)
+	check.Assert(len(routeAdvertisement.Subnets), Equals, 2)
+
+	err = edge.DeleteNsxtRouteAdvertisement(ctx)
+	check.Assert(err, IsNil)
+
+	routeAdvertisement, err = edge.GetNsxtRouteAdvertisement(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(routeAdvertisement.Enable, Equals, false)
+	check.Assert(len(routeAdvertisement.Subnets), Equals, 0)
+}
@@ -0,0 +1,212 @@
+// +build unit nsxt ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_validateNsxtEdgeGatewayStaticRoute(t *testing.T) {
+	validNextHops := []types.NsxtEdgeGatewayStaticRouteNextHops{{IPAddress: "10.0.0.1", AdminDistance: 1}}
+	tests := []struct {
+		name    string
+		config  *types.NsxtEdgeGatewayStaticRoute
+		wantErr bool
+	}{
+		{
+			name:    "Nil",
+			config:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "NoName",
+			config:  &types.NsxtEdgeGatewayStaticRoute{NetworkCidr: "1.1.1.0/24", NextHops: validNextHops},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidCidr",
+			config:  &types.NsxtEdgeGatewayStaticRoute{Name: "route", NetworkCidr: "1.1.1.0", NextHops: validNextHops},
+			wantErr: true,
+		},
+		{
+			name:    "NoNextHops",
+			config:  &types.NsxtEdgeGatewayStaticRoute{Name: "route", NetworkCidr: "1.1.1.0/24"},
+			wantErr: true,
+		},
+		{
+			name: "InvalidNextHopIp",
+			config: &types.NsxtEdgeGatewayStaticRoute{Name: "route", NetworkCidr: "1.1.1.0/24",
+				NextHops: []types.NsxtEdgeGatewayStaticRouteNextHops{{IPAddress: "10.0.0", AdminDistance: 1}}},
+			wantErr: true,
+		},
+		{
+			name: "AdminDistanceOutOfRange",
+			config: &types.NsxtEdgeGatewayStaticRoute{Name: "route", NetworkCidr: "1.1.1.0/24",
+				NextHops: []types.NsxtEdgeGatewayStaticRouteNextHops{{IPAddress: "10.0.0.1", AdminDistance: 256}}},
+			wantErr: true,
+		},
+		{
+			name:    "Valid",
+			config:  &types.NsxtEdgeGatewayStaticRoute{Name: "route", NetworkCidr: "1.1.1.0/24", NextHops: validNextHops},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNsxtEdgeGatewayStaticRoute(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateNsxtEdgeGatewayStaticRoute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateEdgeBgpConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *types.EdgeBgpConfig
+		wantErr bool
+	}{
+		{
+			name:    "Nil",
+			config:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "AsPlain",
+			config:  &types.EdgeBgpConfig{Enabled: true, LocalASNumber: "65000"},
+			wantErr: false,
+		},
+		{
+			name:    "AsDot",
+			config:  &types.EdgeBgpConfig{Enabled: true, LocalASNumber: "1.10"},
+			wantErr: false,
+		},
+		{
+			name:    "InvalidAsNumber",
+			config:  &types.EdgeBgpConfig{Enabled: true, LocalASNumber: "AS65000"},
+			wantErr: true,
+		},
+		{
+			name: "InvalidGracefulRestartMode",
+			config: &types.EdgeBgpConfig{Enabled: true,
+				GracefulRestart: &types.EdgeBgpGracefulRestartConfig{Mode: "ENABLED"}},
+			wantErr: true,
+		},
+		{
+			name: "ValidGracefulRestart",
+			config: &types.EdgeBgpConfig{Enabled: true, Ecmp: true,
+				GracefulRestart: &types.EdgeBgpGracefulRestartConfig{Mode: "GRACEFUL_AND_HELPER", RestartTimer: 180}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEdgeBgpConfig(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateEdgeBgpConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateEdgeBgpNeighbor(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *types.EdgeBgpNeighbor
+		wantErr bool
+	}{
+		{
+			name:    "InvalidNeighborAddress",
+			config:  &types.EdgeBgpNeighbor{NeighborAddress: "1.1.1", RemoteASNumber: "65000"},
+			wantErr: true,
+		},
+		{
+			name:    "NoRemoteAsNumber",
+			config:  &types.EdgeBgpNeighbor{NeighborAddress: "1.1.1.1"},
+			wantErr: true,
+		},
+		{
+			name: "HoldDownLowerThanKeepAlive",
+			config: &types.EdgeBgpNeighbor{NeighborAddress: "1.1.1.1", RemoteASNumber: "65000",
+				KeepAliveTimer: 60, HoldDownTimer: 30},
+			wantErr: true,
+		},
+		{
+			name: "FilterWithoutId",
+			config: &types.EdgeBgpNeighbor{NeighborAddress: "1.1.1.1", RemoteASNumber: "65000",
+				InRoutesFilterRef: &types.OpenApiReference{Name: "filter"}},
+			wantErr: true,
+		},
+		{
+			name: "Valid",
+			config: &types.EdgeBgpNeighbor{NeighborAddress: "fe80::1", RemoteASNumber: "65000",
+				IpAddressTypeFiltering: "IPV6", InRoutesFilterRef: &types.OpenApiReference{ID: "urn:vcloud:prefixList:1"}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEdgeBgpNeighbor(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateEdgeBgpNeighbor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateEdgeBgpIpPrefixList(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *types.EdgeBgpIpPrefixList
+		wantErr bool
+	}{
+		{
+			name:    "NoName",
+			config:  &types.EdgeBgpIpPrefixList{},
+			wantErr: true,
+		},
+		{
+			name: "InvalidNetwork",
+			config: &types.EdgeBgpIpPrefixList{Name: "list",
+				Prefixes: []types.EdgeBgpConfigPrefixListPrefixes{{Network: "10.0.0.0", Action: "PERMIT"}}},
+			wantErr: true,
+		},
+		{
+			name: "InvalidAction",
+			config: &types.EdgeBgpIpPrefixList{Name: "list",
+				Prefixes: []types.EdgeBgpConfigPrefixListPrefixes{{Network: "ANY", Action: "ALLOW"}}},
+			wantErr: true,
+		},
+		{
+			name: "InvalidLengthRange",
+			config: &types.EdgeBgpIpPrefixList{Name: "list",
+				Prefixes: []types.EdgeBgpConfigPrefixListPrefixes{{Network: "10.0.0.0/8", Action: "PERMIT",
+					GreaterThan: takeIntAddress(28), LessThan: takeIntAddress(24)}}},
+			wantErr: true,
+		},
+		{
+			name: "Valid",
+			config: &types.EdgeBgpIpPrefixList{Name: "list",
+				Prefixes: []types.EdgeBgpConfigPrefixListPrefixes{
+					{Network: "10.0.0.0/8", Action: "PERMIT", GreaterThan: takeIntAddress(16), LessThan: takeIntAddress(24)},
+					{Network: "ANY", Action: "DENY"},
+				}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEdgeBgpIpPrefixList(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateEdgeBgpIpPrefixList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
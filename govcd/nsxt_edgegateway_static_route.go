@@ -0,0 +1,279 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// NsxtEdgeGatewayStaticRoute represents a single static route of NSX-T Edge Gateway
+type NsxtEdgeGatewayStaticRoute struct {
+	NsxtEdgeGatewayStaticRoute *types.NsxtEdgeGatewayStaticRoute
+	client                     *Client
+	// edgeGatewayId is stored here so that pointer receiver functions can embed edge gateway ID into path
+	edgeGatewayId string
+}
+
+// GetAllStaticRoutes retrieves all NSX-T Edge Gateway static routes
+func (egw *NsxtEdgeGateway) GetAllStaticRoutes(ctx context.Context, queryParameters url.Values) ([]*NsxtEdgeGatewayStaticRoute, error) {
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeGatewayStaticRoutes
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.NsxtEdgeGatewayStaticRoute{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedResponses := make([]*NsxtEdgeGatewayStaticRoute, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &NsxtEdgeGatewayStaticRoute{
+			NsxtEdgeGatewayStaticRoute: typeResponses[sliceIndex],
+			client:                     client,
+			edgeGatewayId:              egw.EdgeGateway.ID,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetStaticRouteByName retrieves NSX-T Edge Gateway static route by Name
+func (egw *NsxtEdgeGateway) GetStaticRouteByName(ctx context.Context, name string) (*NsxtEdgeGatewayStaticRoute, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+
+	queryParameters := url.Values{}
+	queryParameters.Add("filter", "name=="+name)
+
+	allStaticRoutes, err := egw.GetAllStaticRoutes(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway static route with Name '%s': %s", name, err)
+	}
+
+	if len(allStaticRoutes) == 0 {
+		return nil, fmt.Errorf("%s: could not find NSX-T Edge Gateway static route with Name '%s'", ErrorEntityNotFound, name)
+	}
+
+	if len(allStaticRoutes) > 1 {
+		return nil, fmt.Errorf("found more than 1 NSX-T Edge Gateway static route with Name '%s'", name)
+	}
+
+	return allStaticRoutes[0], nil
+}
+
+// GetStaticRouteByNetworkCidr retrieves NSX-T Edge Gateway static route by network CIDR
+func (egw *NsxtEdgeGateway) GetStaticRouteByNetworkCidr(ctx context.Context, networkCidr string) (*NsxtEdgeGatewayStaticRoute, error) {
+	if networkCidr == "" {
+		return nil, fmt.Errorf("network CIDR cannot be empty")
+	}
+
+	queryParameters := url.Values{}
+	queryParameters.Add("filter", "networkCidr=="+networkCidr)
+
+	allStaticRoutes, err := egw.GetAllStaticRoutes(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway static route with network CIDR '%s': %s",
+			networkCidr, err)
+	}
+
+	if len(allStaticRoutes) == 0 {
+		return nil, fmt.Errorf("%s: could not find NSX-T Edge Gateway static route with network CIDR '%s'",
+			ErrorEntityNotFound, networkCidr)
+	}
+
+	if len(allStaticRoutes) > 1 {
+		return nil, fmt.Errorf("found more than 1 NSX-T Edge Gateway static route with network CIDR '%s'", networkCidr)
+	}
+
+	return allStaticRoutes[0], nil
+}
+
+// GetStaticRouteById retrieves NSX-T Edge Gateway static route by ID
+func (egw *NsxtEdgeGateway) GetStaticRouteById(ctx context.Context, id string) (*NsxtEdgeGatewayStaticRoute, error) {
+	if id == "" {
+		return nil, fmt.Errorf("ID is required to lookup NSX-T Edge Gateway static route by ID")
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeGatewayStaticRoutes
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID), id)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponse := &types.NsxtEdgeGatewayStaticRoute{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, typeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NsxtEdgeGatewayStaticRoute{
+		NsxtEdgeGatewayStaticRoute: typeResponse,
+		client:                     client,
+		edgeGatewayId:              egw.EdgeGateway.ID,
+	}, nil
+}
+
+// CreateStaticRoute creates a static route for NSX-T Edge Gateway
+//
+// Note. The API does not return ID of created static route in the task, therefore it is looked up by Name and
+// network CIDR after the task completes.
+func (egw *NsxtEdgeGateway) CreateStaticRoute(ctx context.Context, staticRouteConfig *types.NsxtEdgeGatewayStaticRoute) (*NsxtEdgeGatewayStaticRoute, error) {
+	if err := validateNsxtEdgeGatewayStaticRoute(staticRouteConfig); err != nil {
+		return nil, err
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeGatewayStaticRoutes
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := client.OpenApiPostItemAsync(ctx, minimumApiVersion, urlRef, nil, staticRouteConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway static route: %s", err)
+	}
+
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NSX-T Edge Gateway static route: %s", err)
+	}
+
+	queryParameters := url.Values{}
+	queryParameters.Add("filter", "name=="+staticRouteConfig.Name)
+	queryParameters = queryParameterFilterAnd("networkCidr=="+staticRouteConfig.NetworkCidr, queryParameters)
+
+	allStaticRoutes, err := egw.GetAllStaticRoutes(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving created NSX-T Edge Gateway static route: %s", err)
+	}
+
+	if len(allStaticRoutes) != 1 {
+		return nil, fmt.Errorf("expected to find exactly 1 NSX-T Edge Gateway static route with Name '%s' and network CIDR '%s', got %d",
+			staticRouteConfig.Name, staticRouteConfig.NetworkCidr, len(allStaticRoutes))
+	}
+
+	return allStaticRoutes[0], nil
+}
+
+// Update updates NSX-T Edge Gateway static route based on supplied staticRouteConfig. ID must be set.
+func (staticRoute *NsxtEdgeGatewayStaticRoute) Update(ctx context.Context, staticRouteConfig *types.NsxtEdgeGatewayStaticRoute) (*NsxtEdgeGatewayStaticRoute, error) {
+	if err := validateNsxtEdgeGatewayStaticRoute(staticRouteConfig); err != nil {
+		return nil, err
+	}
+
+	if staticRouteConfig.ID == "" {
+		return nil, fmt.Errorf("cannot update NSX-T Edge Gateway static route without ID")
+	}
+
+	client := staticRoute.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeGatewayStaticRoutes
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, staticRoute.edgeGatewayId), staticRouteConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	returnObject := &NsxtEdgeGatewayStaticRoute{
+		NsxtEdgeGatewayStaticRoute: &types.NsxtEdgeGatewayStaticRoute{},
+		client:                     client,
+		edgeGatewayId:              staticRoute.edgeGatewayId,
+	}
+
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, staticRouteConfig, returnObject.NsxtEdgeGatewayStaticRoute)
+	if err != nil {
+		return nil, fmt.Errorf("error updating NSX-T Edge Gateway static route: %s", err)
+	}
+
+	return returnObject, nil
+}
+
+// Delete deletes NSX-T Edge Gateway static route
+func (staticRoute *NsxtEdgeGatewayStaticRoute) Delete(ctx context.Context) error {
+	client := staticRoute.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeGatewayStaticRoutes
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if staticRoute.NsxtEdgeGatewayStaticRoute.ID == "" {
+		return fmt.Errorf("cannot delete NSX-T Edge Gateway static route without ID")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, staticRoute.edgeGatewayId), staticRoute.NsxtEdgeGatewayStaticRoute.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting NSX-T Edge Gateway static route: %s", err)
+	}
+
+	return nil
+}
+
+func validateNsxtEdgeGatewayStaticRoute(staticRouteConfig *types.NsxtEdgeGatewayStaticRoute) error {
+	if staticRouteConfig == nil {
+		return fmt.Errorf("NSX-T Edge Gateway static route configuration cannot be nil")
+	}
+
+	if staticRouteConfig.Name == "" {
+		return fmt.Errorf("NSX-T Edge Gateway static route Name cannot be empty")
+	}
+
+	if _, _, err := net.ParseCIDR(staticRouteConfig.NetworkCidr); err != nil {
+		return fmt.Errorf("NSX-T Edge Gateway static route NetworkCidr must be a valid CIDR. Got '%s'",
+			staticRouteConfig.NetworkCidr)
+	}
+
+	if len(staticRouteConfig.NextHops) == 0 {
+		return fmt.Errorf("NSX-T Edge Gateway static route must have at least one next hop")
+	}
+
+	for _, nextHop := range staticRouteConfig.NextHops {
+		if net.ParseIP(nextHop.IPAddress) == nil {
+			return fmt.Errorf("NSX-T Edge Gateway static route next hop must have a valid IPAddress. Got '%s'",
+				nextHop.IPAddress)
+		}
+		if nextHop.AdminDistance < 1 || nextHop.AdminDistance > 255 {
+			return fmt.Errorf("NSX-T Edge Gateway static route next hop '%s' AdminDistance must be between 1 and 255. Got %d",
+				nextHop.IPAddress, nextHop.AdminDistance)
+		}
+	}
+
+	return nil
+}
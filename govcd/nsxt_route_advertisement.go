@@ -0,0 +1,118 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// GetNsxtRouteAdvertisement retrieves the list of subnets that will be advertised so that the Edge Gateway can route
+// out to the connected external network.
+func (egw *NsxtEdgeGateway) GetNsxtRouteAdvertisement(ctx context.Context) (*types.RouteAdvertisement, error) {
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointNsxtRouteAdvertisement
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	routeAdvertisement := &types.RouteAdvertisement{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, routeAdvertisement)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving route advertisement for Edge Gateway '%s': %s",
+			egw.EdgeGateway.Name, err)
+	}
+
+	return routeAdvertisement, nil
+}
+
+// UpdateNsxtRouteAdvertisement updates the list of subnets (usually subnets of routed Org VDC networks connected to
+// this Edge Gateway) that will be advertised. Subnets must be in CIDR format.
+func (egw *NsxtEdgeGateway) UpdateNsxtRouteAdvertisement(ctx context.Context, enable bool, subnets []string) (*types.RouteAdvertisement, error) {
+	for _, subnet := range subnets {
+		if _, _, err := net.ParseCIDR(subnet); err != nil {
+			return nil, fmt.Errorf("route advertisement subnet must be in CIDR format. Got '%s'", subnet)
+		}
+	}
+
+	client := egw.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointNsxtRouteAdvertisement
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, egw.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	// API expects an empty list instead of null when no subnets are advertised
+	if subnets == nil {
+		subnets = []string{}
+	}
+
+	routeAdvertisement := &types.RouteAdvertisement{
+		Enable:  enable,
+		Subnets: subnets,
+	}
+
+	typeResponse := &types.RouteAdvertisement{}
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, routeAdvertisement, typeResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error updating route advertisement for Edge Gateway '%s': %s",
+			egw.EdgeGateway.Name, err)
+	}
+
+	return typeResponse, nil
+}
+
+// UpdateNsxtRouteAdvertisementWithOrgVdcNetworks is a convenience wrapper around UpdateNsxtRouteAdvertisement which
+// advertises subnets of the given routed Org VDC networks. Networks must be connected to this Edge Gateway.
+func (egw *NsxtEdgeGateway) UpdateNsxtRouteAdvertisementWithOrgVdcNetworks(ctx context.Context, enable bool, orgVdcNetworks []*OpenApiOrgVdcNetwork) (*types.RouteAdvertisement, error) {
+	var subnets []string
+	for _, network := range orgVdcNetworks {
+		if network == nil || network.OpenApiOrgVdcNetwork == nil {
+			return nil, fmt.Errorf("Org VDC network cannot be nil")
+		}
+
+		if network.OpenApiOrgVdcNetwork.Connection == nil ||
+			network.OpenApiOrgVdcNetwork.Connection.RouterRef.ID != egw.EdgeGateway.ID {
+			return nil, fmt.Errorf("Org VDC network '%s' is not connected to Edge Gateway '%s'",
+				network.OpenApiOrgVdcNetwork.Name, egw.EdgeGateway.Name)
+		}
+
+		// Subnets are defined by gateway IP and prefix length, while route advertisement expects network address
+		for _, subnet := range network.OpenApiOrgVdcNetwork.Subnets.Values {
+			_, ipNet, err := net.ParseCIDR(fmt.Sprintf("%s/%d", subnet.Gateway, subnet.PrefixLength))
+			if err != nil {
+				return nil, fmt.Errorf("error parsing subnet of Org VDC network '%s': %s",
+					network.OpenApiOrgVdcNetwork.Name, err)
+			}
+			subnets = append(subnets, ipNet.String())
+		}
+	}
+
+	return egw.UpdateNsxtRouteAdvertisement(ctx, enable, subnets)
+}
+
+// DeleteNsxtRouteAdvertisement deletes the list of subnets that will be advertised
+func (egw *NsxtEdgeGateway) DeleteNsxtRouteAdvertisement(ctx context.Context) error {
+	_, err := egw.UpdateNsxtRouteAdvertisement(ctx, false, []string{})
+	if err != nil {
+		return fmt.Errorf("error deleting route advertisement: %s", err)
+	}
+
+	return nil
+}
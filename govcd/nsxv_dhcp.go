@@ -0,0 +1,407 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// GetNsxvDhcpConfig retrieves DHCP configuration (IP pools and static bindings) of an advanced
+// edge gateway using proxied NSX-V API
+func (egw *EdgeGateway) GetNsxvDhcpConfig(ctx context.Context) (*types.EdgeDhcpConfig, error) {
+	if !egw.HasAdvancedNetworking() {
+		return nil, fmt.Errorf("only advanced edge gateways support DHCP")
+	}
+	response := &types.EdgeDhcpConfig{}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDhcpConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+
+	// This query Edge gateway DHCP configuration using proxied NSX-V API
+	_, err = egw.client.ExecuteRequest(ctx, httpPath, http.MethodGet, types.AnyXMLMime,
+		"unable to read edge gateway DHCP configuration: %s", nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// UpdateNsxvDhcpConfig replaces DHCP configuration (IP pools and static bindings) of an advanced
+// edge gateway and returns it. IP pools and static bindings missing in dhcpConfig are removed.
+func (egw *EdgeGateway) UpdateNsxvDhcpConfig(ctx context.Context, dhcpConfig *types.EdgeDhcpConfig) (*types.EdgeDhcpConfig, error) {
+	if err := validateUpdateNsxvDhcpConfig(dhcpConfig, egw); err != nil {
+		return nil, err
+	}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDhcpConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+	// We expect to get http.StatusNoContent or if not an error of type types.NSXError
+	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPut, types.AnyXMLMime,
+		"error setting DHCP configuration: %s", dhcpConfig, &types.NSXError{})
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.GetNsxvDhcpConfig(ctx)
+}
+
+// ResetNsxvDhcpConfig removes all DHCP IP pools and static bindings by sending a DELETE request
+// for DHCP configuration endpoint
+func (egw *EdgeGateway) ResetNsxvDhcpConfig(ctx context.Context) error {
+	if !egw.HasAdvancedNetworking() {
+		return fmt.Errorf("only advanced edge gateways support DHCP")
+	}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDhcpConfigPath)
+	if err != nil {
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+
+	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodDelete, types.AnyXMLMime,
+		"unable to reset edge gateway DHCP configuration: %s", nil, &types.NSXError{})
+	return err
+}
+
+// CreateNsxvDhcpStaticBinding creates DHCP static binding (IP reservation) for a MAC address or a
+// VM network adapter. It returns the binding with all fields populated (including BindingId)
+func (egw *EdgeGateway) CreateNsxvDhcpStaticBinding(ctx context.Context, bindingConfig *types.EdgeDhcpStaticBinding) (*types.EdgeDhcpStaticBinding, error) {
+	if err := validateCreateNsxvDhcpStaticBinding(bindingConfig, egw); err != nil {
+		return nil, err
+	}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDhcpBindingsPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+	// We expect to get http.StatusCreated or if not an error of type types.NSXError
+	resp, err := egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPost, types.AnyXMLMime,
+		"error creating DHCP static binding: %s", bindingConfig, &types.NSXError{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Location header should look similar to:
+	// /api/4.0/edges/edge-1/dhcp/config/bindings/binding-1
+	bindingId, err := extractNsxObjectIdFromPath(resp.Header.Get("Location"))
+	if err != nil {
+		return nil, err
+	}
+
+	readBinding, err := egw.GetNsxvDhcpStaticBindingById(ctx, bindingId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve DHCP static binding with ID (%s) after creation: %s",
+			bindingId, err)
+	}
+	return readBinding, nil
+}
+
+// GetNsxvDhcpStaticBindingById retrieves DHCP static binding by ID (e.g. "binding-1")
+func (egw *EdgeGateway) GetNsxvDhcpStaticBindingById(ctx context.Context, id string) (*types.EdgeDhcpStaticBinding, error) {
+	if err := validateGetNsxvDhcpStaticBinding(id, egw); err != nil {
+		return nil, err
+	}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDhcpBindingsPath + "/" + id)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+
+	binding := &types.EdgeDhcpStaticBinding{}
+	_, err = egw.client.ExecuteRequest(ctx, httpPath, http.MethodGet, types.AnyXMLMime,
+		"unable to read DHCP static binding: %s", nil, binding)
+	if err != nil {
+		return nil, err
+	}
+
+	return binding, nil
+}
+
+// GetAllNsxvDhcpStaticBindings retrieves all DHCP static bindings of an advanced edge gateway
+func (egw *EdgeGateway) GetAllNsxvDhcpStaticBindings(ctx context.Context) ([]*types.EdgeDhcpStaticBinding, error) {
+	dhcpConfig, err := egw.GetNsxvDhcpConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if dhcpConfig.StaticBindings == nil {
+		return []*types.EdgeDhcpStaticBinding{}, nil
+	}
+
+	bindings := make([]*types.EdgeDhcpStaticBinding, len(dhcpConfig.StaticBindings.StaticBinding))
+	for index := range dhcpConfig.StaticBindings.StaticBinding {
+		bindings[index] = &dhcpConfig.StaticBindings.StaticBinding[index]
+	}
+
+	return bindings, nil
+}
+
+// GetNsxvDhcpStaticBindingByMac retrieves DHCP static binding by MAC address
+func (egw *EdgeGateway) GetNsxvDhcpStaticBindingByMac(ctx context.Context, mac string) (*types.EdgeDhcpStaticBinding, error) {
+	if mac == "" {
+		return nil, fmt.Errorf("MAC address must be provided to lookup DHCP static binding")
+	}
+
+	bindings, err := egw.GetAllNsxvDhcpStaticBindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, binding := range bindings {
+		if strings.EqualFold(binding.MacAddress, mac) {
+			return binding, nil
+		}
+	}
+
+	return nil, ErrorEntityNotFound
+}
+
+// UpdateNsxvDhcpStaticBinding updates DHCP static binding. BindingId is mandatory.
+//
+// Note. NSX-V API does not support updating a single binding, therefore complete DHCP
+// configuration is retrieved, the binding is replaced in it and configuration is sent back.
+func (egw *EdgeGateway) UpdateNsxvDhcpStaticBinding(ctx context.Context, bindingConfig *types.EdgeDhcpStaticBinding) (*types.EdgeDhcpStaticBinding, error) {
+	if err := validateUpdateNsxvDhcpStaticBinding(bindingConfig, egw); err != nil {
+		return nil, err
+	}
+
+	dhcpConfig, err := egw.GetNsxvDhcpConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	if dhcpConfig.StaticBindings != nil {
+		for index, binding := range dhcpConfig.StaticBindings.StaticBinding {
+			if binding.BindingId == bindingConfig.BindingId {
+				dhcpConfig.StaticBindings.StaticBinding[index] = *bindingConfig
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%s: DHCP static binding with ID '%s'", ErrorEntityNotFound, bindingConfig.BindingId)
+	}
+
+	_, err = egw.UpdateNsxvDhcpConfig(ctx, dhcpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.GetNsxvDhcpStaticBindingById(ctx, bindingConfig.BindingId)
+}
+
+// DeleteNsxvDhcpStaticBinding deletes DHCP static binding by ID
+func (egw *EdgeGateway) DeleteNsxvDhcpStaticBinding(ctx context.Context, id string) error {
+	if err := validateGetNsxvDhcpStaticBinding(id, egw); err != nil {
+		return err
+	}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDhcpBindingsPath + "/" + id)
+	if err != nil {
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+
+	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodDelete, types.AnyXMLMime,
+		"unable to delete DHCP static binding: %s", nil, &types.NSXError{})
+	return err
+}
+
+// GetNsxvDnsConfig retrieves DNS forwarder configuration of an advanced edge gateway
+func (egw *EdgeGateway) GetNsxvDnsConfig(ctx context.Context) (*types.EdgeDnsConfig, error) {
+	if !egw.HasAdvancedNetworking() {
+		return nil, fmt.Errorf("only advanced edge gateways support DNS forwarder")
+	}
+	response := &types.EdgeDnsConfig{}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDnsConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+
+	_, err = egw.client.ExecuteRequest(ctx, httpPath, http.MethodGet, types.AnyXMLMime,
+		"unable to read edge gateway DNS configuration: %s", nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// UpdateNsxvDnsConfig updates DNS forwarder configuration of an advanced edge gateway and returns it
+func (egw *EdgeGateway) UpdateNsxvDnsConfig(ctx context.Context, dnsConfig *types.EdgeDnsConfig) (*types.EdgeDnsConfig, error) {
+	if err := validateUpdateNsxvDnsConfig(dnsConfig, egw); err != nil {
+		return nil, err
+	}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDnsConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+	// We expect to get http.StatusNoContent or if not an error of type types.NSXError
+	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPut, types.AnyXMLMime,
+		"error setting DNS configuration: %s", dnsConfig, &types.NSXError{})
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.GetNsxvDnsConfig(ctx)
+}
+
+// ResetNsxvDnsConfig removes DNS forwarder configuration by sending a DELETE request for DNS
+// configuration endpoint
+func (egw *EdgeGateway) ResetNsxvDnsConfig(ctx context.Context) error {
+	if !egw.HasAdvancedNetworking() {
+		return fmt.Errorf("only advanced edge gateways support DNS forwarder")
+	}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeDnsConfigPath)
+	if err != nil {
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+
+	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodDelete, types.AnyXMLMime,
+		"unable to reset edge gateway DNS configuration: %s", nil, &types.NSXError{})
+	return err
+}
+
+// validateNsxvDhcpLeaseTime checks that lease time is either empty, "infinite" or a positive number
+// of seconds
+func validateNsxvDhcpLeaseTime(leaseTime string) error {
+	if leaseTime == "" || leaseTime == "infinite" {
+		return nil
+	}
+
+	seconds, err := strconv.Atoi(leaseTime)
+	if err != nil || seconds <= 0 {
+		return fmt.Errorf("DHCP lease time must be a positive number of seconds or 'infinite', got '%s'", leaseTime)
+	}
+
+	return nil
+}
+
+func validateUpdateNsxvDhcpConfig(dhcpConfig *types.EdgeDhcpConfig, egw *EdgeGateway) error {
+	if !egw.HasAdvancedNetworking() {
+		return fmt.Errorf("only advanced edge gateways support DHCP")
+	}
+
+	if dhcpConfig == nil {
+		return fmt.Errorf("DHCP configuration cannot be nil")
+	}
+
+	if dhcpConfig.IpPools != nil {
+		for _, ipPool := range dhcpConfig.IpPools.IpPool {
+			ipRange := strings.Split(ipPool.IpRange, "-")
+			if len(ipRange) != 2 || net.ParseIP(ipRange[0]) == nil || net.ParseIP(ipRange[1]) == nil {
+				return fmt.Errorf("DHCP IP pool range must be in format 'start-end', got '%s'", ipPool.IpRange)
+			}
+			if ipPool.DefaultGateway != "" && net.ParseIP(ipPool.DefaultGateway) == nil {
+				return fmt.Errorf("DHCP IP pool '%s' has invalid default gateway '%s'", ipPool.IpRange,
+					ipPool.DefaultGateway)
+			}
+			if err := validateNsxvDhcpLeaseTime(ipPool.LeaseTime); err != nil {
+				return err
+			}
+		}
+	}
+
+	if dhcpConfig.StaticBindings != nil {
+		for index := range dhcpConfig.StaticBindings.StaticBinding {
+			if err := validateNsxvDhcpStaticBindingFields(&dhcpConfig.StaticBindings.StaticBinding[index]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateNsxvDhcpStaticBindingFields(bindingConfig *types.EdgeDhcpStaticBinding) error {
+	if bindingConfig == nil {
+		return fmt.Errorf("DHCP static binding cannot be nil")
+	}
+
+	hasMac := bindingConfig.MacAddress != ""
+	hasVm := bindingConfig.VmId != "" && bindingConfig.VnicId != nil
+	if hasMac == hasVm {
+		return fmt.Errorf("DHCP static binding must have either MAC address or VM ID with vNic ID")
+	}
+
+	if hasMac {
+		if _, err := net.ParseMAC(bindingConfig.MacAddress); err != nil {
+			return fmt.Errorf("DHCP static binding has invalid MAC address '%s'", bindingConfig.MacAddress)
+		}
+	}
+
+	if net.ParseIP(bindingConfig.IpAddress) == nil {
+		return fmt.Errorf("DHCP static binding must have a valid IP address, got '%s'", bindingConfig.IpAddress)
+	}
+
+	return validateNsxvDhcpLeaseTime(bindingConfig.LeaseTime)
+}
+
+func validateCreateNsxvDhcpStaticBinding(bindingConfig *types.EdgeDhcpStaticBinding, egw *EdgeGateway) error {
+	if !egw.HasAdvancedNetworking() {
+		return fmt.Errorf("only advanced edge gateways support DHCP static bindings")
+	}
+
+	return validateNsxvDhcpStaticBindingFields(bindingConfig)
+}
+
+func validateUpdateNsxvDhcpStaticBinding(bindingConfig *types.EdgeDhcpStaticBinding, egw *EdgeGateway) error {
+	if bindingConfig != nil && bindingConfig.BindingId == "" {
+		return fmt.Errorf("DHCP static binding ID must be set for update")
+	}
+
+	return validateCreateNsxvDhcpStaticBinding(bindingConfig, egw)
+}
+
+func validateGetNsxvDhcpStaticBinding(id string, egw *EdgeGateway) error {
+	if !egw.HasAdvancedNetworking() {
+		return fmt.Errorf("only advanced edge gateways support DHCP static bindings")
+	}
+
+	if id == "" {
+		return fmt.Errorf("unable to retrieve DHCP static binding without ID")
+	}
+
+	return nil
+}
+
+func validateUpdateNsxvDnsConfig(dnsConfig *types.EdgeDnsConfig, egw *EdgeGateway) error {
+	if !egw.HasAdvancedNetworking() {
+		return fmt.Errorf("only advanced edge gateways support DNS forwarder")
+	}
+
+	if dnsConfig == nil {
+		return fmt.Errorf("DNS configuration cannot be nil")
+	}
+
+	if dnsConfig.DnsServers != nil {
+		for _, ipAddress := range dnsConfig.DnsServers.IpAddress {
+			if net.ParseIP(ipAddress) == nil {
+				return fmt.Errorf("DNS server '%s' is not a valid IP address", ipAddress)
+			}
+		}
+	}
+
+	if dnsConfig.CacheSize < 0 {
+		return fmt.Errorf("DNS cache size cannot be negative")
+	}
+
+	return nil
+}
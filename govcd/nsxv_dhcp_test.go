@@ -0,0 +1,89 @@
+// +build nsxv functional ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+// Test_NsxvDhcpConfig tests out Edge gateway DHCP and DNS forwarder configuration. It does the
+// following:
+// 1. Sets DHCP IP pool and checks it
+// 2. Creates, updates and deletes a static binding by MAC address
+// 3. Sets DNS forwarder configuration and resets it
+// 4. Resets DHCP configuration
+func (vcd *TestVCD) Test_NsxvDhcpConfig(check *C) {
+	if vcd.config.VCD.EdgeGateway == "" {
+		check.Skip("Skipping test because no edge gateway given")
+	}
+
+	edge, err := vcd.vdc.GetEdgeGatewayByName(ctx, vcd.config.VCD.EdgeGateway, false)
+	check.Assert(err, IsNil)
+
+	dhcpConfig, err := edge.UpdateNsxvDhcpConfig(ctx, &types.EdgeDhcpConfig{
+		Enabled: true,
+		IpPools: &types.EdgeDhcpIpPools{
+			IpPool: []types.EdgeDhcpIpPool{
+				{
+					IpRange:          "10.10.10.100-10.10.10.150",
+					DefaultGateway:   "10.10.10.1",
+					SubnetMask:       "255.255.255.0",
+					AutoConfigureDNS: true,
+					LeaseTime:        "3600",
+				},
+			},
+		},
+	})
+	check.Assert(err, IsNil)
+	check.Assert(dhcpConfig.Enabled, Equals, true)
+	check.Assert(len(dhcpConfig.IpPools.IpPool), Equals, 1)
+	check.Assert(dhcpConfig.IpPools.IpPool[0].LeaseTime, Equals, "3600")
+
+	binding, err := edge.CreateNsxvDhcpStaticBinding(ctx, &types.EdgeDhcpStaticBinding{
+		MacAddress:     "00:50:56:01:29:c8",
+		Hostname:       "test-host",
+		IpAddress:      "10.10.10.20",
+		DefaultGateway: "10.10.10.1",
+		SubnetMask:     "255.255.255.0",
+		LeaseTime:      "infinite",
+	})
+	check.Assert(err, IsNil)
+	check.Assert(binding.BindingId, Not(Equals), "")
+
+	bindingByMac, err := edge.GetNsxvDhcpStaticBindingByMac(ctx, "00:50:56:01:29:C8")
+	check.Assert(err, IsNil)
+	check.Assert(bindingByMac.BindingId, Equals, binding.BindingId)
+
+	binding.IpAddress = "10.10.10.21"
+	updatedBinding, err := edge.UpdateNsxvDhcpStaticBinding(ctx, binding)
+	check.Assert(err, IsNil)
+	check.Assert(updatedBinding.IpAddress, Equals, "10.10.10.21")
+
+	err = edge.DeleteNsxvDhcpStaticBinding(ctx, binding.BindingId)
+	check.Assert(err, IsNil)
+	_, err = edge.GetNsxvDhcpStaticBindingByMac(ctx, "00:50:56:01:29:c8")
+	check.Assert(err, Equals, ErrorEntityNotFound)
+
+	dnsConfig, err := edge.UpdateNsxvDnsConfig(ctx, &types.EdgeDnsConfig{
+		Enabled:    true,
+		CacheSize:  16,
+		DnsServers: &types.EdgeDnsServers{IpAddress: []string{"8.8.8.8", "8.8.4.4"}},
+	})
+	check.Assert(err, IsNil)
+	check.Assert(dnsConfig.Enabled, Equals, true)
+	check.Assert(dnsConfig.DnsServers.IpAddress, DeepEquals, []string{"8.8.8.8", "8.8.4.4"})
+
+	err = edge.ResetNsxvDnsConfig(ctx)
+	check.Assert(err, IsNil)
+
+	err = edge.ResetNsxvDhcpConfig(ctx)
+	check.Assert(err, IsNil)
+	dhcpConfig, err = edge.GetNsxvDhcpConfig(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(dhcpConfig.IpPools == nil || len(dhcpConfig.IpPools.IpPool) == 0, Equals, true)
+}
@@ -0,0 +1,115 @@
+// +build unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_validateNsxvDhcpStaticBinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *types.EdgeDhcpStaticBinding
+		wantErr bool
+	}{
+		{
+			name:    "Nil",
+			config:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "NoMacAndNoVm",
+			config:  &types.EdgeDhcpStaticBinding{IpAddress: "10.10.10.10"},
+			wantErr: true,
+		},
+		{
+			name: "MacAndVm",
+			config: &types.EdgeDhcpStaticBinding{MacAddress: "00:50:56:01:29:c8", VmId: "vm-34",
+				VnicId: takeIntAddress(0), IpAddress: "10.10.10.10"},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidMac",
+			config:  &types.EdgeDhcpStaticBinding{MacAddress: "00:50:56", IpAddress: "10.10.10.10"},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidIp",
+			config:  &types.EdgeDhcpStaticBinding{MacAddress: "00:50:56:01:29:c8", IpAddress: "10.10.10"},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidLeaseTime",
+			config:  &types.EdgeDhcpStaticBinding{MacAddress: "00:50:56:01:29:c8", IpAddress: "10.10.10.10", LeaseTime: "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "ValidMac",
+			config:  &types.EdgeDhcpStaticBinding{MacAddress: "00:50:56:01:29:c8", IpAddress: "10.10.10.10", LeaseTime: "infinite"},
+			wantErr: false,
+		},
+		{
+			name:    "ValidVm",
+			config:  &types.EdgeDhcpStaticBinding{VmId: "vm-34", VnicId: takeIntAddress(1), IpAddress: "10.10.10.10", LeaseTime: "3600"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCreateNsxvDhcpStaticBinding(tt.config, advancedEdgeGatewayForTest())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCreateNsxvDhcpStaticBinding() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateUpdateNsxvDhcpConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *types.EdgeDhcpConfig
+		wantErr bool
+	}{
+		{
+			name: "InvalidPoolRange",
+			config: &types.EdgeDhcpConfig{Enabled: true, IpPools: &types.EdgeDhcpIpPools{
+				IpPool: []types.EdgeDhcpIpPool{{IpRange: "10.10.10.100"}}}},
+			wantErr: true,
+		},
+		{
+			name: "InvalidPoolGateway",
+			config: &types.EdgeDhcpConfig{Enabled: true, IpPools: &types.EdgeDhcpIpPools{
+				IpPool: []types.EdgeDhcpIpPool{{IpRange: "10.10.10.100-10.10.10.200", DefaultGateway: "gw"}}}},
+			wantErr: true,
+		},
+		{
+			name: "InvalidBinding",
+			config: &types.EdgeDhcpConfig{Enabled: true, StaticBindings: &types.EdgeDhcpStaticBindings{
+				StaticBinding: []types.EdgeDhcpStaticBinding{{IpAddress: "10.10.10.10"}}}},
+			wantErr: true,
+		},
+		{
+			name: "Valid",
+			config: &types.EdgeDhcpConfig{Enabled: true,
+				IpPools: &types.EdgeDhcpIpPools{IpPool: []types.EdgeDhcpIpPool{{IpRange: "10.10.10.100-10.10.10.200",
+					DefaultGateway: "10.10.10.1", SubnetMask: "255.255.255.0", LeaseTime: "86400"}}},
+				StaticBindings: &types.EdgeDhcpStaticBindings{StaticBinding: []types.EdgeDhcpStaticBinding{
+					{MacAddress: "00:50:56:01:29:c8", IpAddress: "10.10.10.10"}}}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUpdateNsxvDhcpConfig(tt.config, advancedEdgeGatewayForTest())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateUpdateNsxvDhcpConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// UpdateL2Vpn updates L2 VPN configuration for a particular edge gateway and returns it. The edge
+// gateway acts either as an L2 VPN server (peer sites connect to it) or as an L2 VPN client.
+func (egw *EdgeGateway) UpdateL2Vpn(ctx context.Context, l2VpnConfig *types.EdgeL2Vpn) (*types.EdgeL2Vpn, error) {
+	if err := validateUpdateL2Vpn(l2VpnConfig, egw); err != nil {
+		return nil, err
+	}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeL2VpnPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+	// We expect to get http.StatusNoContent or if not an error of type types.NSXError
+	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPut, types.AnyXMLMime,
+		"error setting L2 VPN settings: %s", l2VpnConfig, &types.NSXError{})
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.GetL2Vpn(ctx)
+}
+
+// GetL2Vpn retrieves L2 VPN configuration of a particular edge gateway
+func (egw *EdgeGateway) GetL2Vpn(ctx context.Context) (*types.EdgeL2Vpn, error) {
+	if !egw.HasAdvancedNetworking() {
+		return nil, fmt.Errorf("only advanced edge gateways support L2 VPN")
+	}
+	response := &types.EdgeL2Vpn{}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeL2VpnPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+
+	// This query Edge gateway L2 VPN using proxied NSX-V API
+	_, err = egw.client.ExecuteRequest(ctx, httpPath, http.MethodGet, types.AnyXMLMime,
+		"unable to read edge gateway L2 VPN configuration: %s", nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func validateUpdateL2Vpn(l2VpnConfig *types.EdgeL2Vpn, egw *EdgeGateway) error {
+	if !egw.HasAdvancedNetworking() {
+		return fmt.Errorf("only advanced edge gateways support L2 VPN")
+	}
+
+	if l2VpnConfig == nil {
+		return fmt.Errorf("L2 VPN configuration cannot be nil")
+	}
+
+	if l2VpnConfig.L2VpnSites == nil {
+		return nil
+	}
+
+	for _, site := range l2VpnConfig.L2VpnSites.L2VpnSite {
+		if (site.Server == nil) == (site.Client == nil) {
+			return fmt.Errorf("L2 VPN site must have either server or client configuration")
+		}
+
+		if site.Server != nil {
+			if net.ParseIP(site.Server.ListenerIp) == nil {
+				return fmt.Errorf("L2 VPN server must have a valid listener IP, got '%s'", site.Server.ListenerIp)
+			}
+			if site.Server.PeerSites == nil {
+				continue
+			}
+			for _, peerSite := range site.Server.PeerSites.PeerSite {
+				if peerSite.Name == "" {
+					return fmt.Errorf("L2 VPN peer site must have a name")
+				}
+				if peerSite.L2VpnUser == nil || peerSite.L2VpnUser.UserId == "" {
+					return fmt.Errorf("L2 VPN peer site '%s' must have a user", peerSite.Name)
+				}
+				if peerSite.Vnics == nil || len(peerSite.Vnics.Index) == 0 {
+					return fmt.Errorf("L2 VPN peer site '%s' must stretch at least one vNic", peerSite.Name)
+				}
+			}
+		}
+
+		if site.Client != nil {
+			if site.Client.Configuration == nil || site.Client.Configuration.ServerAddress == "" {
+				return fmt.Errorf("L2 VPN client must have server address configured")
+			}
+			if site.Client.L2VpnUser == nil || site.Client.L2VpnUser.UserId == "" {
+				return fmt.Errorf("L2 VPN client must have a user")
+			}
+		}
+	}
+
+	return nil
+}
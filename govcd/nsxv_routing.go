@@ -0,0 +1,238 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// GetNsxvRoutingConfig retrieves complete routing configuration (global settings, static routes,
+// OSPF and BGP) of an advanced edge gateway using proxied NSX-V API
+func (egw *EdgeGateway) GetNsxvRoutingConfig(ctx context.Context) (*types.EdgeRouting, error) {
+	if !egw.HasAdvancedNetworking() {
+		return nil, fmt.Errorf("only advanced edge gateways support routing configuration")
+	}
+	response := &types.EdgeRouting{}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(types.EdgeRoutingPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+
+	_, err = egw.client.ExecuteRequest(ctx, httpPath, http.MethodGet, types.AnyXMLMime,
+		"unable to read edge gateway routing configuration: %s", nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// UpdateNsxvStaticRoutes replaces default route and all static routes of an advanced edge gateway
+// and returns the new static routing configuration. Static routes not present in
+// staticRoutingConfig are removed.
+func (egw *EdgeGateway) UpdateNsxvStaticRoutes(ctx context.Context, staticRoutingConfig *types.EdgeStaticRouting) (*types.EdgeStaticRouting, error) {
+	if err := validateUpdateNsxvStaticRoutes(staticRoutingConfig, egw); err != nil {
+		return nil, err
+	}
+
+	err := egw.updateNsxvRoutingSection(ctx, types.EdgeStaticRoutingPath, "static routes", staticRoutingConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	routingConfig, err := egw.GetNsxvRoutingConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return routingConfig.StaticRouting, nil
+}
+
+// UpdateNsxvOspf updates OSPF configuration of an advanced edge gateway and returns it.
+//
+// Note. Dynamic routing requires RouterId to be set in global routing configuration.
+func (egw *EdgeGateway) UpdateNsxvOspf(ctx context.Context, ospfConfig *types.EdgeOspfRouting) (*types.EdgeOspfRouting, error) {
+	if err := validateUpdateNsxvOspf(ospfConfig, egw); err != nil {
+		return nil, err
+	}
+
+	err := egw.updateNsxvRoutingSection(ctx, types.EdgeOspfRoutingPath, "OSPF", ospfConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	routingConfig, err := egw.GetNsxvRoutingConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return routingConfig.Ospf, nil
+}
+
+// UpdateNsxvBgp updates BGP configuration of an advanced edge gateway and returns it.
+//
+// Note. Dynamic routing requires RouterId to be set in global routing configuration.
+func (egw *EdgeGateway) UpdateNsxvBgp(ctx context.Context, bgpConfig *types.EdgeBgpRouting) (*types.EdgeBgpRouting, error) {
+	if err := validateUpdateNsxvBgp(bgpConfig, egw); err != nil {
+		return nil, err
+	}
+
+	err := egw.updateNsxvRoutingSection(ctx, types.EdgeBgpRoutingPath, "BGP", bgpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	routingConfig, err := egw.GetNsxvRoutingConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return routingConfig.Bgp, nil
+}
+
+// updateNsxvRoutingSection sends a PUT request with payload to a particular routing configuration
+// section endpoint
+func (egw *EdgeGateway) updateNsxvRoutingSection(ctx context.Context, sectionPath, sectionName string, payload interface{}) error {
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(sectionPath)
+	if err != nil {
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+
+	// We expect to get http.StatusNoContent or if not an error of type types.NSXError
+	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPut, types.AnyXMLMime,
+		"error setting "+sectionName+" configuration: %s", payload, &types.NSXError{})
+	return err
+}
+
+// nsxvAsNumberRegexp matches autonomous system numbers in ASPLAIN notation
+var nsxvAsNumberRegexp = regexp.MustCompile(`^\d+$`)
+
+func validateUpdateNsxvStaticRoutes(staticRoutingConfig *types.EdgeStaticRouting, egw *EdgeGateway) error {
+	if !egw.HasAdvancedNetworking() {
+		return fmt.Errorf("only advanced edge gateways support static routing")
+	}
+
+	if staticRoutingConfig == nil {
+		return fmt.Errorf("static routing configuration cannot be nil")
+	}
+
+	if staticRoutingConfig.DefaultRoute != nil &&
+		net.ParseIP(staticRoutingConfig.DefaultRoute.GatewayAddress) == nil {
+		return fmt.Errorf("default route must have a valid gateway address")
+	}
+
+	if staticRoutingConfig.StaticRoutes == nil {
+		return nil
+	}
+
+	for _, route := range staticRoutingConfig.StaticRoutes.Routes {
+		if route == nil {
+			return fmt.Errorf("static route cannot be nil")
+		}
+		if _, _, err := net.ParseCIDR(route.Network); err != nil {
+			return fmt.Errorf("static route must have network in CIDR format, got '%s'", route.Network)
+		}
+		if net.ParseIP(route.NextHop) == nil {
+			return fmt.Errorf("static route for network '%s' must have a valid next hop", route.Network)
+		}
+		// An admin distance of 0 is not set and the edge gateway default applies
+		if route.AdminDistance != 0 && (route.AdminDistance < 1 || route.AdminDistance > 255) {
+			return fmt.Errorf("static route for network '%s' must have admin distance between 1 and 255",
+				route.Network)
+		}
+	}
+
+	return nil
+}
+
+func validateUpdateNsxvOspf(ospfConfig *types.EdgeOspfRouting, egw *EdgeGateway) error {
+	if !egw.HasAdvancedNetworking() {
+		return fmt.Errorf("only advanced edge gateways support OSPF")
+	}
+
+	if ospfConfig == nil {
+		return fmt.Errorf("OSPF configuration cannot be nil")
+	}
+
+	areaIds := make(map[int]bool)
+	if ospfConfig.OspfAreas != nil {
+		for _, area := range ospfConfig.OspfAreas.OspfArea {
+			if area.Type != "" && area.Type != "normal" && area.Type != "nssa" {
+				return fmt.Errorf("OSPF area %d type must be 'normal' or 'nssa', got '%s'", area.AreaId, area.Type)
+			}
+			if area.Authentication != nil && area.Authentication.Type != "none" &&
+				area.Authentication.Value == "" {
+				return fmt.Errorf("OSPF area %d authentication type '%s' requires a value",
+					area.AreaId, area.Authentication.Type)
+			}
+			areaIds[area.AreaId] = true
+		}
+	}
+
+	if ospfConfig.OspfInterfaces != nil {
+		for _, ospfInterface := range ospfConfig.OspfInterfaces.OspfInterface {
+			if ospfInterface.Vnic == nil {
+				return fmt.Errorf("OSPF interface must have vNic index specified")
+			}
+			if !areaIds[ospfInterface.AreaId] {
+				return fmt.Errorf("OSPF interface for vNic %d references undefined area %d",
+					*ospfInterface.Vnic, ospfInterface.AreaId)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateUpdateNsxvBgp(bgpConfig *types.EdgeBgpRouting, egw *EdgeGateway) error {
+	if !egw.HasAdvancedNetworking() {
+		return fmt.Errorf("only advanced edge gateways support BGP")
+	}
+
+	if bgpConfig == nil {
+		return fmt.Errorf("BGP configuration cannot be nil")
+	}
+
+	if bgpConfig.Enabled && !nsxvAsNumberRegexp.MatchString(bgpConfig.LocalAS) {
+		return fmt.Errorf("BGP must have a numeric local AS when enabled, got '%s'", bgpConfig.LocalAS)
+	}
+
+	if bgpConfig.BgpNeighbours == nil {
+		return nil
+	}
+
+	for _, neighbour := range bgpConfig.BgpNeighbours.BgpNeighbour {
+		if net.ParseIP(neighbour.IpAddress) == nil {
+			return fmt.Errorf("BGP neighbour must have a valid IP address, got '%s'", neighbour.IpAddress)
+		}
+		if !nsxvAsNumberRegexp.MatchString(neighbour.RemoteAS) {
+			return fmt.Errorf("BGP neighbour '%s' must have a numeric remote AS, got '%s'",
+				neighbour.IpAddress, neighbour.RemoteAS)
+		}
+		if neighbour.BgpFilters == nil {
+			continue
+		}
+		for _, filter := range neighbour.BgpFilters.BgpFilter {
+			if filter.Direction != "in" && filter.Direction != "out" {
+				return fmt.Errorf("BGP filter direction must be 'in' or 'out', got '%s'", filter.Direction)
+			}
+			if filter.Action != "permit" && filter.Action != "deny" {
+				return fmt.Errorf("BGP filter action must be 'permit' or 'deny', got '%s'", filter.Action)
+			}
+			if _, _, err := net.ParseCIDR(filter.Network); err != nil {
+				return fmt.Errorf("BGP filter must have network in CIDR format, got '%s'", filter.Network)
+			}
+		}
+	}
+
+	return nil
+}
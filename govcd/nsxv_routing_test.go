@@ -0,0 +1,112 @@
+// +build nsxv functional ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+// Test_NsxvRouting tests out Edge gateway routing configuration. It does the following:
+// 1. Reads initial routing configuration so that it can be restored at the end
+// 2. Adds a static route and checks that it is present
+// 3. Enables OSPF with one area and one interface
+// 4. Enables BGP with one neighbour and a route filter
+// 5. Restores initial static routing, OSPF and BGP configuration
+func (vcd *TestVCD) Test_NsxvRouting(check *C) {
+	if vcd.config.VCD.EdgeGateway == "" {
+		check.Skip("Skipping test because no edge gateway given")
+	}
+
+	edge, err := vcd.vdc.GetEdgeGatewayByName(ctx, vcd.config.VCD.EdgeGateway, false)
+	check.Assert(err, IsNil)
+	check.Assert(edge.EdgeGateway.Name, Equals, vcd.config.VCD.EdgeGateway)
+
+	initialRouting, err := edge.GetNsxvRoutingConfig(ctx)
+	check.Assert(err, IsNil)
+
+	vNicIndex, _, err := edge.GetAnyVnicIndexByNetworkName(ctx, vcd.config.VCD.Network.Net1)
+	check.Assert(err, IsNil)
+
+	// Static routes. Default route gateway is used as next hop because it is always reachable.
+	if initialRouting.StaticRouting == nil || initialRouting.StaticRouting.DefaultRoute == nil {
+		check.Skip("Skipping test because edge gateway has no default route")
+	}
+	staticRouting := &types.EdgeStaticRouting{
+		DefaultRoute: initialRouting.StaticRouting.DefaultRoute,
+		StaticRoutes: &types.EdgeStaticRoutesList{
+			Routes: []*types.EdgeStaticRoute{
+				{
+					Description:   check.TestName(),
+					Network:       "192.168.222.0/24",
+					NextHop:       initialRouting.StaticRouting.DefaultRoute.GatewayAddress,
+					AdminDistance: 5,
+				},
+			},
+		},
+	}
+	updatedStaticRouting, err := edge.UpdateNsxvStaticRoutes(ctx, staticRouting)
+	check.Assert(err, IsNil)
+	check.Assert(updatedStaticRouting.StaticRoutes, NotNil)
+	check.Assert(len(updatedStaticRouting.StaticRoutes.Routes), Equals, 1)
+	check.Assert(updatedStaticRouting.StaticRoutes.Routes[0].Network, Equals, "192.168.222.0/24")
+	check.Assert(updatedStaticRouting.StaticRoutes.Routes[0].AdminDistance, Equals, 5)
+
+	// OSPF
+	ospfConfig := &types.EdgeOspfRouting{
+		Enabled: true,
+		OspfAreas: &types.EdgeOspfAreas{
+			OspfArea: []types.EdgeOspfArea{
+				{AreaId: 11, Type: "normal", Authentication: &types.EdgeOspfAuthentication{Type: "none"}},
+			},
+		},
+		OspfInterfaces: &types.EdgeOspfInterfaces{
+			OspfInterface: []types.EdgeOspfInterface{
+				{Vnic: vNicIndex, AreaId: 11, HelloInterval: 10, DeadInterval: 40, Priority: 128, Cost: 1},
+			},
+		},
+		GracefulRestart: true,
+	}
+	updatedOspf, err := edge.UpdateNsxvOspf(ctx, ospfConfig)
+	check.Assert(err, IsNil)
+	check.Assert(updatedOspf.Enabled, Equals, true)
+	check.Assert(updatedOspf.OspfAreas.OspfArea[0].AreaId, Equals, 11)
+
+	// BGP
+	bgpConfig := &types.EdgeBgpRouting{
+		Enabled: true,
+		LocalAS: "65000",
+		BgpNeighbours: &types.EdgeBgpNeighbours{
+			BgpNeighbour: []types.EdgeBgpNeighbour{
+				{
+					IpAddress:      "10.10.10.200",
+					RemoteAS:       "65001",
+					HoldDownTimer:  180,
+					KeepAliveTimer: 60,
+					BgpFilters: &types.EdgeBgpFilters{
+						BgpFilter: []types.EdgeBgpFilter{
+							{Direction: "in", Action: "deny", Network: "10.0.0.0/8"},
+						},
+					},
+				},
+			},
+		},
+	}
+	updatedBgp, err := edge.UpdateNsxvBgp(ctx, bgpConfig)
+	check.Assert(err, IsNil)
+	check.Assert(updatedBgp.Enabled, Equals, true)
+	check.Assert(updatedBgp.LocalAS, Equals, "65000")
+	check.Assert(len(updatedBgp.BgpNeighbours.BgpNeighbour), Equals, 1)
+
+	// Restore initial configuration
+	_, err = edge.UpdateNsxvBgp(ctx, initialRouting.Bgp)
+	check.Assert(err, IsNil)
+	_, err = edge.UpdateNsxvOspf(ctx, initialRouting.Ospf)
+	check.Assert(err, IsNil)
+	_, err = edge.UpdateNsxvStaticRoutes(ctx, initialRouting.StaticRouting)
+	check.Assert(err, IsNil)
+}
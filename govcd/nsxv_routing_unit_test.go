@@ -0,0 +1,273 @@
+// +build unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Test_EdgeRoutingUnmarshal checks that a sample routing configuration returned by proxied NSX-V
+// API is unmarshalled into typed structures
+func Test_EdgeRoutingUnmarshal(t *testing.T) {
+	sampleBody := []byte(`
+<routing>
+    <version>7</version>
+    <enabled>true</enabled>
+    <routingGlobalConfig>
+        <routerId>10.150.191.253</routerId>
+        <ecmp>true</ecmp>
+        <logging>
+            <enable>false</enable>
+            <logLevel>info</logLevel>
+        </logging>
+    </routingGlobalConfig>
+    <staticRouting>
+        <defaultRoute>
+            <vnic>0</vnic>
+            <mtu>1500</mtu>
+            <gatewayAddress>10.150.191.1</gatewayAddress>
+            <adminDistance>1</adminDistance>
+        </defaultRoute>
+        <staticRoutes>
+            <route>
+                <description>test route</description>
+                <vnic>1</vnic>
+                <network>192.168.10.0/24</network>
+                <nextHop>10.10.10.5</nextHop>
+                <mtu>1500</mtu>
+                <adminDistance>5</adminDistance>
+            </route>
+        </staticRoutes>
+    </staticRouting>
+    <ospf>
+        <enabled>true</enabled>
+        <ospfAreas>
+            <ospfArea>
+                <areaId>10</areaId>
+                <type>nssa</type>
+                <authentication>
+                    <type>none</type>
+                </authentication>
+            </ospfArea>
+        </ospfAreas>
+        <ospfInterfaces>
+            <ospfInterface>
+                <vnic>0</vnic>
+                <areaId>10</areaId>
+                <helloInterval>10</helloInterval>
+                <deadInterval>40</deadInterval>
+                <priority>128</priority>
+                <cost>1</cost>
+                <mtuIgnore>false</mtuIgnore>
+            </ospfInterface>
+        </ospfInterfaces>
+        <gracefulRestart>true</gracefulRestart>
+        <defaultOriginate>false</defaultOriginate>
+    </ospf>
+    <bgp>
+        <enabled>true</enabled>
+        <localAS>65000</localAS>
+        <bgpNeighbours>
+            <bgpNeighbour>
+                <ipAddress>10.150.191.10</ipAddress>
+                <remoteAS>65001</remoteAS>
+                <weight>60</weight>
+                <holdDownTimer>180</holdDownTimer>
+                <keepAliveTimer>60</keepAliveTimer>
+                <bgpFilters>
+                    <bgpFilter>
+                        <direction>in</direction>
+                        <action>deny</action>
+                        <network>10.0.0.0/8</network>
+                    </bgpFilter>
+                </bgpFilters>
+            </bgpNeighbour>
+        </bgpNeighbours>
+        <redistribution>
+            <enabled>true</enabled>
+            <rules>
+                <rule>
+                    <id>0</id>
+                    <from>
+                        <ospf>false</ospf>
+                        <bgp>false</bgp>
+                        <static>true</static>
+                        <connected>true</connected>
+                    </from>
+                    <action>permit</action>
+                </rule>
+            </rules>
+        </redistribution>
+        <gracefulRestart>true</gracefulRestart>
+        <defaultOriginate>false</defaultOriginate>
+    </bgp>
+</routing>`)
+
+	routing := &types.EdgeRouting{}
+	err := xml.Unmarshal(sampleBody, routing)
+	if err != nil {
+		t.Fatalf("error unmarshalling routing configuration: %s", err)
+	}
+
+	if routing.RoutingGlobalConfig.RouterId != "10.150.191.253" || !routing.RoutingGlobalConfig.Ecmp {
+		t.Errorf("unexpected global config: %+v", routing.RoutingGlobalConfig)
+	}
+	if routing.StaticRouting.DefaultRoute.GatewayAddress != "10.150.191.1" {
+		t.Errorf("unexpected default route: %+v", routing.StaticRouting.DefaultRoute)
+	}
+	if len(routing.StaticRouting.StaticRoutes.Routes) != 1 ||
+		routing.StaticRouting.StaticRoutes.Routes[0].NextHop != "10.10.10.5" ||
+		*routing.StaticRouting.StaticRoutes.Routes[0].Vnic != 1 {
+		t.Errorf("unexpected static routes: %+v", routing.StaticRouting.StaticRoutes)
+	}
+	if routing.Ospf.OspfAreas.OspfArea[0].Type != "nssa" || routing.Ospf.OspfInterfaces.OspfInterface[0].AreaId != 10 {
+		t.Errorf("unexpected OSPF config: %+v", routing.Ospf)
+	}
+	if routing.Bgp.LocalAS != "65000" || routing.Bgp.BgpNeighbours.BgpNeighbour[0].BgpFilters.BgpFilter[0].Action != "deny" {
+		t.Errorf("unexpected BGP config: %+v", routing.Bgp)
+	}
+	if !routing.Bgp.Redistribution.Rules.Rule[0].From.Connected {
+		t.Errorf("unexpected BGP redistribution: %+v", routing.Bgp.Redistribution)
+	}
+
+	// Static routing must be accepted back by validation as is
+	if err := validateUpdateNsxvStaticRoutes(routing.StaticRouting, advancedEdgeGatewayForTest()); err != nil {
+		t.Errorf("unexpected validation error for static routes: %s", err)
+	}
+	if err := validateUpdateNsxvOspf(routing.Ospf, advancedEdgeGatewayForTest()); err != nil {
+		t.Errorf("unexpected validation error for OSPF: %s", err)
+	}
+	if err := validateUpdateNsxvBgp(routing.Bgp, advancedEdgeGatewayForTest()); err != nil {
+		t.Errorf("unexpected validation error for BGP: %s", err)
+	}
+}
+
+func Test_validateUpdateNsxvRouting(t *testing.T) {
+	advancedEdge := advancedEdgeGatewayForTest()
+	nonAdvancedEdge := &EdgeGateway{EdgeGateway: &types.EdgeGateway{}}
+
+	tests := []struct {
+		name     string
+		validate func() error
+		wantErr  bool
+	}{
+		{
+			name: "StaticRoutesNonAdvancedEdge",
+			validate: func() error {
+				return validateUpdateNsxvStaticRoutes(&types.EdgeStaticRouting{}, nonAdvancedEdge)
+			},
+			wantErr: true,
+		},
+		{
+			name: "StaticRouteInvalidNetwork",
+			validate: func() error {
+				return validateUpdateNsxvStaticRoutes(&types.EdgeStaticRouting{StaticRoutes: &types.EdgeStaticRoutesList{
+					Routes: []*types.EdgeStaticRoute{{Network: "192.168.1.0", NextHop: "10.0.0.1"}}}}, advancedEdge)
+			},
+			wantErr: true,
+		},
+		{
+			name: "StaticRouteInvalidNextHop",
+			validate: func() error {
+				return validateUpdateNsxvStaticRoutes(&types.EdgeStaticRouting{StaticRoutes: &types.EdgeStaticRoutesList{
+					Routes: []*types.EdgeStaticRoute{{Network: "192.168.1.0/24", NextHop: "10.0.0"}}}}, advancedEdge)
+			},
+			wantErr: true,
+		},
+		{
+			name: "StaticRouteNegativeAdminDistance",
+			validate: func() error {
+				return validateUpdateNsxvStaticRoutes(&types.EdgeStaticRouting{StaticRoutes: &types.EdgeStaticRoutesList{
+					Routes: []*types.EdgeStaticRoute{{Network: "192.168.1.0/24", NextHop: "10.0.0.1", AdminDistance: -1}}}}, advancedEdge)
+			},
+			wantErr: true,
+		},
+		{
+			name: "StaticRouteAdminDistanceTooHigh",
+			validate: func() error {
+				return validateUpdateNsxvStaticRoutes(&types.EdgeStaticRouting{StaticRoutes: &types.EdgeStaticRoutesList{
+					Routes: []*types.EdgeStaticRoute{{Network: "192.168.1.0/24", NextHop: "10.0.0.1", AdminDistance: 256}}}}, advancedEdge)
+			},
+			wantErr: true,
+		},
+		{
+			name: "StaticRouteUnsetAdminDistance",
+			validate: func() error {
+				return validateUpdateNsxvStaticRoutes(&types.EdgeStaticRouting{StaticRoutes: &types.EdgeStaticRoutesList{
+					Routes: []*types.EdgeStaticRoute{{Network: "192.168.1.0/24", NextHop: "10.0.0.1"}}}}, advancedEdge)
+			},
+			wantErr: false,
+		},
+		{
+			name: "OspfInterfaceUndefinedArea",
+			validate: func() error {
+				return validateUpdateNsxvOspf(&types.EdgeOspfRouting{
+					OspfAreas:      &types.EdgeOspfAreas{OspfArea: []types.EdgeOspfArea{{AreaId: 1}}},
+					OspfInterfaces: &types.EdgeOspfInterfaces{OspfInterface: []types.EdgeOspfInterface{{Vnic: takeIntAddress(0), AreaId: 2}}},
+				}, advancedEdge)
+			},
+			wantErr: true,
+		},
+		{
+			name: "OspfPasswordWithoutValue",
+			validate: func() error {
+				return validateUpdateNsxvOspf(&types.EdgeOspfRouting{
+					OspfAreas: &types.EdgeOspfAreas{OspfArea: []types.EdgeOspfArea{
+						{AreaId: 1, Authentication: &types.EdgeOspfAuthentication{Type: "password"}}}},
+				}, advancedEdge)
+			},
+			wantErr: true,
+		},
+		{
+			name: "BgpEnabledWithoutLocalAs",
+			validate: func() error {
+				return validateUpdateNsxvBgp(&types.EdgeBgpRouting{Enabled: true}, advancedEdge)
+			},
+			wantErr: true,
+		},
+		{
+			name: "BgpInvalidFilterDirection",
+			validate: func() error {
+				return validateUpdateNsxvBgp(&types.EdgeBgpRouting{Enabled: true, LocalAS: "65000",
+					BgpNeighbours: &types.EdgeBgpNeighbours{BgpNeighbour: []types.EdgeBgpNeighbour{{
+						IpAddress: "10.0.0.1", RemoteAS: "65001",
+						BgpFilters: &types.EdgeBgpFilters{BgpFilter: []types.EdgeBgpFilter{
+							{Direction: "both", Action: "permit", Network: "10.0.0.0/8"}}},
+					}}}}, advancedEdge)
+			},
+			wantErr: true,
+		},
+		{
+			name: "BgpDisabled",
+			validate: func() error {
+				return validateUpdateNsxvBgp(&types.EdgeBgpRouting{Enabled: false}, advancedEdge)
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validation error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// advancedEdgeGatewayForTest returns an EdgeGateway with advanced networking enabled which is
+// enough for validation functions
+func advancedEdgeGatewayForTest() *EdgeGateway {
+	return &EdgeGateway{
+		EdgeGateway: &types.EdgeGateway{
+			Configuration: &types.GatewayConfiguration{AdvancedNetworkingEnabled: takeBoolPointer(true)},
+		},
+	}
+}
@@ -0,0 +1,373 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// GetSslVpnConfig retrieves complete SSL VPN-Plus configuration of an advanced edge gateway
+func (egw *EdgeGateway) GetSslVpnConfig(ctx context.Context) (*types.EdgeSslVpnConfig, error) {
+	response := &types.EdgeSslVpnConfig{}
+	err := egw.getNsxvSslVpnSection(ctx, types.EdgeSslVpnPath, "configuration", response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// UpdateSslVpnConfig updates complete SSL VPN-Plus configuration of an advanced edge gateway and
+// returns it. It can be used to enable or disable the service (types.EdgeSslVpnConfig.Enabled).
+//
+// Note. All sections which are nil in sslVpnConfig are reset to defaults by NSX-V. Retrieve
+// configuration with GetSslVpnConfig and amend it if only some sections must change.
+func (egw *EdgeGateway) UpdateSslVpnConfig(ctx context.Context, sslVpnConfig *types.EdgeSslVpnConfig) (*types.EdgeSslVpnConfig, error) {
+	if sslVpnConfig == nil {
+		return nil, fmt.Errorf("SSL VPN-Plus configuration cannot be nil")
+	}
+
+	if sslVpnConfig.ServerSettings != nil {
+		if err := validateSslVpnServerSettings(sslVpnConfig.ServerSettings); err != nil {
+			return nil, err
+		}
+	}
+	if sslVpnConfig.IpAddressPools != nil {
+		if err := validateSslVpnIpPools(sslVpnConfig.IpAddressPools); err != nil {
+			return nil, err
+		}
+	}
+	if sslVpnConfig.PrivateNetworks != nil {
+		if err := validateSslVpnPrivateNetworks(sslVpnConfig.PrivateNetworks); err != nil {
+			return nil, err
+		}
+	}
+	if sslVpnConfig.Users != nil {
+		if err := validateSslVpnUsers(sslVpnConfig.Users); err != nil {
+			return nil, err
+		}
+	}
+	if sslVpnConfig.ClientInstallPackages != nil {
+		if err := validateSslVpnInstallPackages(sslVpnConfig.ClientInstallPackages); err != nil {
+			return nil, err
+		}
+	}
+
+	err := egw.updateNsxvSslVpnSection(ctx, types.EdgeSslVpnPath, "configuration", sslVpnConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.GetSslVpnConfig(ctx)
+}
+
+// GetSslVpnServerSettings retrieves SSL VPN-Plus server settings (listener IP addresses, port,
+// certificate and ciphers)
+func (egw *EdgeGateway) GetSslVpnServerSettings(ctx context.Context) (*types.EdgeSslVpnServerSettings, error) {
+	response := &types.EdgeSslVpnServerSettings{}
+	err := egw.getNsxvSslVpnSection(ctx, types.EdgeSslVpnServerPath, "server settings", response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// UpdateSslVpnServerSettings updates SSL VPN-Plus server settings and returns them
+func (egw *EdgeGateway) UpdateSslVpnServerSettings(ctx context.Context, serverSettings *types.EdgeSslVpnServerSettings) (*types.EdgeSslVpnServerSettings, error) {
+	if err := validateSslVpnServerSettings(serverSettings); err != nil {
+		return nil, err
+	}
+
+	err := egw.updateNsxvSslVpnSection(ctx, types.EdgeSslVpnServerPath, "server settings", serverSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.GetSslVpnServerSettings(ctx)
+}
+
+// GetSslVpnIpPools retrieves SSL VPN-Plus IP pools from which remote users get IP addresses
+func (egw *EdgeGateway) GetSslVpnIpPools(ctx context.Context) (*types.EdgeSslVpnIpAddressPools, error) {
+	response := &types.EdgeSslVpnIpAddressPools{}
+	err := egw.getNsxvSslVpnSection(ctx, types.EdgeSslVpnIpPoolsPath, "IP pools", response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// UpdateSslVpnIpPools replaces all SSL VPN-Plus IP pools and returns them
+func (egw *EdgeGateway) UpdateSslVpnIpPools(ctx context.Context, ipPools *types.EdgeSslVpnIpAddressPools) (*types.EdgeSslVpnIpAddressPools, error) {
+	if err := validateSslVpnIpPools(ipPools); err != nil {
+		return nil, err
+	}
+
+	err := egw.updateNsxvSslVpnSection(ctx, types.EdgeSslVpnIpPoolsPath, "IP pools", ipPools)
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.GetSslVpnIpPools(ctx)
+}
+
+// GetSslVpnPrivateNetworks retrieves SSL VPN-Plus private networks accessible to remote users
+func (egw *EdgeGateway) GetSslVpnPrivateNetworks(ctx context.Context) (*types.EdgeSslVpnPrivateNetworks, error) {
+	response := &types.EdgeSslVpnPrivateNetworks{}
+	err := egw.getNsxvSslVpnSection(ctx, types.EdgeSslVpnPrivateNetworksPath, "private networks", response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// UpdateSslVpnPrivateNetworks replaces all SSL VPN-Plus private networks and returns them
+func (egw *EdgeGateway) UpdateSslVpnPrivateNetworks(ctx context.Context, privateNetworks *types.EdgeSslVpnPrivateNetworks) (*types.EdgeSslVpnPrivateNetworks, error) {
+	if err := validateSslVpnPrivateNetworks(privateNetworks); err != nil {
+		return nil, err
+	}
+
+	err := egw.updateNsxvSslVpnSection(ctx, types.EdgeSslVpnPrivateNetworksPath, "private networks", privateNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.GetSslVpnPrivateNetworks(ctx)
+}
+
+// GetSslVpnUsers retrieves SSL VPN-Plus local users. Passwords are not returned.
+func (egw *EdgeGateway) GetSslVpnUsers(ctx context.Context) (*types.EdgeSslVpnUsers, error) {
+	response := &types.EdgeSslVpnUsers{}
+	err := egw.getNsxvSslVpnSection(ctx, types.EdgeSslVpnUsersPath, "users", response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// UpdateSslVpnUsers replaces all SSL VPN-Plus local users and returns them
+func (egw *EdgeGateway) UpdateSslVpnUsers(ctx context.Context, users *types.EdgeSslVpnUsers) (*types.EdgeSslVpnUsers, error) {
+	if err := validateSslVpnUsers(users); err != nil {
+		return nil, err
+	}
+
+	err := egw.updateNsxvSslVpnSection(ctx, types.EdgeSslVpnUsersPath, "users", users)
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.GetSslVpnUsers(ctx)
+}
+
+// GetSslVpnInstallPackages retrieves SSL VPN-Plus client installation packages
+func (egw *EdgeGateway) GetSslVpnInstallPackages(ctx context.Context) (*types.EdgeSslVpnInstallPackages, error) {
+	response := &types.EdgeSslVpnInstallPackages{}
+	err := egw.getNsxvSslVpnSection(ctx, types.EdgeSslVpnInstallPackagesPath, "installation packages", response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// UpdateSslVpnInstallPackages replaces all SSL VPN-Plus client installation packages and returns
+// them
+func (egw *EdgeGateway) UpdateSslVpnInstallPackages(ctx context.Context, installPackages *types.EdgeSslVpnInstallPackages) (*types.EdgeSslVpnInstallPackages, error) {
+	if err := validateSslVpnInstallPackages(installPackages); err != nil {
+		return nil, err
+	}
+
+	err := egw.updateNsxvSslVpnSection(ctx, types.EdgeSslVpnInstallPackagesPath, "installation packages", installPackages)
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.GetSslVpnInstallPackages(ctx)
+}
+
+// GetSslVpnAuthConfig retrieves SSL VPN-Plus authentication servers configuration
+func (egw *EdgeGateway) GetSslVpnAuthConfig(ctx context.Context) (*types.EdgeSslVpnAuthConfig, error) {
+	response := &types.EdgeSslVpnAuthConfig{}
+	err := egw.getNsxvSslVpnSection(ctx, types.EdgeSslVpnAuthPath, "authentication configuration", response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// UpdateSslVpnAuthConfig updates SSL VPN-Plus authentication servers configuration and returns it
+func (egw *EdgeGateway) UpdateSslVpnAuthConfig(ctx context.Context, authConfig *types.EdgeSslVpnAuthConfig) (*types.EdgeSslVpnAuthConfig, error) {
+	if err := validateSslVpnAuthConfig(authConfig); err != nil {
+		return nil, err
+	}
+
+	err := egw.updateNsxvSslVpnSection(ctx, types.EdgeSslVpnAuthPath, "authentication configuration", authConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return egw.GetSslVpnAuthConfig(ctx)
+}
+
+// getNsxvSslVpnSection retrieves a particular SSL VPN-Plus configuration section into response
+func (egw *EdgeGateway) getNsxvSslVpnSection(ctx context.Context, sectionPath, sectionName string, response interface{}) error {
+	if !egw.HasAdvancedNetworking() {
+		return fmt.Errorf("only advanced edge gateways support SSL VPN-Plus")
+	}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(sectionPath)
+	if err != nil {
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+
+	// This query Edge gateway SSL VPN-Plus section using proxied NSX-V API
+	_, err = egw.client.ExecuteRequest(ctx, httpPath, http.MethodGet, types.AnyXMLMime,
+		"unable to read edge gateway SSL VPN-Plus "+sectionName+": %s", nil, response)
+	return err
+}
+
+// updateNsxvSslVpnSection sends a PUT request with payload to a particular SSL VPN-Plus
+// configuration section
+func (egw *EdgeGateway) updateNsxvSslVpnSection(ctx context.Context, sectionPath, sectionName string, payload interface{}) error {
+	if !egw.HasAdvancedNetworking() {
+		return fmt.Errorf("only advanced edge gateways support SSL VPN-Plus")
+	}
+
+	httpPath, err := egw.buildProxiedEdgeEndpointURL(sectionPath)
+	if err != nil {
+		return fmt.Errorf("could not get Edge Gateway API endpoint: %s", err)
+	}
+
+	// We expect to get http.StatusNoContent or if not an error of type types.NSXError
+	_, err = egw.client.ExecuteRequestWithCustomError(ctx, httpPath, http.MethodPut, types.AnyXMLMime,
+		"error setting SSL VPN-Plus "+sectionName+": %s", payload, &types.NSXError{})
+	return err
+}
+
+func validateSslVpnServerSettings(serverSettings *types.EdgeSslVpnServerSettings) error {
+	if serverSettings == nil {
+		return fmt.Errorf("SSL VPN-Plus server settings cannot be nil")
+	}
+
+	if serverSettings.ServerAddresses == nil || len(serverSettings.ServerAddresses.IpAddress) == 0 {
+		return fmt.Errorf("SSL VPN-Plus server settings must have at least one server address")
+	}
+
+	for _, ipAddress := range serverSettings.ServerAddresses.IpAddress {
+		if net.ParseIP(ipAddress) == nil {
+			return fmt.Errorf("SSL VPN-Plus server address '%s' is not a valid IP", ipAddress)
+		}
+	}
+
+	if serverSettings.Port < 1 || serverSettings.Port > 65535 {
+		return fmt.Errorf("SSL VPN-Plus server port must be between 1 and 65535, got %d", serverSettings.Port)
+	}
+
+	return nil
+}
+
+func validateSslVpnIpPools(ipPools *types.EdgeSslVpnIpAddressPools) error {
+	if ipPools == nil {
+		return fmt.Errorf("SSL VPN-Plus IP pools cannot be nil")
+	}
+
+	for _, ipPool := range ipPools.IpAddressPool {
+		ipRange := strings.Split(ipPool.IpRange, "-")
+		if len(ipRange) != 2 || net.ParseIP(ipRange[0]) == nil || net.ParseIP(ipRange[1]) == nil {
+			return fmt.Errorf("SSL VPN-Plus IP pool range must be in format 'start-end', got '%s'", ipPool.IpRange)
+		}
+		if net.ParseIP(ipPool.Netmask) == nil {
+			return fmt.Errorf("SSL VPN-Plus IP pool '%s' must have a valid netmask", ipPool.IpRange)
+		}
+		if net.ParseIP(ipPool.Gateway) == nil {
+			return fmt.Errorf("SSL VPN-Plus IP pool '%s' must have a valid gateway", ipPool.IpRange)
+		}
+	}
+
+	return nil
+}
+
+func validateSslVpnPrivateNetworks(privateNetworks *types.EdgeSslVpnPrivateNetworks) error {
+	if privateNetworks == nil {
+		return fmt.Errorf("SSL VPN-Plus private networks cannot be nil")
+	}
+
+	for _, privateNetwork := range privateNetworks.PrivateNetwork {
+		if _, _, err := net.ParseCIDR(privateNetwork.Network); err != nil {
+			return fmt.Errorf("SSL VPN-Plus private network must be in CIDR format, got '%s'", privateNetwork.Network)
+		}
+	}
+
+	return nil
+}
+
+func validateSslVpnUsers(users *types.EdgeSslVpnUsers) error {
+	if users == nil {
+		return fmt.Errorf("SSL VPN-Plus users cannot be nil")
+	}
+
+	seenUserIds := make(map[string]bool)
+	for _, user := range users.User {
+		if user.UserId == "" {
+			return fmt.Errorf("SSL VPN-Plus user must have user ID")
+		}
+		if seenUserIds[user.UserId] {
+			return fmt.Errorf("SSL VPN-Plus user ID '%s' is specified more than once", user.UserId)
+		}
+		seenUserIds[user.UserId] = true
+		// Existing users are identified by ObjectId and may be sent without password
+		if user.ObjectId == "" && user.Password == "" {
+			return fmt.Errorf("new SSL VPN-Plus user '%s' must have a password", user.UserId)
+		}
+	}
+
+	return nil
+}
+
+func validateSslVpnInstallPackages(installPackages *types.EdgeSslVpnInstallPackages) error {
+	if installPackages == nil {
+		return fmt.Errorf("SSL VPN-Plus installation packages cannot be nil")
+	}
+
+	for _, installPackage := range installPackages.ClientInstallPackage {
+		if installPackage.ProfileName == "" {
+			return fmt.Errorf("SSL VPN-Plus installation package must have a profile name")
+		}
+		if installPackage.GatewayList == nil || len(installPackage.GatewayList.Gateway) == 0 {
+			return fmt.Errorf("SSL VPN-Plus installation package '%s' must have at least one gateway",
+				installPackage.ProfileName)
+		}
+	}
+
+	return nil
+}
+
+func validateSslVpnAuthConfig(authConfig *types.EdgeSslVpnAuthConfig) error {
+	if authConfig == nil {
+		return fmt.Errorf("SSL VPN-Plus authentication configuration cannot be nil")
+	}
+
+	if authConfig.PasswordAuthentication == nil || authConfig.PasswordAuthentication.PrimaryAuthServers == nil {
+		return fmt.Errorf("SSL VPN-Plus authentication configuration must have primary authentication servers")
+	}
+
+	primary := authConfig.PasswordAuthentication.PrimaryAuthServers
+	if primary.LocalAuthServer == nil && len(primary.LdapAuthServers) == 0 && len(primary.AdAuthServers) == 0 &&
+		len(primary.RadiusAuthServers) == 0 && primary.RsaAuthServer == nil {
+		return fmt.Errorf("SSL VPN-Plus authentication configuration must have at least one primary authentication server")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// +build nsxv functional ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+// Test_NsxvSslVpn tests out Edge gateway SSL VPN-Plus configuration. It does the following:
+// 1. Reads initial configuration so that it can be restored at the end
+// 2. Sets server settings, IP pool, private network and a local user
+// 3. Enables the service and checks that all sections are present
+// 4. Restores initial configuration
+func (vcd *TestVCD) Test_NsxvSslVpn(check *C) {
+	if vcd.config.VCD.EdgeGateway == "" || vcd.config.VCD.ExternalIp == "" {
+		check.Skip("Skipping test because no edge gateway or external IP given")
+	}
+
+	edge, err := vcd.vdc.GetEdgeGatewayByName(ctx, vcd.config.VCD.EdgeGateway, false)
+	check.Assert(err, IsNil)
+
+	initialConfig, err := edge.GetSslVpnConfig(ctx)
+	check.Assert(err, IsNil)
+
+	serverSettings, err := edge.UpdateSslVpnServerSettings(ctx, &types.EdgeSslVpnServerSettings{
+		ServerAddresses: &types.EdgeSslVpnServerAddresses{IpAddress: []string{vcd.config.VCD.ExternalIp}},
+		Port:            443,
+		CipherList:      &types.EdgeSslVpnCipherList{Cipher: []string{"AES128-SHA"}},
+	})
+	check.Assert(err, IsNil)
+	check.Assert(serverSettings.Port, Equals, 443)
+
+	ipPools, err := edge.UpdateSslVpnIpPools(ctx, &types.EdgeSslVpnIpAddressPools{
+		IpAddressPool: []types.EdgeSslVpnIpAddressPool{
+			{IpRange: "10.201.1.10-10.201.1.50", Netmask: "255.255.255.0", Gateway: "10.201.1.1", Enabled: true},
+		},
+	})
+	check.Assert(err, IsNil)
+	check.Assert(len(ipPools.IpAddressPool), Equals, 1)
+
+	privateNetworks, err := edge.UpdateSslVpnPrivateNetworks(ctx, &types.EdgeSslVpnPrivateNetworks{
+		PrivateNetwork: []types.EdgeSslVpnPrivateNetwork{
+			{Network: "192.168.201.0/24", SendOverTunnel: &types.EdgeSslVpnSendOverTunnel{Optimize: true}, Enabled: true},
+		},
+	})
+	check.Assert(err, IsNil)
+	check.Assert(len(privateNetworks.PrivateNetwork), Equals, 1)
+
+	users, err := edge.UpdateSslVpnUsers(ctx, &types.EdgeSslVpnUsers{
+		User: []types.EdgeSslVpnUser{{UserId: "test-user", Password: "Test-Passw0rd!", PasswordNeverExpires: true}},
+	})
+	check.Assert(err, IsNil)
+	check.Assert(len(users.User), Equals, 1)
+	check.Assert(users.User[0].UserId, Equals, "test-user")
+
+	sslVpnConfig, err := edge.GetSslVpnConfig(ctx)
+	check.Assert(err, IsNil)
+	sslVpnConfig.Enabled = true
+	sslVpnConfig, err = edge.UpdateSslVpnConfig(ctx, sslVpnConfig)
+	check.Assert(err, IsNil)
+	check.Assert(sslVpnConfig.Enabled, Equals, true)
+	check.Assert(sslVpnConfig.ServerSettings, NotNil)
+
+	// Restore initial configuration
+	_, err = edge.UpdateSslVpnConfig(ctx, initialConfig)
+	check.Assert(err, IsNil)
+}
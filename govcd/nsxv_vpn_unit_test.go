@@ -0,0 +1,351 @@
+// +build unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Test_EdgeSslVpnConfigRoundTrip checks that SSL VPN-Plus configuration survives unmarshal and
+// marshal without losing data
+func Test_EdgeSslVpnConfigRoundTrip(t *testing.T) {
+	sampleBody := []byte(`
+<sslvpnConfig>
+    <version>4</version>
+    <enabled>true</enabled>
+    <logging>
+        <enable>true</enable>
+        <logLevel>notice</logLevel>
+    </logging>
+    <advancedConfig>
+        <enableCompression>false</enableCompression>
+        <forceVirtualKeyboard>false</forceVirtualKeyboard>
+        <randomizeVirtualkeys>false</randomizeVirtualkeys>
+        <preventMultipleLogon>true</preventMultipleLogon>
+        <enablePublicUrlAccess>false</enablePublicUrlAccess>
+        <timeout>
+            <forcedTimeout>60</forcedTimeout>
+            <sessionIdleTimeout>10</sessionIdleTimeout>
+        </timeout>
+    </advancedConfig>
+    <clientConfiguration>
+        <autoReconnect>true</autoReconnect>
+        <upgradeNotification>false</upgradeNotification>
+    </clientConfiguration>
+    <authenticationConfig>
+        <passwordAuthentication>
+            <authenticationTimeout>1</authenticationTimeout>
+            <primaryAuthServers>
+                <com.vmware.vshield.edge.sslvpn.dto.LocalAuthServerDto>
+                    <enabled>true</enabled>
+                    <passwordPolicy>
+                        <minLength>8</minLength>
+                        <maxLength>63</maxLength>
+                        <minDigits>1</minDigits>
+                        <allowUserIdWithinPassword>false</allowUserIdWithinPassword>
+                        <passwordLifeTime>30</passwordLifeTime>
+                        <expiryNotification>25</expiryNotification>
+                    </passwordPolicy>
+                    <accountLockoutPolicy>
+                        <retryCount>5</retryCount>
+                        <retryDuration>1</retryDuration>
+                        <lockoutDuration>2</lockoutDuration>
+                    </accountLockoutPolicy>
+                </com.vmware.vshield.edge.sslvpn.dto.LocalAuthServerDto>
+                <com.vmware.vshield.edge.sslvpn.dto.LdapAuthServerDto>
+                    <ip>10.0.0.50</ip>
+                    <port>389</port>
+                    <timeOut>20</timeOut>
+                    <enableSsl>false</enableSsl>
+                    <searchBase>dc=example,dc=com</searchBase>
+                    <loginAttributeName>uid</loginAttributeName>
+                    <enabled>true</enabled>
+                </com.vmware.vshield.edge.sslvpn.dto.LdapAuthServerDto>
+            </primaryAuthServers>
+            <secondaryAuthServer>
+                <com.vmware.vshield.edge.sslvpn.dto.RadiusAuthServerDto>
+                    <ip>10.0.0.60</ip>
+                    <port>1812</port>
+                    <timeOut>10</timeOut>
+                    <retryCount>3</retryCount>
+                    <enabled>true</enabled>
+                </com.vmware.vshield.edge.sslvpn.dto.RadiusAuthServerDto>
+            </secondaryAuthServer>
+        </passwordAuthentication>
+    </authenticationConfig>
+    <serverSettings>
+        <serverAddresses>
+            <ipAddress>192.168.1.10</ipAddress>
+        </serverAddresses>
+        <port>443</port>
+        <cipherList>
+            <cipher>AES128-SHA</cipher>
+            <cipher>AES256-SHA</cipher>
+        </cipherList>
+    </serverSettings>
+    <ipAddressPools>
+        <ipAddressPool>
+            <objectId>ipAddressPool-1</objectId>
+            <ipRange>10.1.1.10-10.1.1.50</ipRange>
+            <netmask>255.255.255.0</netmask>
+            <gateway>10.1.1.1</gateway>
+            <primaryDns>8.8.8.8</primaryDns>
+            <enabled>true</enabled>
+        </ipAddressPool>
+    </ipAddressPools>
+    <privateNetworks>
+        <privateNetwork>
+            <objectId>privateNetwork-1</objectId>
+            <network>192.168.100.0/24</network>
+            <sendOverTunnel>
+                <ports>20-40</ports>
+                <optimize>true</optimize>
+            </sendOverTunnel>
+            <enabled>true</enabled>
+        </privateNetwork>
+    </privateNetworks>
+    <users>
+        <user>
+            <objectId>user-1</objectId>
+            <userId>alice</userId>
+            <firstName>Alice</firstName>
+            <disableUserAccount>false</disableUserAccount>
+            <passwordNeverExpires>true</passwordNeverExpires>
+            <allowChangePassword>
+                <changePasswordOnNextLogin>false</changePasswordOnNextLogin>
+            </allowChangePassword>
+        </user>
+    </users>
+    <clientInstallPackages>
+        <clientInstallPackage>
+            <objectId>clientInstallPackage-1</objectId>
+            <profileName>default</profileName>
+            <gatewayList>
+                <gateway>
+                    <hostName>192.168.1.10</hostName>
+                    <port>443</port>
+                </gateway>
+            </gatewayList>
+            <startClientOnLogon>false</startClientOnLogon>
+            <hideSystrayIcon>false</hideSystrayIcon>
+            <rememberPassword>true</rememberPassword>
+            <silentModeOperation>false</silentModeOperation>
+            <silentModeInstallation>false</silentModeInstallation>
+            <hideNetworkAdaptor>false</hideNetworkAdaptor>
+            <createDesktopIcon>true</createDesktopIcon>
+            <enforceServerSecurityCertValidation>true</enforceServerSecurityCertValidation>
+            <createLinuxClient>true</createLinuxClient>
+            <createMacClient>false</createMacClient>
+            <enabled>true</enabled>
+        </clientInstallPackage>
+    </clientInstallPackages>
+</sslvpnConfig>`)
+
+	sslVpnConfig := &types.EdgeSslVpnConfig{}
+	assertXmlRoundTrip(t, sampleBody, sslVpnConfig, &types.EdgeSslVpnConfig{})
+
+	primary := sslVpnConfig.AuthenticationConfig.PasswordAuthentication.PrimaryAuthServers
+	if primary.LocalAuthServer == nil || primary.LocalAuthServer.PasswordPolicy.MinLength != 8 {
+		t.Errorf("local authentication server was not parsed: %+v", primary.LocalAuthServer)
+	}
+	if len(primary.LdapAuthServers) != 1 || primary.LdapAuthServers[0].Ip != "10.0.0.50" {
+		t.Errorf("LDAP authentication server was not parsed: %+v", primary.LdapAuthServers)
+	}
+	secondary := sslVpnConfig.AuthenticationConfig.PasswordAuthentication.SecondaryAuthServer
+	if len(secondary.RadiusAuthServers) != 1 || secondary.RadiusAuthServers[0].Port != 1812 {
+		t.Errorf("RADIUS authentication server was not parsed: %+v", secondary.RadiusAuthServers)
+	}
+	if sslVpnConfig.Users.User[0].UserId != "alice" ||
+		sslVpnConfig.ClientInstallPackages.ClientInstallPackage[0].GatewayList.Gateway[0].Port != 443 {
+		t.Errorf("users or installation packages were not parsed: %+v %+v", sslVpnConfig.Users,
+			sslVpnConfig.ClientInstallPackages)
+	}
+
+	// Parsed sections must pass validation
+	if err := validateSslVpnServerSettings(sslVpnConfig.ServerSettings); err != nil {
+		t.Errorf("unexpected server settings validation error: %s", err)
+	}
+	if err := validateSslVpnIpPools(sslVpnConfig.IpAddressPools); err != nil {
+		t.Errorf("unexpected IP pools validation error: %s", err)
+	}
+	if err := validateSslVpnPrivateNetworks(sslVpnConfig.PrivateNetworks); err != nil {
+		t.Errorf("unexpected private networks validation error: %s", err)
+	}
+	if err := validateSslVpnUsers(sslVpnConfig.Users); err != nil {
+		t.Errorf("unexpected users validation error: %s", err)
+	}
+	if err := validateSslVpnInstallPackages(sslVpnConfig.ClientInstallPackages); err != nil {
+		t.Errorf("unexpected installation packages validation error: %s", err)
+	}
+	if err := validateSslVpnAuthConfig(sslVpnConfig.AuthenticationConfig); err != nil {
+		t.Errorf("unexpected authentication configuration validation error: %s", err)
+	}
+}
+
+// Test_EdgeL2VpnRoundTrip checks that L2 VPN server and client configurations survive unmarshal and
+// marshal without losing data
+func Test_EdgeL2VpnRoundTrip(t *testing.T) {
+	sampleBody := []byte(`
+<l2Vpn>
+    <version>2</version>
+    <enabled>true</enabled>
+    <logging>
+        <enable>false</enable>
+        <logLevel>info</logLevel>
+    </logging>
+    <l2VpnSites>
+        <l2VpnSite>
+            <server>
+                <listenerIp>192.168.15.14</listenerIp>
+                <listenerPort>443</listenerPort>
+                <encryptionAlgorithm>AES128-GCM-SHA256</encryptionAlgorithm>
+                <peerSites>
+                    <peerSite>
+                        <name>PeerSite1</name>
+                        <l2VpnUser>
+                            <userId>user1</userId>
+                        </l2VpnUser>
+                        <vnics>
+                            <index>10</index>
+                            <index>11</index>
+                        </vnics>
+                        <egressOptimization>
+                            <gatewayIpAddress>192.168.15.1</gatewayIpAddress>
+                        </egressOptimization>
+                        <enabled>true</enabled>
+                    </peerSite>
+                </peerSites>
+            </server>
+        </l2VpnSite>
+    </l2VpnSites>
+</l2Vpn>`)
+
+	l2Vpn := &types.EdgeL2Vpn{}
+	assertXmlRoundTrip(t, sampleBody, l2Vpn, &types.EdgeL2Vpn{})
+
+	peerSite := l2Vpn.L2VpnSites.L2VpnSite[0].Server.PeerSites.PeerSite[0]
+	if peerSite.Name != "PeerSite1" || len(peerSite.Vnics.Index) != 2 {
+		t.Errorf("L2 VPN peer site was not parsed: %+v", peerSite)
+	}
+
+	if err := validateUpdateL2Vpn(l2Vpn, advancedEdgeGatewayForTest()); err != nil {
+		t.Errorf("unexpected L2 VPN validation error: %s", err)
+	}
+
+	// A site with both server and client configuration is invalid
+	l2Vpn.L2VpnSites.L2VpnSite[0].Client = &types.EdgeL2VpnClient{}
+	if err := validateUpdateL2Vpn(l2Vpn, advancedEdgeGatewayForTest()); err == nil {
+		t.Errorf("expected L2 VPN validation error for site with server and client configuration")
+	}
+}
+
+func Test_validateSslVpnSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func() error
+		wantErr  bool
+	}{
+		{
+			name: "ServerWithoutAddress",
+			validate: func() error {
+				return validateSslVpnServerSettings(&types.EdgeSslVpnServerSettings{Port: 443})
+			},
+			wantErr: true,
+		},
+		{
+			name: "ServerInvalidPort",
+			validate: func() error {
+				return validateSslVpnServerSettings(&types.EdgeSslVpnServerSettings{
+					ServerAddresses: &types.EdgeSslVpnServerAddresses{IpAddress: []string{"10.0.0.1"}}, Port: 70000})
+			},
+			wantErr: true,
+		},
+		{
+			name: "IpPoolInvalidRange",
+			validate: func() error {
+				return validateSslVpnIpPools(&types.EdgeSslVpnIpAddressPools{IpAddressPool: []types.EdgeSslVpnIpAddressPool{
+					{IpRange: "10.1.1.10", Netmask: "255.255.255.0", Gateway: "10.1.1.1"}}})
+			},
+			wantErr: true,
+		},
+		{
+			name: "PrivateNetworkInvalidCidr",
+			validate: func() error {
+				return validateSslVpnPrivateNetworks(&types.EdgeSslVpnPrivateNetworks{
+					PrivateNetwork: []types.EdgeSslVpnPrivateNetwork{{Network: "192.168.1.0"}}})
+			},
+			wantErr: true,
+		},
+		{
+			name: "NewUserWithoutPassword",
+			validate: func() error {
+				return validateSslVpnUsers(&types.EdgeSslVpnUsers{User: []types.EdgeSslVpnUser{{UserId: "bob"}}})
+			},
+			wantErr: true,
+		},
+		{
+			name: "DuplicateUser",
+			validate: func() error {
+				return validateSslVpnUsers(&types.EdgeSslVpnUsers{User: []types.EdgeSslVpnUser{
+					{UserId: "bob", Password: "secret"}, {UserId: "bob", Password: "secret"}}})
+			},
+			wantErr: true,
+		},
+		{
+			name: "InstallPackageWithoutGateway",
+			validate: func() error {
+				return validateSslVpnInstallPackages(&types.EdgeSslVpnInstallPackages{
+					ClientInstallPackage: []types.EdgeSslVpnInstallPackage{{ProfileName: "default"}}})
+			},
+			wantErr: true,
+		},
+		{
+			name: "AuthWithoutPrimaryServer",
+			validate: func() error {
+				return validateSslVpnAuthConfig(&types.EdgeSslVpnAuthConfig{
+					PasswordAuthentication: &types.EdgeSslVpnPasswordAuthentication{
+						PrimaryAuthServers: &types.EdgeSslVpnAuthServers{}}})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validation error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// assertXmlRoundTrip unmarshals sampleBody into firstPass, marshals it back and unmarshals into
+// secondPass. Both passes must be equal.
+func assertXmlRoundTrip(t *testing.T, sampleBody []byte, firstPass, secondPass interface{}) {
+	err := xml.Unmarshal(sampleBody, firstPass)
+	if err != nil {
+		t.Fatalf("error unmarshalling sample: %s", err)
+	}
+
+	marshalled, err := xml.Marshal(firstPass)
+	if err != nil {
+		t.Fatalf("error marshalling: %s", err)
+	}
+
+	err = xml.Unmarshal(marshalled, secondPass)
+	if err != nil {
+		t.Fatalf("error unmarshalling marshalled structure: %s", err)
+	}
+
+	if !reflect.DeepEqual(firstPass, secondPass) {
+		t.Errorf("structures differ after round trip:\n%+v\n%+v", firstPass, secondPass)
+	}
+}
@@ -0,0 +1,327 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// OvfProperty is an OVF property declared in the ProductSection of a vApp template or of one of its
+// VMs
+type OvfProperty struct {
+	Key              string
+	Label            string
+	Description      string
+	Type             string // OVF type, e.g. "string", "boolean", "uint16" or "real64"
+	Qualifiers       string // Constraints on the value, e.g. MinLen(1), MaxLen(64), ValueMap{"a","b"}
+	DefaultValue     string
+	UserConfigurable bool
+	Password         bool
+
+	// OwnerName and OwnerHref identify the vApp template or VM template declaring the property
+	OwnerName string
+	OwnerHref string
+}
+
+// Regular expressions for OVF property qualifiers (DSP0243)
+var (
+	reOvfQualifierMinLen   = regexp.MustCompile(`MinLen\(\s*(\d+)\s*\)`)
+	reOvfQualifierMaxLen   = regexp.MustCompile(`MaxLen\(\s*(\d+)\s*\)`)
+	reOvfQualifierMinValue = regexp.MustCompile(`MinValue\(\s*(-?[0-9.]+)\s*\)`)
+	reOvfQualifierMaxValue = regexp.MustCompile(`MaxValue\(\s*(-?[0-9.]+)\s*\)`)
+	reOvfQualifierValueMap = regexp.MustCompile(`ValueMap\s*\{([^}]*)\}`)
+)
+
+// GetOvfProperties retrieves the OVF properties declared in the vApp template and in its VMs
+func (vAppTemplate *VAppTemplate) GetOvfProperties(ctx context.Context) ([]*OvfProperty, error) {
+	if vAppTemplate.VAppTemplate == nil || vAppTemplate.VAppTemplate.HREF == "" {
+		return nil, fmt.Errorf("cannot retrieve OVF properties, vApp template HREF is empty")
+	}
+
+	owners := []*types.VAppTemplate{vAppTemplate.VAppTemplate}
+	if vAppTemplate.VAppTemplate.Children != nil {
+		owners = append(owners, vAppTemplate.VAppTemplate.Children.VM...)
+	}
+
+	var properties []*OvfProperty
+	for _, owner := range owners {
+		productSectionList, err := getProductSectionList(ctx, vAppTemplate.client, owner.HREF)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving OVF properties of %s: %s", owner.Name, err)
+		}
+		properties = append(properties, ovfPropertiesFromProductSection(productSectionList.ProductSection, owner.Name, owner.HREF)...)
+	}
+	return properties, nil
+}
+
+// SetOvfPropertiesForInstantiation validates values against the OVF properties of the vApp template
+// and adds them to the instantiation parameters. Properties declared by the vApp template are set
+// in params.InstantiationParams. Properties declared by a VM are set in params.SourcedItem, which
+// is only possible when a single VM declares the given properties.
+func (vAppTemplate *VAppTemplate) SetOvfPropertiesForInstantiation(ctx context.Context, params *types.InstantiateVAppTemplateParams,
+	values map[string]string) error {
+	if params == nil {
+		return fmt.Errorf("instantiation parameters must not be nil")
+	}
+
+	properties, err := vAppTemplate.GetOvfProperties(ctx)
+	if err != nil {
+		return err
+	}
+	err = ValidateOvfPropertyValues(properties, values)
+	if err != nil {
+		return err
+	}
+
+	sections := ovfPropertyValuesByOwner(properties, values)
+	for ownerHref, section := range sections {
+		if ownerHref == vAppTemplate.VAppTemplate.HREF {
+			if params.InstantiationParams == nil {
+				params.InstantiationParams = &types.InstantiationParams{}
+			}
+			params.InstantiationParams.ProductSection = section
+			continue
+		}
+
+		if params.SourcedItem == nil {
+			params.SourcedItem = &types.SourcedCompositionItemParam{
+				Source: &types.Reference{HREF: ownerHref},
+			}
+		}
+		if params.SourcedItem.Source == nil || params.SourcedItem.Source.HREF != ownerHref {
+			return fmt.Errorf("OVF properties of more than one VM can't be set at instantiation. " +
+				"Use VM.SetProductSectionList after instantiation instead")
+		}
+		if params.SourcedItem.InstantiationParams == nil {
+			params.SourcedItem.InstantiationParams = &types.InstantiationParams{}
+		}
+		params.SourcedItem.InstantiationParams.ProductSection = section
+	}
+	return nil
+}
+
+// SetOvfPropertiesForVmInstantiation validates values against the OVF properties of the VM template
+// referenced in params.SourcedVmTemplateItem.Source and adds them to the parameters of
+// Vdc.CreateStandaloneVMFromTemplate. vAppTemplate can be the VM template itself or the vApp
+// template containing it.
+func (vAppTemplate *VAppTemplate) SetOvfPropertiesForVmInstantiation(ctx context.Context, params *types.InstantiateVmTemplateParams,
+	values map[string]string) error {
+	if params == nil || params.SourcedVmTemplateItem == nil || params.SourcedVmTemplateItem.Source == nil {
+		return fmt.Errorf("instantiation parameters must contain SourcedVmTemplateItem with a Source")
+	}
+	vmTemplateHref := params.SourcedVmTemplateItem.Source.HREF
+
+	allProperties, err := vAppTemplate.GetOvfProperties(ctx)
+	if err != nil {
+		return err
+	}
+	var properties []*OvfProperty
+	for _, property := range allProperties {
+		if property.OwnerHref == vmTemplateHref {
+			properties = append(properties, property)
+		}
+	}
+
+	err = ValidateOvfPropertyValues(properties, values)
+	if err != nil {
+		return err
+	}
+
+	section := ovfPropertyValuesByOwner(properties, values)[vmTemplateHref]
+	if section == nil {
+		return nil
+	}
+	if params.SourcedVmTemplateItem.VmTemplateInstantiationParams == nil {
+		params.SourcedVmTemplateItem.VmTemplateInstantiationParams = &types.InstantiationParams{}
+	}
+	params.SourcedVmTemplateItem.VmTemplateInstantiationParams.ProductSection = section
+	return nil
+}
+
+// ValidateOvfPropertyValues checks values against the declared properties. It fails for unknown
+// keys, properties which are not user configurable, values which don't match the property type or
+// qualifiers, and typed user configurable properties which end up without a value.
+func ValidateOvfPropertyValues(properties []*OvfProperty, values map[string]string) error {
+	declared := make(map[string][]*OvfProperty)
+	for _, property := range properties {
+		declared[property.Key] = append(declared[property.Key], property)
+	}
+
+	var errors []string
+	for key, value := range values {
+		declarations, ok := declared[key]
+		if !ok {
+			errors = append(errors, fmt.Sprintf("property '%s' is not declared", key))
+			continue
+		}
+		for _, property := range declarations {
+			if !property.UserConfigurable {
+				errors = append(errors, fmt.Sprintf("property '%s' of %s is not user configurable", key, property.OwnerName))
+				continue
+			}
+			err := validateOvfPropertyValue(property, value)
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("property '%s' of %s: %s", key, property.OwnerName, err))
+			}
+		}
+	}
+
+	for _, property := range properties {
+		if _, ok := values[property.Key]; ok || property.Type == "" || property.Type == "string" {
+			continue
+		}
+		// Values can't be set for properties which are not user configurable
+		if !property.UserConfigurable {
+			continue
+		}
+		if property.DefaultValue == "" {
+			errors = append(errors, fmt.Sprintf("property '%s' of %s of type %s requires a value",
+				property.Key, property.OwnerName, property.Type))
+		}
+	}
+
+	if len(errors) > 0 {
+		sort.Strings(errors)
+		return fmt.Errorf("invalid OVF property values: %s", strings.Join(errors, "; "))
+	}
+	return nil
+}
+
+// validateOvfPropertyValue checks a single value against the type and qualifiers of a property
+func validateOvfPropertyValue(property *OvfProperty, value string) error {
+	switch property.Type {
+	case "", "string":
+	case "boolean":
+		if value != "true" && value != "false" {
+			return fmt.Errorf("value '%s' is not a boolean (true or false)", value)
+		}
+	case "real32", "real64":
+		_, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("value '%s' is not a %s", value, property.Type)
+		}
+	case "uint8", "uint16", "uint32", "uint64", "sint8", "sint16", "sint32", "sint64":
+		bits, _ := strconv.Atoi(property.Type[4:])
+		var err error
+		if property.Type[0] == 'u' {
+			_, err = strconv.ParseUint(value, 10, bits)
+		} else {
+			_, err = strconv.ParseInt(value, 10, bits)
+		}
+		if err != nil {
+			return fmt.Errorf("value '%s' is not a valid %s", value, property.Type)
+		}
+	default:
+		return fmt.Errorf("unsupported property type '%s'", property.Type)
+	}
+
+	qualifiers := property.Qualifiers
+	if match := reOvfQualifierMinLen.FindStringSubmatch(qualifiers); match != nil {
+		minLength, _ := strconv.Atoi(match[1])
+		if len(value) < minLength {
+			return fmt.Errorf("value must be at least %d characters long", minLength)
+		}
+	}
+	if match := reOvfQualifierMaxLen.FindStringSubmatch(qualifiers); match != nil {
+		maxLength, _ := strconv.Atoi(match[1])
+		if len(value) > maxLength {
+			return fmt.Errorf("value must be at most %d characters long", maxLength)
+		}
+	}
+	minValue, maxValue := math.Inf(-1), math.Inf(1)
+	if match := reOvfQualifierMinValue.FindStringSubmatch(qualifiers); match != nil {
+		minValue, _ = strconv.ParseFloat(match[1], 64)
+	}
+	if match := reOvfQualifierMaxValue.FindStringSubmatch(qualifiers); match != nil {
+		maxValue, _ = strconv.ParseFloat(match[1], 64)
+	}
+	if !math.IsInf(minValue, -1) || !math.IsInf(maxValue, 1) {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("value '%s' is not a number", value)
+		}
+		if number < minValue || number > maxValue {
+			return fmt.Errorf("value %s is out of range [%v, %v]", value, minValue, maxValue)
+		}
+	}
+	if match := reOvfQualifierValueMap.FindStringSubmatch(qualifiers); match != nil {
+		allowed := parseOvfValueMap(match[1])
+		if !stringInSlice(value, allowed) {
+			return fmt.Errorf("value '%s' is not one of %s", value, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// parseOvfValueMap splits the content of a ValueMap qualifier ("a", "b") into its values
+func parseOvfValueMap(valueMap string) []string {
+	var values []string
+	for _, value := range strings.Split(valueMap, ",") {
+		value = strings.TrimSpace(value)
+		value = strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+		values = append(values, value)
+	}
+	return values
+}
+
+// ovfPropertiesFromProductSection converts the properties of a product section into OvfProperty
+func ovfPropertiesFromProductSection(productSection *types.ProductSection, ownerName, ownerHref string) []*OvfProperty {
+	if productSection == nil {
+		return nil
+	}
+	properties := make([]*OvfProperty, 0, len(productSection.Property))
+	for _, property := range productSection.Property {
+		properties = append(properties, &OvfProperty{
+			Key:              property.Key,
+			Label:            property.Label,
+			Description:      property.Description,
+			Type:             property.Type,
+			Qualifiers:       property.Qualifiers,
+			DefaultValue:     property.DefaultValue,
+			UserConfigurable: property.UserConfigurable,
+			Password:         property.Password,
+			OwnerName:        ownerName,
+			OwnerHref:        ownerHref,
+		})
+	}
+	return properties
+}
+
+// ovfPropertyValuesByOwner builds a product section with the given values for each owner (vApp
+// template or VM template) declaring at least one of the keys
+func ovfPropertyValuesByOwner(properties []*OvfProperty, values map[string]string) map[string]*types.ProductSection {
+	sections := make(map[string]*types.ProductSection)
+	for _, property := range properties {
+		value, ok := values[property.Key]
+		if !ok {
+			continue
+		}
+		section, ok := sections[property.OwnerHref]
+		if !ok {
+			section = &types.ProductSection{Info: "Custom properties"}
+			sections[property.OwnerHref] = section
+		}
+		section.Property = append(section.Property, &types.Property{
+			Key:              property.Key,
+			Label:            property.Label,
+			Description:      property.Description,
+			DefaultValue:     property.DefaultValue,
+			Value:            &types.Value{Value: value},
+			Type:             property.Type,
+			Qualifiers:       property.Qualifiers,
+			UserConfigurable: property.UserConfigurable,
+			Password:         property.Password,
+		})
+	}
+	return sections
+}
@@ -0,0 +1,111 @@
+// +build vapp unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_ValidateOvfPropertyValues(t *testing.T) {
+	properties := []*OvfProperty{
+		{Key: "hostname", Type: "string", Qualifiers: "MinLen(1) MaxLen(15)", UserConfigurable: true, OwnerName: "vm"},
+		{Key: "port", Type: "uint16", DefaultValue: "443", UserConfigurable: true, OwnerName: "vm"},
+		{Key: "ratio", Type: "real32", Qualifiers: "MinValue(0) MaxValue(1)", DefaultValue: "0.5", UserConfigurable: true, OwnerName: "vm"},
+		{Key: "debug", Type: "boolean", DefaultValue: "false", UserConfigurable: true, OwnerName: "vm"},
+		{Key: "size", Type: "string", Qualifiers: `ValueMap{"small", "medium", "large"}`, DefaultValue: "small",
+			UserConfigurable: true, OwnerName: "vm"},
+		{Key: "offset", Type: "sint8", DefaultValue: "0", UserConfigurable: true, OwnerName: "vm"},
+		{Key: "vendor.version", Type: "string", DefaultValue: "1.0", OwnerName: "vm"},
+	}
+	tests := []struct {
+		name    string
+		values  map[string]string
+		wantErr string
+	}{
+		{name: "NoValues", values: nil},
+		{name: "ValidValues", values: map[string]string{"hostname": "appliance", "port": "8443", "ratio": "0.25",
+			"debug": "true", "size": "large", "offset": "-12"}},
+		{name: "UnknownKey", values: map[string]string{"unknown": "x"}, wantErr: "not declared"},
+		{name: "NotUserConfigurable", values: map[string]string{"vendor.version": "2.0"}, wantErr: "not user configurable"},
+		{name: "TooShort", values: map[string]string{"hostname": ""}, wantErr: "at least 1"},
+		{name: "TooLong", values: map[string]string{"hostname": "a-very-long-hostname"}, wantErr: "at most 15"},
+		{name: "PortOutOfRange", values: map[string]string{"port": "70000"}, wantErr: "not a valid uint16"},
+		{name: "NegativeUnsigned", values: map[string]string{"port": "-1"}, wantErr: "not a valid uint16"},
+		{name: "SignedOutOfRange", values: map[string]string{"offset": "200"}, wantErr: "not a valid sint8"},
+		{name: "InvalidBoolean", values: map[string]string{"debug": "yes"}, wantErr: "not a boolean"},
+		{name: "RatioOutOfRange", values: map[string]string{"ratio": "1.5"}, wantErr: "out of range"},
+		{name: "InvalidReal", values: map[string]string{"ratio": "half"}, wantErr: "not a real32"},
+		{name: "NotInValueMap", values: map[string]string{"size": "huge"}, wantErr: "not one of small, medium, large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOvfPropertyValues(properties, tt.values)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateOvfPropertyValues() unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateOvfPropertyValues() error = %v, want error containing '%s'", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Typed properties without default require a value
+	required := []*OvfProperty{{Key: "port", Type: "uint16", UserConfigurable: true, OwnerName: "vm"}}
+	err := ValidateOvfPropertyValues(required, nil)
+	if err == nil || !strings.Contains(err.Error(), "requires a value") {
+		t.Errorf("ValidateOvfPropertyValues() error = %v, want missing value error", err)
+	}
+	err = ValidateOvfPropertyValues(required, map[string]string{"port": "22"})
+	if err != nil {
+		t.Errorf("ValidateOvfPropertyValues() unexpected error: %s", err)
+	}
+
+	// Properties which are not user configurable can't be given a value, so they never require one
+	fixed := []*OvfProperty{{Key: "build", Type: "uint16", OwnerName: "vm"}}
+	err = ValidateOvfPropertyValues(fixed, nil)
+	if err != nil {
+		t.Errorf("ValidateOvfPropertyValues() unexpected error for property which is not user configurable: %s", err)
+	}
+}
+
+func Test_ovfPropertyValuesByOwner(t *testing.T) {
+	productSection := &types.ProductSection{Property: []*types.Property{
+		{Key: "hostname", Type: "string", UserConfigurable: true},
+		{Key: "password", Type: "string", UserConfigurable: true, Password: true},
+		{Key: "port", Type: "uint16", DefaultValue: "443", UserConfigurable: true},
+	}}
+	properties := ovfPropertiesFromProductSection(productSection, "vm", "https://vcd/vm-1")
+	properties = append(properties, ovfPropertiesFromProductSection(&types.ProductSection{Property: []*types.Property{
+		{Key: "site", Type: "string", UserConfigurable: true},
+	}}, "template", "https://vcd/vappTemplate-1")...)
+
+	sections := ovfPropertyValuesByOwner(properties, map[string]string{"hostname": "appliance", "site": "eu"})
+	if len(sections) != 2 {
+		t.Fatalf("ovfPropertyValuesByOwner() returned %d sections, want 2", len(sections))
+	}
+	vmSection := sections["https://vcd/vm-1"]
+	if vmSection == nil || len(vmSection.Property) != 1 || vmSection.Property[0].Value.Value != "appliance" {
+		t.Errorf("ovfPropertyValuesByOwner() unexpected VM section %+v", vmSection)
+	}
+
+	// The product section is serialized in the OVF namespace when used in instantiation parameters
+	params := types.InstantiationParams{ProductSection: vmSection}
+	text, err := xml.Marshal(params)
+	if err != nil {
+		t.Fatalf("error marshalling instantiation parameters: %s", err)
+	}
+	if !strings.Contains(string(text), `<ProductSection xmlns="`+types.XMLNamespaceOVF+`">`) {
+		t.Errorf("product section not in OVF namespace: %s", text)
+	}
+}
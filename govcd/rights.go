@@ -0,0 +1,338 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// GetAllRights retrieves all rights available in VCD. Query parameters can be supplied to perform
+// additional filtering (e.g. "filter=category==<category ID>")
+func (vcdClient *VCDClient) GetAllRights(ctx context.Context, queryParameters url.Values) ([]*types.Right, error) {
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRights
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.Right{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	return typeResponses, nil
+}
+
+// GetRightByName retrieves a right by its name
+func (vcdClient *VCDClient) GetRightByName(ctx context.Context, name string) (*types.Right, error) {
+	queryParameters := url.Values{}
+	queryParameters.Add("filter", "name=="+name)
+
+	rights, err := vcdClient.GetAllRights(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error reading right with name '%s': %s", name, err)
+	}
+
+	if len(rights) == 0 {
+		return nil, fmt.Errorf("%s: could not find right with name '%s'", ErrorEntityNotFound, name)
+	}
+
+	if len(rights) > 1 {
+		return nil, fmt.Errorf("found more than 1 right with name '%s'", name)
+	}
+
+	return rights[0], nil
+}
+
+// GetRightById retrieves a right by its ID
+func (vcdClient *VCDClient) GetRightById(ctx context.Context, id string) (*types.Right, error) {
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRights
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("empty right id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	right := &types.Right{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, right)
+	if err != nil {
+		return nil, err
+	}
+
+	return right, nil
+}
+
+// GetAllRightsCategories retrieves all rights categories. Query parameters can be supplied to
+// perform additional filtering
+func (vcdClient *VCDClient) GetAllRightsCategories(ctx context.Context, queryParameters url.Values) ([]*types.RightsCategory, error) {
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRightsCategories
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.RightsCategory{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	return typeResponses, nil
+}
+
+// GetRightsCategoryById retrieves a rights category by its ID. It can be used to look up the
+// category of a right (types.Right.Category)
+func (vcdClient *VCDClient) GetRightsCategoryById(ctx context.Context, id string) (*types.RightsCategory, error) {
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRightsCategories
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("empty rights category id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	category := &types.RightsCategory{}
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, category)
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// getRights retrieves the rights of a role, global role or rights bundle. endpointTemplate is one
+// of the OpenApiEndpoint*Rights endpoints, containing a placeholder for the entity ID.
+func getRights(ctx context.Context, client *Client, endpointTemplate, id string, queryParameters url.Values) ([]*types.Right, error) {
+	endpoint := types.OpenApiPathVersion1_0_0 + endpointTemplate
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("cannot retrieve rights without entity id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, id))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.Right{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	return typeResponses, nil
+}
+
+// addRights adds rights to a role, global role or rights bundle
+func addRights(ctx context.Context, client *Client, endpointTemplate, id string, newRights []types.OpenApiReference) error {
+	if len(newRights) == 0 {
+		return nil
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + endpointTemplate
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if id == "" {
+		return fmt.Errorf("cannot add rights without entity id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, id))
+	if err != nil {
+		return err
+	}
+
+	input := types.OpenApiItems{Values: newRights}
+	var output types.OpenApiItems
+	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, &input, &output)
+	if err != nil {
+		return fmt.Errorf("error adding rights: %s", err)
+	}
+
+	return nil
+}
+
+// setRights replaces all rights of a role, global role or rights bundle with the given ones
+func setRights(ctx context.Context, client *Client, endpointTemplate, id string, rights []types.OpenApiReference) error {
+	endpoint := types.OpenApiPathVersion1_0_0 + endpointTemplate
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if id == "" {
+		return fmt.Errorf("cannot set rights without entity id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, id))
+	if err != nil {
+		return err
+	}
+
+	input := types.OpenApiItems{Values: rights}
+	if input.Values == nil {
+		input.Values = []types.OpenApiReference{}
+	}
+	var output types.OpenApiItems
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, &input, &output)
+	if err != nil {
+		return fmt.Errorf("error setting rights: %s", err)
+	}
+
+	return nil
+}
+
+// removeRights removes rights from a role, global role or rights bundle by setting the remaining
+// ones. It fails if any of the rights to remove is not granted.
+func removeRights(ctx context.Context, client *Client, endpointTemplate, id string, rightsToRemove []types.OpenApiReference) error {
+	if len(rightsToRemove) == 0 {
+		return nil
+	}
+
+	currentRights, err := getRights(ctx, client, endpointTemplate, id, nil)
+	if err != nil {
+		return err
+	}
+
+	remainingRights, err := subtractRights(currentRights, rightsToRemove)
+	if err != nil {
+		return err
+	}
+
+	return setRights(ctx, client, endpointTemplate, id, remainingRights)
+}
+
+// subtractRights returns the references of currentRights which are not in rightsToRemove. Rights
+// are matched by ID or, when the ID is not set, by name.
+func subtractRights(currentRights []*types.Right, rightsToRemove []types.OpenApiReference) ([]types.OpenApiReference, error) {
+	toRemove := make(map[string]bool)
+	for _, right := range rightsToRemove {
+		found := false
+		for _, current := range currentRights {
+			if (right.ID != "" && right.ID == current.ID) || (right.ID == "" && right.Name == current.Name) {
+				toRemove[current.ID] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s: right '%s' %s is not granted", ErrorEntityNotFound, right.Name, right.ID)
+		}
+	}
+
+	remaining := []types.OpenApiReference{}
+	for _, current := range currentRights {
+		if !toRemove[current.ID] {
+			remaining = append(remaining, types.OpenApiReference{Name: current.Name, ID: current.ID})
+		}
+	}
+	return remaining, nil
+}
+
+// getTenants retrieves the organizations to which a global role or rights bundle is published
+func getTenants(ctx context.Context, client *Client, endpointTemplate, id string, queryParameters url.Values) ([]types.OpenApiReference, error) {
+	endpoint := types.OpenApiPathVersion1_0_0 + endpointTemplate
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("cannot retrieve tenants without entity id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, id))
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []types.OpenApiReference{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	return typeResponses, nil
+}
+
+// publishTenants runs one of the publishing actions ("publish", "unpublish", "publishAll" or
+// "unpublishAll") of a global role or rights bundle. tenants is ignored for "publishAll" and
+// "unpublishAll".
+func publishTenants(ctx context.Context, client *Client, endpointTemplate, id, action string, tenants []types.OpenApiReference) error {
+	endpoint := types.OpenApiPathVersion1_0_0 + endpointTemplate
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if id == "" {
+		return fmt.Errorf("cannot %s without entity id", action)
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(fmt.Sprintf(endpoint, id), "/"+action)
+	if err != nil {
+		return err
+	}
+
+	input := types.OpenApiItems{Values: []types.OpenApiReference{}}
+	switch action {
+	case "publish", "unpublish":
+		if len(tenants) == 0 {
+			return fmt.Errorf("no tenants given to %s", action)
+		}
+		input.Values = tenants
+	case "publishAll", "unpublishAll":
+	default:
+		return fmt.Errorf("unknown publishing action '%s'", action)
+	}
+
+	var output types.OpenApiItems
+	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, &input, &output)
+	if err != nil {
+		return fmt.Errorf("error running %s: %s", action, err)
+	}
+
+	return nil
+}
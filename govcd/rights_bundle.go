@@ -0,0 +1,239 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// RightsBundle is a set of rights defined by the provider which can be published to tenants. The rights
+// of the bundles published to an organization are the ones that the organization roles can use.
+type RightsBundle struct {
+	RightsBundle *types.RightsBundle
+	client       *Client
+}
+
+// GetAllRightsBundles retrieves all rights bundles. Query parameters can be supplied to perform
+// additional filtering
+func (vcdClient *VCDClient) GetAllRightsBundles(ctx context.Context, queryParameters url.Values) ([]*RightsBundle, error) {
+	client := &vcdClient.Client
+	if !client.IsSysAdmin {
+		return nil, fmt.Errorf("reading rights bundles requires System user")
+	}
+
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRightsBundles
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	typeResponses := []*types.RightsBundle{{}}
+	err = client.OpenApiGetAllItems(ctx, minimumApiVersion, urlRef, queryParameters, &typeResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wrap all typeResponses into RightsBundle types with client
+	wrappedResponses := make([]*RightsBundle, len(typeResponses))
+	for sliceIndex := range typeResponses {
+		wrappedResponses[sliceIndex] = &RightsBundle{
+			RightsBundle: typeResponses[sliceIndex],
+			client:       client,
+		}
+	}
+
+	return wrappedResponses, nil
+}
+
+// GetRightsBundleByName retrieves a rights bundle by name
+func (vcdClient *VCDClient) GetRightsBundleByName(ctx context.Context, name string) (*RightsBundle, error) {
+	queryParameters := url.Values{}
+	queryParameters.Add("filter", "name=="+name)
+
+	rightsBundles, err := vcdClient.GetAllRightsBundles(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rights bundle with name '%s': %s", name, err)
+	}
+
+	if len(rightsBundles) == 0 {
+		return nil, fmt.Errorf("%s: could not find rights bundle with name '%s'", ErrorEntityNotFound, name)
+	}
+
+	if len(rightsBundles) > 1 {
+		return nil, fmt.Errorf("found more than 1 rights bundle with name '%s'", name)
+	}
+
+	return rightsBundles[0], nil
+}
+
+// GetRightsBundleById retrieves a rights bundle by ID
+func (vcdClient *VCDClient) GetRightsBundleById(ctx context.Context, id string) (*RightsBundle, error) {
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRightsBundles
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("empty rights bundle id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rightsBundle := &RightsBundle{
+		RightsBundle: &types.RightsBundle{},
+		client:       client,
+	}
+
+	err = client.OpenApiGetItem(ctx, minimumApiVersion, urlRef, nil, rightsBundle.RightsBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return rightsBundle, nil
+}
+
+// CreateRightsBundle creates a new rights bundle
+func (vcdClient *VCDClient) CreateRightsBundle(ctx context.Context, newRightsBundle *types.RightsBundle) (*RightsBundle, error) {
+	client := &vcdClient.Client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRightsBundles
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	returnRightsBundle := &RightsBundle{
+		RightsBundle: &types.RightsBundle{},
+		client:       client,
+	}
+
+	err = client.OpenApiPostItem(ctx, minimumApiVersion, urlRef, nil, newRightsBundle, returnRightsBundle.RightsBundle)
+	if err != nil {
+		return nil, fmt.Errorf("error creating rights bundle: %s", err)
+	}
+
+	return returnRightsBundle, nil
+}
+
+// Update updates an existing rights bundle
+func (rightsBundle *RightsBundle) Update(ctx context.Context) (*RightsBundle, error) {
+	client := rightsBundle.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRightsBundles
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if rightsBundle.RightsBundle.ID == "" {
+		return nil, fmt.Errorf("cannot update rights bundle without id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, rightsBundle.RightsBundle.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	returnRightsBundle := &RightsBundle{
+		RightsBundle: &types.RightsBundle{},
+		client:       client,
+	}
+
+	err = client.OpenApiPutItem(ctx, minimumApiVersion, urlRef, nil, rightsBundle.RightsBundle, returnRightsBundle.RightsBundle)
+	if err != nil {
+		return nil, fmt.Errorf("error updating rights bundle: %s", err)
+	}
+
+	return returnRightsBundle, nil
+}
+
+// Delete deletes the rights bundle
+func (rightsBundle *RightsBundle) Delete(ctx context.Context) error {
+	client := rightsBundle.client
+	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRightsBundles
+	minimumApiVersion, err := client.checkOpenApiEndpointCompatibility(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if rightsBundle.RightsBundle.ID == "" {
+		return fmt.Errorf("cannot delete rights bundle without id")
+	}
+
+	urlRef, err := client.OpenApiBuildEndpoint(endpoint, rightsBundle.RightsBundle.ID)
+	if err != nil {
+		return err
+	}
+
+	err = client.OpenApiDeleteItem(ctx, minimumApiVersion, urlRef, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting rights bundle: %s", err)
+	}
+
+	return nil
+}
+
+// GetRights retrieves the rights of the rights bundle
+func (rightsBundle *RightsBundle) GetRights(ctx context.Context, queryParameters url.Values) ([]*types.Right, error) {
+	return getRights(ctx, rightsBundle.client, types.OpenApiEndpointRightsBundleRights, rightsBundle.RightsBundle.ID, queryParameters)
+}
+
+// AddRights adds the given rights to the rights bundle
+func (rightsBundle *RightsBundle) AddRights(ctx context.Context, newRights []types.OpenApiReference) error {
+	return addRights(ctx, rightsBundle.client, types.OpenApiEndpointRightsBundleRights, rightsBundle.RightsBundle.ID, newRights)
+}
+
+// RemoveRights removes the given rights from the rights bundle. It fails if any of them is not
+// part of the rights bundle.
+func (rightsBundle *RightsBundle) RemoveRights(ctx context.Context, removedRights []types.OpenApiReference) error {
+	return removeRights(ctx, rightsBundle.client, types.OpenApiEndpointRightsBundleRights, rightsBundle.RightsBundle.ID, removedRights)
+}
+
+// SetRights replaces all rights of the rights bundle with the given ones
+func (rightsBundle *RightsBundle) SetRights(ctx context.Context, rights []types.OpenApiReference) error {
+	return setRights(ctx, rightsBundle.client, types.OpenApiEndpointRightsBundleRights, rightsBundle.RightsBundle.ID, rights)
+}
+
+// GetTenants retrieves the organizations to which the rights bundle is published
+func (rightsBundle *RightsBundle) GetTenants(ctx context.Context, queryParameters url.Values) ([]types.OpenApiReference, error) {
+	return getTenants(ctx, rightsBundle.client, types.OpenApiEndpointRightsBundleTenants, rightsBundle.RightsBundle.ID, queryParameters)
+}
+
+// PublishTenants publishes the rights bundle to the given organizations
+func (rightsBundle *RightsBundle) PublishTenants(ctx context.Context, tenants []types.OpenApiReference) error {
+	return publishTenants(ctx, rightsBundle.client, types.OpenApiEndpointRightsBundleTenants, rightsBundle.RightsBundle.ID, "publish", tenants)
+}
+
+// UnpublishTenants removes the rights bundle from the given organizations
+func (rightsBundle *RightsBundle) UnpublishTenants(ctx context.Context, tenants []types.OpenApiReference) error {
+	return publishTenants(ctx, rightsBundle.client, types.OpenApiEndpointRightsBundleTenants, rightsBundle.RightsBundle.ID, "unpublish", tenants)
+}
+
+// PublishAllTenants publishes the rights bundle to all organizations, including future ones
+func (rightsBundle *RightsBundle) PublishAllTenants(ctx context.Context) error {
+	return publishTenants(ctx, rightsBundle.client, types.OpenApiEndpointRightsBundleTenants, rightsBundle.RightsBundle.ID, "publishAll", nil)
+}
+
+// UnpublishAllTenants removes the rights bundle from all organizations
+func (rightsBundle *RightsBundle) UnpublishAllTenants(ctx context.Context) error {
+	return publishTenants(ctx, rightsBundle.client, types.OpenApiEndpointRightsBundleTenants, rightsBundle.RightsBundle.ID, "unpublishAll", nil)
+}
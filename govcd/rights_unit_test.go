@@ -0,0 +1,64 @@
+// +build unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_subtractRights(t *testing.T) {
+	currentRights := []*types.Right{
+		{ID: "urn:vcloud:right:1", Name: "Catalog: View Private and Shared Catalogs"},
+		{ID: "urn:vcloud:right:2", Name: "vApp: Power Operations"},
+		{ID: "urn:vcloud:right:3", Name: "vApp: View VM metrics"},
+	}
+	tests := []struct {
+		name           string
+		rightsToRemove []types.OpenApiReference
+		want           []types.OpenApiReference
+		wantErr        bool
+	}{
+		{name: "Nothing", rightsToRemove: nil, want: []types.OpenApiReference{
+			{ID: "urn:vcloud:right:1", Name: "Catalog: View Private and Shared Catalogs"},
+			{ID: "urn:vcloud:right:2", Name: "vApp: Power Operations"},
+			{ID: "urn:vcloud:right:3", Name: "vApp: View VM metrics"},
+		}},
+		{name: "ById", rightsToRemove: []types.OpenApiReference{{ID: "urn:vcloud:right:2"}}, want: []types.OpenApiReference{
+			{ID: "urn:vcloud:right:1", Name: "Catalog: View Private and Shared Catalogs"},
+			{ID: "urn:vcloud:right:3", Name: "vApp: View VM metrics"},
+		}},
+		{name: "ByName", rightsToRemove: []types.OpenApiReference{{Name: "vApp: View VM metrics"}}, want: []types.OpenApiReference{
+			{ID: "urn:vcloud:right:1", Name: "Catalog: View Private and Shared Catalogs"},
+			{ID: "urn:vcloud:right:2", Name: "vApp: Power Operations"},
+		}},
+		{name: "All", rightsToRemove: []types.OpenApiReference{
+			{ID: "urn:vcloud:right:1"}, {Name: "vApp: Power Operations"}, {ID: "urn:vcloud:right:3"},
+		}, want: []types.OpenApiReference{}},
+		{name: "NotGrantedId", rightsToRemove: []types.OpenApiReference{{ID: "urn:vcloud:right:4"}}, wantErr: true},
+		{name: "NotGrantedName", rightsToRemove: []types.OpenApiReference{{Name: "Organization: Edit Name"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := subtractRights(currentRights, tt.rightsToRemove)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("subtractRights() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !ContainsNotFound(err) {
+					t.Errorf("subtractRights() error = %v, want ErrorEntityNotFound", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("subtractRights() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -80,6 +80,27 @@ func (adminOrg *AdminOrg) GetAllOpenApiRoles(ctx context.Context, queryParameter
 	return returnRoles, nil
 }
 
+// GetOpenApiRoleByName retrieves role by given name
+func (adminOrg *AdminOrg) GetOpenApiRoleByName(ctx context.Context, name string) (*Role, error) {
+	queryParameters := url.Values{}
+	queryParameters.Add("filter", "name=="+name)
+
+	roles, err := adminOrg.GetAllOpenApiRoles(ctx, queryParameters)
+	if err != nil {
+		return nil, fmt.Errorf("error reading role with name '%s': %s", name, err)
+	}
+
+	if len(roles) == 0 {
+		return nil, fmt.Errorf("%s: could not find role with name '%s'", ErrorEntityNotFound, name)
+	}
+
+	if len(roles) > 1 {
+		return nil, fmt.Errorf("found more than 1 role with name '%s'", name)
+	}
+
+	return roles[0], nil
+}
+
 // CreateRole creates a new role using OpenAPI endpoint
 func (adminOrg *AdminOrg) CreateRole(ctx context.Context, newRole *types.Role) (*Role, error) {
 	endpoint := types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointRoles
@@ -161,3 +182,24 @@ func (role *Role) Delete(ctx context.Context) error {
 
 	return nil
 }
+
+// GetRights retrieves the rights granted to the role. Query parameters can be supplied to perform
+// additional filtering
+func (role *Role) GetRights(ctx context.Context, queryParameters url.Values) ([]*types.Right, error) {
+	return getRights(ctx, role.client, types.OpenApiEndpointRoleRights, role.Role.ID, queryParameters)
+}
+
+// AddRights grants the given rights to the role, keeping the ones already granted
+func (role *Role) AddRights(ctx context.Context, newRights []types.OpenApiReference) error {
+	return addRights(ctx, role.client, types.OpenApiEndpointRoleRights, role.Role.ID, newRights)
+}
+
+// RemoveRights revokes the given rights from the role. It fails if any of them is not granted.
+func (role *Role) RemoveRights(ctx context.Context, removedRights []types.OpenApiReference) error {
+	return removeRights(ctx, role.client, types.OpenApiEndpointRoleRights, role.Role.ID, removedRights)
+}
+
+// SetRights replaces all rights of the role with the given ones. An empty list revokes all rights.
+func (role *Role) SetRights(ctx context.Context, rights []types.OpenApiReference) error {
+	return setRights(ctx, role.client, types.OpenApiEndpointRoleRights, role.Role.ID, rights)
+}
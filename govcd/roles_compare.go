@@ -0,0 +1,251 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// RoleDiff describes how a role differs between two sets of roles (e.g. the roles of two
+// organizations, or a tenant role and its global role)
+type RoleDiff struct {
+	Name string
+	// MissingInFirst is set when the role only exists in the second set
+	MissingInFirst bool
+	// MissingInSecond is set when the role only exists in the first set
+	MissingInSecond bool
+	// RightsAdded are the names of the rights granted in the second set but not in the first one
+	RightsAdded []string
+	// RightsRemoved are the names of the rights granted in the first set but not in the second one
+	RightsRemoved []string
+}
+
+// IsEmpty returns true when the role is the same in both sets
+func (diff RoleDiff) IsEmpty() bool {
+	return !diff.MissingInFirst && !diff.MissingInSecond && len(diff.RightsAdded) == 0 && len(diff.RightsRemoved) == 0
+}
+
+// CompareRoles compares the roles of two organizations and returns, for each role which is not
+// identical, the rights added and removed in orgB compared to orgA, or whether the role is missing
+// in one of them. Roles are matched by name and the result is sorted by role name.
+func CompareRoles(ctx context.Context, orgA, orgB *AdminOrg) ([]RoleDiff, error) {
+	rolesA, err := getRoleRightNames(ctx, orgA)
+	if err != nil {
+		return nil, err
+	}
+	rolesB, err := getRoleRightNames(ctx, orgB)
+	if err != nil {
+		return nil, err
+	}
+	return diffRoles(rolesA, rolesB), nil
+}
+
+// CloneRole copies the role with the given name from one organization to another, including its
+// rights. It fails if a role with the same name already exists in the destination organization.
+func CloneRole(ctx context.Context, fromOrg, toOrg *AdminOrg, name string) (*Role, error) {
+	sourceRole, err := fromOrg.GetOpenApiRoleByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = toOrg.GetOpenApiRoleByName(ctx, name)
+	if err == nil {
+		return nil, fmt.Errorf("role '%s' already exists in organization '%s'", name, toOrg.AdminOrg.Name)
+	}
+	if !ContainsNotFound(err) {
+		return nil, err
+	}
+
+	rights, err := sourceRole.GetRights(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving rights of role '%s': %s", name, err)
+	}
+
+	newRole, err := toOrg.CreateRole(ctx, &types.Role{
+		Name:        sourceRole.Role.Name,
+		Description: sourceRole.Role.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = newRole.AddRights(ctx, rightsToReferences(rights))
+	if err != nil {
+		// Remove the role without rights, so that the clone can be retried
+		deleteErr := newRole.Delete(ctx)
+		if deleteErr != nil {
+			return nil, fmt.Errorf("error copying rights of role '%s': %s. Removal of the new role failed: %s",
+				name, err, deleteErr)
+		}
+		return nil, fmt.Errorf("error copying rights of role '%s': %s", name, err)
+	}
+
+	return newRole, nil
+}
+
+// SyncRolesFromGlobal re-aligns the rights of the tenant roles of adminOrg with the global roles of
+// the same name which are published to it. Read-only tenant roles are managed by VCD and are
+// skipped. It returns the differences which were corrected, using the tenant role as first set and
+// the global role as second one.
+func SyncRolesFromGlobal(ctx context.Context, vcdClient *VCDClient, adminOrg *AdminOrg) ([]RoleDiff, error) {
+	globalRoles, err := vcdClient.GetAllGlobalRoles(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantRoles, err := adminOrg.GetAllOpenApiRoles(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	tenantRolesByName := make(map[string]*Role)
+	for _, role := range tenantRoles {
+		tenantRolesByName[role.Role.Name] = role
+	}
+
+	var diffs []RoleDiff
+	for _, globalRole := range globalRoles {
+		tenantRole, found := tenantRolesByName[globalRole.GlobalRole.Name]
+		if !found || tenantRole.Role.ReadOnly {
+			continue
+		}
+
+		var tenants []types.OpenApiReference
+		if globalRole.GlobalRole.PublishAll == nil || !*globalRole.GlobalRole.PublishAll {
+			tenants, err = globalRole.GetTenants(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !isPublishedTo(globalRole.GlobalRole, tenants, adminOrg.AdminOrg.ID) {
+			continue
+		}
+
+		globalRights, err := globalRole.GetRights(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		tenantRights, err := tenantRole.GetRights(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		diff := diffRoles(
+			map[string][]string{tenantRole.Role.Name: rightNames(tenantRights)},
+			map[string][]string{tenantRole.Role.Name: rightNames(globalRights)})
+		if len(diff) == 0 {
+			continue
+		}
+
+		err = tenantRole.SetRights(ctx, rightsToReferences(globalRights))
+		if err != nil {
+			return nil, fmt.Errorf("error aligning role '%s' with its global role: %s", tenantRole.Role.Name, err)
+		}
+		diffs = append(diffs, diff...)
+	}
+
+	return diffs, nil
+}
+
+// getRoleRightNames returns the rights names of each role of adminOrg, indexed by role name
+func getRoleRightNames(ctx context.Context, adminOrg *AdminOrg) (map[string][]string, error) {
+	roles, err := adminOrg.GetAllOpenApiRoles(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving roles of organization '%s': %s", adminOrg.AdminOrg.Name, err)
+	}
+
+	result := make(map[string][]string)
+	for _, role := range roles {
+		rights, err := role.GetRights(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving rights of role '%s': %s", role.Role.Name, err)
+		}
+		result[role.Role.Name] = rightNames(rights)
+	}
+	return result, nil
+}
+
+// diffRoles compares two sets of roles, given as role name to right names. Only roles which differ
+// are returned, sorted by name.
+func diffRoles(first, second map[string][]string) []RoleDiff {
+	names := make(map[string]bool)
+	for name := range first {
+		names[name] = true
+	}
+	for name := range second {
+		names[name] = true
+	}
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	var diffs []RoleDiff
+	for _, name := range sortedNames {
+		firstRights, inFirst := first[name]
+		secondRights, inSecond := second[name]
+		diff := RoleDiff{
+			Name:            name,
+			MissingInFirst:  !inFirst,
+			MissingInSecond: !inSecond,
+		}
+		if inFirst && inSecond {
+			diff.RightsAdded = stringsNotIn(secondRights, firstRights)
+			diff.RightsRemoved = stringsNotIn(firstRights, secondRights)
+		}
+		if !diff.IsEmpty() {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+// stringsNotIn returns the sorted items of list which are not in other
+func stringsNotIn(list, other []string) []string {
+	var result []string
+	for _, item := range list {
+		if !stringInSlice(item, other) {
+			result = append(result, item)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// isPublishedTo returns true when the global role is published to all tenants or tenants contains
+// the organization with the given ID
+func isPublishedTo(globalRole *types.GlobalRole, tenants []types.OpenApiReference, orgId string) bool {
+	if globalRole.PublishAll != nil && *globalRole.PublishAll {
+		return true
+	}
+	for _, tenant := range tenants {
+		if tenant.ID == orgId {
+			return true
+		}
+	}
+	return false
+}
+
+// rightNames returns the names of the given rights
+func rightNames(rights []*types.Right) []string {
+	names := make([]string, len(rights))
+	for i, right := range rights {
+		names[i] = right.Name
+	}
+	return names
+}
+
+// rightsToReferences converts rights into the references used to grant them
+func rightsToReferences(rights []*types.Right) []types.OpenApiReference {
+	references := make([]types.OpenApiReference, len(rights))
+	for i, right := range rights {
+		references[i] = types.OpenApiReference{Name: right.Name, ID: right.ID}
+	}
+	return references
+}
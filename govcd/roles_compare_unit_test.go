@@ -0,0 +1,86 @@
+// +build unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_diffRoles(t *testing.T) {
+	reference := map[string][]string{
+		"vApp Author":       {"vApp: Create / Reconfigure a vApp", "vApp: Power Operations", "Catalog: View Private and Shared Catalogs"},
+		"Console Access":    {"vApp: VM Console"},
+		"Catalog Author":    {"Catalog: Add vApp from My Cloud"},
+		"Organization Role": {},
+	}
+	tests := []struct {
+		name   string
+		second map[string][]string
+		want   []RoleDiff
+	}{
+		{name: "Identical", second: map[string][]string{
+			"vApp Author":       {"Catalog: View Private and Shared Catalogs", "vApp: Power Operations", "vApp: Create / Reconfigure a vApp"},
+			"Console Access":    {"vApp: VM Console"},
+			"Catalog Author":    {"Catalog: Add vApp from My Cloud"},
+			"Organization Role": {},
+		}},
+		{name: "Drifted", second: map[string][]string{
+			"vApp Author":       {"vApp: Power Operations", "vApp: Delete a vApp", "vApp: Copy a vApp"},
+			"Console Access":    {"vApp: VM Console"},
+			"Organization Role": {},
+			"Auditor":           {"Organization: View"},
+		}, want: []RoleDiff{
+			{Name: "Auditor", MissingInFirst: true},
+			{Name: "Catalog Author", MissingInSecond: true},
+			{Name: "vApp Author",
+				RightsAdded:   []string{"vApp: Copy a vApp", "vApp: Delete a vApp"},
+				RightsRemoved: []string{"Catalog: View Private and Shared Catalogs", "vApp: Create / Reconfigure a vApp"}},
+		}},
+		{name: "Empty", second: map[string][]string{}, want: []RoleDiff{
+			{Name: "Catalog Author", MissingInSecond: true},
+			{Name: "Console Access", MissingInSecond: true},
+			{Name: "Organization Role", MissingInSecond: true},
+			{Name: "vApp Author", MissingInSecond: true},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffRoles(reference, tt.second)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffRoles() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isPublishedTo(t *testing.T) {
+	orgId := "urn:vcloud:org:11111111-1111-1111-1111-111111111111"
+	otherOrg := types.OpenApiReference{Name: "other", ID: "urn:vcloud:org:22222222-2222-2222-2222-222222222222"}
+	tests := []struct {
+		name       string
+		globalRole *types.GlobalRole
+		tenants    []types.OpenApiReference
+		want       bool
+	}{
+		{name: "PublishAll", globalRole: &types.GlobalRole{PublishAll: takeBoolPointer(true)}, want: true},
+		{name: "PublishedToOrg", globalRole: &types.GlobalRole{PublishAll: takeBoolPointer(false)},
+			tenants: []types.OpenApiReference{otherOrg, {Name: "org", ID: orgId}}, want: true},
+		{name: "PublishedToOthers", globalRole: &types.GlobalRole{},
+			tenants: []types.OpenApiReference{otherOrg}, want: false},
+		{name: "NotPublished", globalRole: &types.GlobalRole{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPublishedTo(tt.globalRole, tt.tenants, orgId); got != tt.want {
+				t.Errorf("isPublishedTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@
 package govcd
 
 import (
+	"fmt"
 	"net/url"
 
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
@@ -79,3 +80,118 @@ func (vcd *TestVCD) Test_Roles(check *C) {
 	check.Assert(ContainsNotFound(err), Equals, true)
 	check.Assert(deletedRole, IsNil)
 }
+
+func (vcd *TestVCD) Test_RoleRights(check *C) {
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.org.Org.Name)
+	check.Assert(err, IsNil)
+
+	role, err := adminOrg.CreateRole(ctx, &types.Role{
+		Name:        check.TestName(),
+		Description: "Role created by test",
+		BundleKey:   "com.vmware.vcloud.undefined.key",
+	})
+	check.Assert(err, IsNil)
+	AddToCleanupListOpenApi(role.Role.Name, check.TestName(), types.OpenApiPathVersion1_0_0+
+		types.OpenApiEndpointRoles+role.Role.ID)
+
+	right, err := vcd.client.GetRightByName(ctx, "Catalog: View Private and Shared Catalogs")
+	check.Assert(err, IsNil)
+	category, err := vcd.client.GetRightsCategoryById(ctx, right.Category)
+	check.Assert(err, IsNil)
+	check.Assert(category.ID, Equals, right.Category)
+
+	rightRef := types.OpenApiReference{Name: right.Name, ID: right.ID}
+	err = role.AddRights(ctx, []types.OpenApiReference{rightRef})
+	check.Assert(err, IsNil)
+	roleRights, err := role.GetRights(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(roleRights), Equals, 1)
+	check.Assert(roleRights[0].ID, Equals, right.ID)
+
+	err = role.RemoveRights(ctx, []types.OpenApiReference{rightRef})
+	check.Assert(err, IsNil)
+	roleRights, err = role.GetRights(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(roleRights), Equals, 0)
+
+	// Removing a right which is not granted fails
+	err = role.RemoveRights(ctx, []types.OpenApiReference{rightRef})
+	check.Assert(ContainsNotFound(err), Equals, true)
+
+	err = role.SetRights(ctx, []types.OpenApiReference{rightRef})
+	check.Assert(err, IsNil)
+	roleRights, err = role.GetRights(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(roleRights), Equals, 1)
+
+	err = role.Delete(ctx)
+	check.Assert(err, IsNil)
+}
+
+func (vcd *TestVCD) Test_GlobalRoles(check *C) {
+	if vcd.skipAdminTests {
+		check.Skip(fmt.Sprintf(TestRequiresSysAdminPrivileges, check.TestName()))
+	}
+
+	newGlobalRole := &types.GlobalRole{
+		Name:        check.TestName(),
+		Description: "Global role created by test",
+	}
+
+	globalRole, err := vcd.client.CreateGlobalRole(ctx, newGlobalRole)
+	check.Assert(err, IsNil)
+	check.Assert(globalRole.GlobalRole.ID, Not(Equals), "")
+	AddToCleanupListOpenApi(globalRole.GlobalRole.Name, check.TestName(), types.OpenApiPathVersion1_0_0+
+		types.OpenApiEndpointGlobalRoles+globalRole.GlobalRole.ID)
+
+	foundGlobalRole, err := vcd.client.GetGlobalRoleByName(ctx, newGlobalRole.Name)
+	check.Assert(err, IsNil)
+	check.Assert(foundGlobalRole.GlobalRole.ID, Equals, globalRole.GlobalRole.ID)
+
+	right, err := vcd.client.GetRightByName(ctx, "Catalog: View Private and Shared Catalogs")
+	check.Assert(err, IsNil)
+	err = globalRole.AddRights(ctx, []types.OpenApiReference{{Name: right.Name, ID: right.ID}})
+	check.Assert(err, IsNil)
+	globalRoleRights, err := globalRole.GetRights(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(globalRoleRights), Equals, 1)
+
+	// Publish to the test organization and then remove it again
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.org.Org.Name)
+	check.Assert(err, IsNil)
+	orgRef := types.OpenApiReference{Name: adminOrg.AdminOrg.Name, ID: adminOrg.AdminOrg.ID}
+	err = globalRole.PublishTenants(ctx, []types.OpenApiReference{orgRef})
+	check.Assert(err, IsNil)
+	tenants, err := globalRole.GetTenants(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(tenants), Equals, 1)
+	check.Assert(tenants[0].ID, Equals, orgRef.ID)
+
+	err = globalRole.UnpublishTenants(ctx, []types.OpenApiReference{orgRef})
+	check.Assert(err, IsNil)
+	tenants, err = globalRole.GetTenants(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(tenants), Equals, 0)
+
+	err = globalRole.Delete(ctx)
+	check.Assert(err, IsNil)
+	_, err = vcd.client.GetGlobalRoleByName(ctx, newGlobalRole.Name)
+	check.Assert(ContainsNotFound(err), Equals, true)
+}
+
+func (vcd *TestVCD) Test_CompareRoles(check *C) {
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.org.Org.Name)
+	check.Assert(err, IsNil)
+
+	// An organization compared with itself has no differences
+	diffs, err := CompareRoles(ctx, adminOrg, adminOrg)
+	check.Assert(err, IsNil)
+	check.Assert(len(diffs), Equals, 0)
+
+	// Cloning into the same organization fails because the role already exists
+	roles, err := adminOrg.GetAllOpenApiRoles(ctx, nil)
+	check.Assert(err, IsNil)
+	check.Assert(len(roles) > 0, Equals, true)
+	_, err = CloneRole(ctx, adminOrg, adminOrg, roles[0].Role.Name)
+	check.Assert(err, NotNil)
+}
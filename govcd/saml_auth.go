@@ -182,8 +182,7 @@ func getSamlAdfsServer(ctx context.Context, vcdCli *VCDClient, org string) (stri
 // Returns an error if Entity ID is empty
 // Sample response body can be found in saml_auth_unit_test.go
 func getSamlEntityId(ctx context.Context, vcdCli *VCDClient, org string) (string, error) {
-	url := vcdCli.Client.VCDHREF
-	samlMetadataUrl := url.Scheme + "://" + url.Host + "/cloud/org/" + org + "/saml/metadata/alias/vcd"
+	samlMetadataUrl := getSamlMetadataUrl(&vcdCli.Client, org)
 
 	metadata := types.VcdSamlMetadata{}
 	errString := fmt.Sprintf("SAML - unable to load metadata from URL %s: %%s", samlMetadataUrl)
@@ -202,6 +201,12 @@ func getSamlEntityId(ctx context.Context, vcdCli *VCDClient, org string) (string
 	return samlEntityId, nil
 }
 
+// getSamlMetadataUrl returns the URL of the vCD hosted SAML metadata of an Org
+func getSamlMetadataUrl(client *Client, org string) string {
+	url := client.VCDHREF
+	return url.Scheme + "://" + url.Host + "/cloud/org/" + org + "/saml/metadata/alias/vcd"
+}
+
 // getSamlAuthToken generates a token request payload using function
 // getSamlTokenRequestBody. This request is submitted to ADFS server endpoint
 // "/adfs/services/trust/13/usernamemixed" and `RequestedSecurityTokenTxt` is expected in response
@@ -0,0 +1,351 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// PrincipalType defines the kind of subject an entity can be shared with
+type PrincipalType string
+
+const (
+	PrincipalTypeUser     PrincipalType = "user"
+	PrincipalTypeGroup    PrincipalType = "group"
+	PrincipalTypeEveryone PrincipalType = "everyone" // all the members of the organization
+)
+
+// Principal is a user, group or the whole organization, identified by name, which can be given
+// access to an entity
+type Principal struct {
+	Type PrincipalType
+	Name string
+}
+
+// User returns a Principal for the organization user with the given name
+func User(name string) Principal {
+	return Principal{Type: PrincipalTypeUser, Name: name}
+}
+
+// Group returns a Principal for the organization group with the given name
+func Group(name string) Principal {
+	return Principal{Type: PrincipalTypeGroup, Name: name}
+}
+
+// Everyone returns a Principal for all the members of the organization
+func Everyone() Principal {
+	return Principal{Type: PrincipalTypeEveryone}
+}
+
+// String returns a readable representation of the principal
+func (principal Principal) String() string {
+	if principal.Type == PrincipalTypeEveryone {
+		return string(PrincipalTypeEveryone)
+	}
+	return fmt.Sprintf("%s '%s'", principal.Type, principal.Name)
+}
+
+// SharingEntry is a principal with access to an entity, as returned by AdminOrg.ListSharing
+type SharingEntry struct {
+	Principal   Principal
+	Href        string // HREF of the user or group. Empty for PrincipalTypeEveryone
+	AccessLevel string // One of types.ControlAccessReadOnly, types.ControlAccessReadWrite, types.ControlAccessFullControl
+}
+
+// sharingTarget contains the data needed to change the access control of an entity
+type sharingTarget struct {
+	client     *Client
+	href       string
+	name       string
+	entityType string
+}
+
+// ShareWith gives accessLevel (one of types.ControlAccessReadOnly, types.ControlAccessReadWrite,
+// types.ControlAccessFullControl) on entity to the given principals. Users and groups are looked up
+// by name in adminOrg. The existing access settings of the entity are kept: principals which were
+// already given access get the new access level.
+// Supported entities are *VApp, *VAppTemplate, *Catalog, *AdminCatalog, *Vdc and *AdminVdc.
+// Sharing with Everyone() replaces all individual settings and can't be combined with other
+// principals.
+func (adminOrg *AdminOrg) ShareWith(ctx context.Context, entity interface{}, principals []Principal, accessLevel string) error {
+	if !stringInSlice(accessLevel, []string{types.ControlAccessReadOnly, types.ControlAccessReadWrite, types.ControlAccessFullControl}) {
+		return fmt.Errorf("invalid access level '%s'", accessLevel)
+	}
+	if len(principals) == 0 {
+		return fmt.Errorf("no principals given to share with")
+	}
+
+	target, err := getSharingTarget(entity)
+	if err != nil {
+		return err
+	}
+
+	subjects, everyone, err := adminOrg.resolvePrincipals(ctx, principals)
+	if err != nil {
+		return err
+	}
+
+	header, err := adminOrg.getSharingHeader(target)
+	if err != nil {
+		return err
+	}
+
+	accessControl, err := target.client.GetAccessControl(ctx, target.href, target.entityType, target.name, header)
+	if err != nil {
+		return err
+	}
+
+	accessControl, err = mergeAccessSettings(accessControl, subjects, everyone, accessLevel)
+	if err != nil {
+		return fmt.Errorf("error sharing %s '%s': %s", target.entityType, target.name, err)
+	}
+
+	return target.client.SetAccessControl(ctx, accessControl, target.href, target.entityType, target.name, header)
+}
+
+// Unshare removes the access to entity of the given principals, keeping the other access settings.
+// Principals which have no access are ignored.
+// Supported entities are *VApp, *VAppTemplate, *Catalog, *AdminCatalog, *Vdc and *AdminVdc.
+func (adminOrg *AdminOrg) Unshare(ctx context.Context, entity interface{}, principals []Principal) error {
+	target, err := getSharingTarget(entity)
+	if err != nil {
+		return err
+	}
+
+	subjects, everyone, err := adminOrg.resolvePrincipals(ctx, principals)
+	if err != nil {
+		return err
+	}
+
+	header, err := adminOrg.getSharingHeader(target)
+	if err != nil {
+		return err
+	}
+
+	accessControl, err := target.client.GetAccessControl(ctx, target.href, target.entityType, target.name, header)
+	if err != nil {
+		return err
+	}
+
+	accessControl = removeAccessSettings(accessControl, subjects, everyone)
+
+	return target.client.SetAccessControl(ctx, accessControl, target.href, target.entityType, target.name, header)
+}
+
+// ListSharing returns the principals which have access to entity, with their access level
+// Supported entities are *VApp, *VAppTemplate, *Catalog, *AdminCatalog, *Vdc and *AdminVdc.
+func (adminOrg *AdminOrg) ListSharing(ctx context.Context, entity interface{}) ([]SharingEntry, error) {
+	target, err := getSharingTarget(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := adminOrg.getSharingHeader(target)
+	if err != nil {
+		return nil, err
+	}
+
+	accessControl, err := target.client.GetAccessControl(ctx, target.href, target.entityType, target.name, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return sharingEntries(accessControl), nil
+}
+
+// getSharingTarget extracts the data needed to manage the access control of a supported entity. It
+// fails if the entity doesn't have a controlAccess link.
+func getSharingTarget(entity interface{}) (*sharingTarget, error) {
+	var target sharingTarget
+	var links types.LinkList
+
+	switch e := entity.(type) {
+	case *VApp:
+		target = sharingTarget{client: e.client, href: e.VApp.HREF, name: e.VApp.Name, entityType: "vApp"}
+		links = e.VApp.Link
+	case *VAppTemplate:
+		target = sharingTarget{client: e.client, href: e.VAppTemplate.HREF, name: e.VAppTemplate.Name, entityType: "vApp template"}
+		links = e.VAppTemplate.Link
+	case *Catalog:
+		target = sharingTarget{client: e.client, href: e.Catalog.HREF, name: e.Catalog.Name, entityType: "catalog"}
+		links = e.Catalog.Link
+	case *AdminCatalog:
+		target = sharingTarget{client: e.client, href: e.AdminCatalog.HREF, name: e.AdminCatalog.Name, entityType: "catalog"}
+		links = e.AdminCatalog.Link
+	case *Vdc:
+		target = sharingTarget{client: e.client, href: e.Vdc.HREF, name: e.Vdc.Name, entityType: "VDC"}
+		links = e.Vdc.Link
+	case *AdminVdc:
+		target = sharingTarget{client: e.client, href: e.AdminVdc.HREF, name: e.AdminVdc.Name, entityType: "VDC"}
+		links = e.AdminVdc.Link
+	default:
+		return nil, fmt.Errorf("sharing is not supported for entity of type %T", entity)
+	}
+
+	if target.href == "" {
+		return nil, fmt.Errorf("%s HREF is empty", target.entityType)
+	}
+	if !hasControlAccessLink(links) {
+		return nil, fmt.Errorf("%s '%s' has no controlAccess link", target.entityType, target.name)
+	}
+	// Access control is only available through the non-admin URL
+	target.href = strings.Replace(target.href, "/admin/", "/", 1)
+	return &target, nil
+}
+
+// hasControlAccessLink returns true when the links contain an access control operation
+func hasControlAccessLink(links types.LinkList) bool {
+	for _, link := range links {
+		if link.Type == types.MimeControlAccess || strings.Contains(link.HREF, "/controlAccess") {
+			return true
+		}
+	}
+	return false
+}
+
+// getSharingHeader returns the tenant context of adminOrg when the client is a system
+// administrator, as users and groups can only be used as subjects within their organization
+func (adminOrg *AdminOrg) getSharingHeader(target *sharingTarget) (map[string]string, error) {
+	if !target.client.IsSysAdmin {
+		return map[string]string{}, nil
+	}
+	orgId, err := GetUuidFromHref(adminOrg.AdminOrg.HREF, true)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{types.HeaderTenantContext: orgId, types.HeaderAuthContext: adminOrg.AdminOrg.Name}, nil
+}
+
+// resolvePrincipals converts users and groups into subjects, using the users and groups of
+// adminOrg. The second result is true when Everyone() is among the principals.
+func (adminOrg *AdminOrg) resolvePrincipals(ctx context.Context, principals []Principal) ([]*types.LocalSubject, bool, error) {
+	var subjects []*types.LocalSubject
+	everyone := false
+	for _, principal := range principals {
+		switch principal.Type {
+		case PrincipalTypeUser:
+			user, err := adminOrg.GetUserByName(ctx, principal.Name, false)
+			if err != nil {
+				return nil, false, fmt.Errorf("error retrieving %s: %s", principal, err)
+			}
+			subjects = append(subjects, &types.LocalSubject{HREF: user.User.Href, Name: user.User.Name, Type: types.MimeAdminUser})
+		case PrincipalTypeGroup:
+			group, err := adminOrg.GetGroupByName(ctx, principal.Name, false)
+			if err != nil {
+				return nil, false, fmt.Errorf("error retrieving %s: %s", principal, err)
+			}
+			subjects = append(subjects, &types.LocalSubject{HREF: group.Group.Href, Name: group.Group.Name, Type: types.MimeAdminGroup})
+		case PrincipalTypeEveryone:
+			everyone = true
+		default:
+			return nil, false, fmt.Errorf("unknown principal type '%s'", principal.Type)
+		}
+	}
+	return subjects, everyone, nil
+}
+
+// mergeAccessSettings adds the subjects to the access settings of accessControl with the given
+// access level, or updates their access level if they are already present. When everyone is true,
+// the entity is shared with the whole organization instead.
+func mergeAccessSettings(accessControl *types.ControlAccessParams, subjects []*types.LocalSubject, everyone bool, accessLevel string) (*types.ControlAccessParams, error) {
+	if everyone {
+		if len(subjects) > 0 {
+			return nil, fmt.Errorf("can't share with everyone and with specific users or groups at the same time")
+		}
+		return &types.ControlAccessParams{IsSharedToEveryone: true, EveryoneAccessLevel: &accessLevel}, nil
+	}
+	if accessControl.IsSharedToEveryone {
+		return nil, fmt.Errorf("entity is shared with everyone: unshare it from everyone before sharing with specific users or groups")
+	}
+
+	result := &types.ControlAccessParams{AccessSettings: &types.AccessSettingList{}}
+	if accessControl.AccessSettings != nil {
+		for _, setting := range accessControl.AccessSettings.AccessSetting {
+			settingCopy := *setting
+			result.AccessSettings.AccessSetting = append(result.AccessSettings.AccessSetting, &settingCopy)
+		}
+	}
+	for _, subject := range subjects {
+		found := false
+		for _, setting := range result.AccessSettings.AccessSetting {
+			if setting.Subject != nil && setting.Subject.HREF == subject.HREF {
+				setting.AccessLevel = accessLevel
+				found = true
+				break
+			}
+		}
+		if !found {
+			result.AccessSettings.AccessSetting = append(result.AccessSettings.AccessSetting,
+				&types.AccessSetting{Subject: subject, AccessLevel: accessLevel})
+		}
+	}
+	return result, nil
+}
+
+// removeAccessSettings removes the subjects from the access settings of accessControl. When
+// everyone is true, the sharing with the whole organization is removed as well.
+func removeAccessSettings(accessControl *types.ControlAccessParams, subjects []*types.LocalSubject, everyone bool) *types.ControlAccessParams {
+	result := &types.ControlAccessParams{}
+	if accessControl.IsSharedToEveryone && !everyone {
+		result.IsSharedToEveryone = true
+		result.EveryoneAccessLevel = accessControl.EveryoneAccessLevel
+		return result
+	}
+	if accessControl.AccessSettings == nil {
+		return result
+	}
+
+	var remaining []*types.AccessSetting
+	for _, setting := range accessControl.AccessSettings.AccessSetting {
+		removed := false
+		for _, subject := range subjects {
+			if setting.Subject != nil && setting.Subject.HREF == subject.HREF {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			remaining = append(remaining, setting)
+		}
+	}
+	if len(remaining) > 0 {
+		result.AccessSettings = &types.AccessSettingList{AccessSetting: remaining}
+	}
+	return result
+}
+
+// sharingEntries converts access control settings into sharing entries
+func sharingEntries(accessControl *types.ControlAccessParams) []SharingEntry {
+	var entries []SharingEntry
+	if accessControl.IsSharedToEveryone {
+		entry := SharingEntry{Principal: Everyone()}
+		if accessControl.EveryoneAccessLevel != nil {
+			entry.AccessLevel = *accessControl.EveryoneAccessLevel
+		}
+		entries = append(entries, entry)
+	}
+	if accessControl.AccessSettings == nil {
+		return entries
+	}
+	for _, setting := range accessControl.AccessSettings.AccessSetting {
+		if setting.Subject == nil {
+			continue
+		}
+		principal := User(setting.Subject.Name)
+		if setting.Subject.Type == types.MimeAdminGroup || strings.Contains(setting.Subject.HREF, "/group/") {
+			principal = Group(setting.Subject.Name)
+		}
+		entries = append(entries, SharingEntry{
+			Principal:   principal,
+			Href:        setting.Subject.HREF,
+			AccessLevel: setting.AccessLevel,
+		})
+	}
+	return entries
+}
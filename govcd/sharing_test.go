@@ -0,0 +1,70 @@
+// +build functional catalog ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	. "gopkg.in/check.v1"
+)
+
+func (vcd *TestVCD) Test_CatalogSharing(check *C) {
+	if vcd.config.VCD.Org == "" {
+		check.Skip("Test_CatalogSharing: Org name not given.")
+		return
+	}
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.config.VCD.Org)
+	check.Assert(err, IsNil)
+
+	catalogName := "sharing-catalog"
+	adminCatalog, err := adminOrg.CreateCatalog(ctx, catalogName, catalogName)
+	check.Assert(err, IsNil)
+	AddToCleanupList(catalogName, "catalog", vcd.config.VCD.Org, check.TestName())
+
+	userNames := []string{"sharing-user1", "sharing-user2"}
+	for _, userName := range userNames {
+		user, err := adminOrg.CreateUserSimple(ctx, OrgUserConfiguration{
+			Name: userName, Password: userName, RoleName: OrgUserRoleVappAuthor, IsEnabled: true,
+		})
+		check.Assert(err, IsNil)
+		AddToCleanupList(userName, "user", vcd.config.VCD.Org, check.TestName())
+		defer func() {
+			err := user.Delete(ctx, false)
+			check.Assert(err, IsNil)
+		}()
+	}
+
+	// Sharing with the second user keeps the first one
+	err = adminOrg.ShareWith(ctx, &adminCatalog, []Principal{User(userNames[0])}, types.ControlAccessReadOnly)
+	check.Assert(err, IsNil)
+	err = adminOrg.ShareWith(ctx, &adminCatalog, []Principal{User(userNames[1])}, types.ControlAccessReadWrite)
+	check.Assert(err, IsNil)
+
+	entries, err := adminOrg.ListSharing(ctx, &adminCatalog)
+	check.Assert(err, IsNil)
+	check.Assert(len(entries), Equals, 2)
+	for _, entry := range entries {
+		check.Assert(entry.Principal.Type, Equals, PrincipalTypeUser)
+		check.Assert(stringInSlice(entry.Principal.Name, userNames), Equals, true)
+	}
+
+	err = adminOrg.Unshare(ctx, &adminCatalog, []Principal{User(userNames[0])})
+	check.Assert(err, IsNil)
+	entries, err = adminOrg.ListSharing(ctx, &adminCatalog)
+	check.Assert(err, IsNil)
+	check.Assert(len(entries), Equals, 1)
+	check.Assert(entries[0].Principal, Equals, User(userNames[1]))
+	check.Assert(entries[0].AccessLevel, Equals, types.ControlAccessReadWrite)
+
+	err = adminOrg.Unshare(ctx, &adminCatalog, []Principal{User(userNames[1])})
+	check.Assert(err, IsNil)
+	entries, err = adminOrg.ListSharing(ctx, &adminCatalog)
+	check.Assert(err, IsNil)
+	check.Assert(len(entries), Equals, 0)
+
+	err = adminCatalog.Delete(ctx, true, true)
+	check.Assert(err, IsNil)
+}
@@ -0,0 +1,151 @@
+// +build unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+var (
+	sharingTestUser  = &types.LocalSubject{HREF: "https://vcd.example.com/api/admin/user/1", Name: "alice", Type: types.MimeAdminUser}
+	sharingTestUser2 = &types.LocalSubject{HREF: "https://vcd.example.com/api/admin/user/2", Name: "bob", Type: types.MimeAdminUser}
+	sharingTestGroup = &types.LocalSubject{HREF: "https://vcd.example.com/api/admin/group/3", Name: "ops", Type: types.MimeAdminGroup}
+)
+
+func sharingTestParams(settings ...*types.AccessSetting) *types.ControlAccessParams {
+	params := &types.ControlAccessParams{}
+	if len(settings) > 0 {
+		params.AccessSettings = &types.AccessSettingList{AccessSetting: settings}
+	}
+	return params
+}
+
+func Test_mergeAccessSettings(t *testing.T) {
+	readOnly := types.ControlAccessReadOnly
+	tests := []struct {
+		name     string
+		current  *types.ControlAccessParams
+		subjects []*types.LocalSubject
+		everyone bool
+		level    string
+		want     *types.ControlAccessParams
+		wantErr  bool
+	}{
+		{name: "AddToEmpty", current: sharingTestParams(),
+			subjects: []*types.LocalSubject{sharingTestUser, sharingTestGroup}, level: types.ControlAccessReadWrite,
+			want: sharingTestParams(
+				&types.AccessSetting{Subject: sharingTestUser, AccessLevel: types.ControlAccessReadWrite},
+				&types.AccessSetting{Subject: sharingTestGroup, AccessLevel: types.ControlAccessReadWrite})},
+		{name: "MergeAndUpdate", current: sharingTestParams(
+			&types.AccessSetting{Subject: sharingTestUser, AccessLevel: types.ControlAccessReadOnly},
+			&types.AccessSetting{Subject: sharingTestUser2, AccessLevel: types.ControlAccessReadOnly}),
+			subjects: []*types.LocalSubject{sharingTestUser, sharingTestGroup}, level: types.ControlAccessFullControl,
+			want: sharingTestParams(
+				&types.AccessSetting{Subject: sharingTestUser, AccessLevel: types.ControlAccessFullControl},
+				&types.AccessSetting{Subject: sharingTestUser2, AccessLevel: types.ControlAccessReadOnly},
+				&types.AccessSetting{Subject: sharingTestGroup, AccessLevel: types.ControlAccessFullControl})},
+		{name: "Everyone", current: sharingTestParams(
+			&types.AccessSetting{Subject: sharingTestUser, AccessLevel: types.ControlAccessReadOnly}),
+			everyone: true, level: types.ControlAccessReadOnly,
+			want: &types.ControlAccessParams{IsSharedToEveryone: true, EveryoneAccessLevel: &readOnly}},
+		{name: "EveryoneAndUser", current: sharingTestParams(), everyone: true,
+			subjects: []*types.LocalSubject{sharingTestUser}, level: types.ControlAccessReadOnly, wantErr: true},
+		{name: "AlreadySharedWithEveryone", current: &types.ControlAccessParams{IsSharedToEveryone: true, EveryoneAccessLevel: &readOnly},
+			subjects: []*types.LocalSubject{sharingTestUser}, level: types.ControlAccessReadOnly, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeAccessSettings(tt.current, tt.subjects, tt.everyone, tt.level)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mergeAccessSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeAccessSettings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_removeAccessSettings(t *testing.T) {
+	readOnly := types.ControlAccessReadOnly
+	current := sharingTestParams(
+		&types.AccessSetting{Subject: sharingTestUser, AccessLevel: types.ControlAccessReadOnly},
+		&types.AccessSetting{Subject: sharingTestGroup, AccessLevel: types.ControlAccessReadWrite})
+	tests := []struct {
+		name     string
+		current  *types.ControlAccessParams
+		subjects []*types.LocalSubject
+		everyone bool
+		want     *types.ControlAccessParams
+	}{
+		{name: "RemoveOne", current: current, subjects: []*types.LocalSubject{sharingTestUser},
+			want: sharingTestParams(&types.AccessSetting{Subject: sharingTestGroup, AccessLevel: types.ControlAccessReadWrite})},
+		{name: "RemoveAll", current: current, subjects: []*types.LocalSubject{sharingTestGroup, sharingTestUser},
+			want: sharingTestParams()},
+		{name: "NotShared", current: current, subjects: []*types.LocalSubject{sharingTestUser2}, want: current},
+		{name: "KeepEveryone", current: &types.ControlAccessParams{IsSharedToEveryone: true, EveryoneAccessLevel: &readOnly},
+			subjects: []*types.LocalSubject{sharingTestUser},
+			want:     &types.ControlAccessParams{IsSharedToEveryone: true, EveryoneAccessLevel: &readOnly}},
+		{name: "RemoveEveryone", current: &types.ControlAccessParams{IsSharedToEveryone: true, EveryoneAccessLevel: &readOnly},
+			everyone: true, want: sharingTestParams()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := removeAccessSettings(tt.current, tt.subjects, tt.everyone)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("removeAccessSettings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sharingEntries(t *testing.T) {
+	fullControl := types.ControlAccessFullControl
+	got := sharingEntries(sharingTestParams(
+		&types.AccessSetting{Subject: sharingTestUser, AccessLevel: types.ControlAccessReadOnly},
+		&types.AccessSetting{Subject: sharingTestGroup, AccessLevel: types.ControlAccessReadWrite}))
+	want := []SharingEntry{
+		{Principal: User("alice"), Href: sharingTestUser.HREF, AccessLevel: types.ControlAccessReadOnly},
+		{Principal: Group("ops"), Href: sharingTestGroup.HREF, AccessLevel: types.ControlAccessReadWrite},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sharingEntries() = %+v, want %+v", got, want)
+	}
+
+	got = sharingEntries(&types.ControlAccessParams{IsSharedToEveryone: true, EveryoneAccessLevel: &fullControl})
+	want = []SharingEntry{{Principal: Everyone(), AccessLevel: types.ControlAccessFullControl}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sharingEntries() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_getSharingTarget(t *testing.T) {
+	controlAccessLink := &types.Link{Rel: "down", Type: types.MimeControlAccess,
+		HREF: "https://vcd.example.com/api/catalog/1/controlAccess/"}
+
+	adminCatalog := &AdminCatalog{AdminCatalog: &types.AdminCatalog{Catalog: types.Catalog{
+		HREF: "https://vcd.example.com/api/admin/catalog/1", Name: "cat", Link: types.LinkList{controlAccessLink}}}}
+	target, err := getSharingTarget(adminCatalog)
+	if err != nil {
+		t.Fatalf("getSharingTarget() unexpected error: %s", err)
+	}
+	if target.href != "https://vcd.example.com/api/catalog/1" {
+		t.Errorf("getSharingTarget() href = %s, want non-admin catalog HREF", target.href)
+	}
+
+	vdcWithoutLink := &Vdc{Vdc: &types.Vdc{HREF: "https://vcd.example.com/api/vdc/1", Name: "vdc"}}
+	if _, err = getSharingTarget(vdcWithoutLink); err == nil {
+		t.Errorf("getSharingTarget() expected error for entity without controlAccess link")
+	}
+
+	if _, err = getSharingTarget(&VM{}); err == nil {
+		t.Errorf("getSharingTarget() expected error for unsupported entity")
+	}
+}
@@ -0,0 +1,245 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+	"github.com/vmware/go-vcloud-director/v2/util"
+)
+
+// maxConcurrentUserImports is the maximum number of users created at the same time by
+// AdminOrg.ImportUsers
+const maxConcurrentUserImports = 5
+
+// Issues reported by AdminOrg.UserReport
+const (
+	UserIssueLocked              = "locked"
+	UserIssueDisabled            = "disabled"
+	UserIssueStoredVmQuotaFull   = "stored VM quota reached"
+	UserIssueDeployedVmQuotaFull = "deployed VM quota reached"
+)
+
+// UserImportResult is the outcome of the creation of one user by AdminOrg.ImportUsers
+type UserImportResult struct {
+	Name  string
+	User  *OrgUser // The created user. Nil if the creation failed
+	Error error
+}
+
+// UserReportEntry describes a user which needs attention, as returned by AdminOrg.UserReport
+type UserReportEntry struct {
+	Name            string
+	Href            string
+	IsLocked        bool
+	IsEnabled       bool
+	StoredVms       int // Number of VMs owned by the user
+	DeployedVms     int // Number of deployed VMs owned by the user
+	StoredVmQuota   int // 0 means "unlimited"
+	DeployedVmQuota int // 0 means "unlimited"
+	Issues          []string
+}
+
+// ImportUsers creates the given users concurrently, using CreateUserSimple. It returns one result
+// per user, in the same order as the input, and an error summarising the failures if any user
+// could not be created.
+func (adminOrg *AdminOrg) ImportUsers(ctx context.Context, users []OrgUserConfiguration) ([]UserImportResult, error) {
+	results := make([]UserImportResult, len(users))
+	semaphore := make(chan struct{}, maxConcurrentUserImports)
+	var wg sync.WaitGroup
+
+	for i := range users {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// CreateUserSimple refreshes the organization while waiting for the user to be
+			// available. Each creation gets its own copy so that the refreshes don't race
+			workerOrg := &AdminOrg{AdminOrg: adminOrg.AdminOrg, client: adminOrg.client}
+			user, err := workerOrg.CreateUserSimple(ctx, users[i])
+			if user != nil {
+				user.AdminOrg = adminOrg
+			}
+			results[i] = UserImportResult{Name: users[i].Name, User: user, Error: err}
+		}(i)
+	}
+	wg.Wait()
+
+	var failures []string
+	for _, result := range results {
+		if result.Error != nil {
+			util.Logger.Printf("[TRACE] error importing user %s: %s", result.Name, result.Error)
+			failures = append(failures, fmt.Sprintf("%s: %s", result.Name, result.Error))
+		}
+	}
+
+	err := adminOrg.Refresh(ctx)
+	if err != nil {
+		return results, err
+	}
+
+	if len(failures) > 0 {
+		return results, fmt.Errorf("%d of %d users could not be imported: %s", len(failures), len(users),
+			strings.Join(failures, "; "))
+	}
+	return results, nil
+}
+
+// ImportGroupsFromLdap creates groups for the given LDAP group names, assigning them the role
+// roleName. Groups which already exist get their role updated.
+// The organization must be configured to use LDAP.
+func (adminOrg *AdminOrg) ImportGroupsFromLdap(ctx context.Context, groupNames []string, roleName string) ([]*OrgGroup, error) {
+	return adminOrg.importGroups(ctx, groupNames, roleName, OrgUserProviderIntegrated)
+}
+
+// ImportGroupsFromSaml creates groups for the given SAML group names, assigning them the role
+// roleName. Groups which already exist get their role updated.
+// The organization must be configured to use SAML.
+func (adminOrg *AdminOrg) ImportGroupsFromSaml(ctx context.Context, groupNames []string, roleName string) ([]*OrgGroup, error) {
+	return adminOrg.importGroups(ctx, groupNames, roleName, OrgUserProviderSAML)
+}
+
+// importGroups creates or updates groups from an identity provider
+func (adminOrg *AdminOrg) importGroups(ctx context.Context, groupNames []string, roleName, providerType string) ([]*OrgGroup, error) {
+	role, err := adminOrg.GetRoleReference(roleName)
+	if err != nil {
+		return nil, fmt.Errorf("error finding a role named %s", roleName)
+	}
+
+	err = adminOrg.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []*OrgGroup
+	for _, name := range groupNames {
+		var group *OrgGroup
+		if adminOrg.AdminOrg.Groups != nil {
+			group, err = adminOrg.GetGroupByName(ctx, name, false)
+			if err != nil && !ContainsNotFound(err) {
+				return groups, err
+			}
+		}
+
+		if group != nil {
+			if group.Group.Role == nil || group.Group.Role.HREF != role.HREF {
+				group.Group.Role = &types.Reference{HREF: role.HREF}
+				err = group.Update(ctx)
+				if err != nil {
+					return groups, fmt.Errorf("error updating role of group %s: %s", name, err)
+				}
+			}
+			groups = append(groups, group)
+			continue
+		}
+
+		group, err = adminOrg.CreateGroup(ctx, &types.Group{
+			Name:         name,
+			ProviderType: providerType,
+			Role:         &types.Reference{HREF: role.HREF},
+		})
+		if err != nil {
+			return groups, fmt.Errorf("error importing group %s: %s", name, err)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, adminOrg.Refresh(ctx)
+}
+
+// UserReport lists the users of the organization which are locked, disabled or have reached their
+// stored or deployed VM quota. VMs are counted within the VDCs of the organization.
+func (adminOrg *AdminOrg) UserReport(ctx context.Context) ([]UserReportEntry, error) {
+	err := adminOrg.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*types.User
+	if adminOrg.AdminOrg.Users != nil {
+		for _, userRef := range adminOrg.AdminOrg.Users.User {
+			user, err := adminOrg.GetUserByHref(ctx, userRef.HREF)
+			if err != nil {
+				return nil, err
+			}
+			users = append(users, user.User)
+		}
+	}
+
+	vms, err := adminOrg.client.QueryVmList(ctx, types.VmQueryFilterOnlyDeployed)
+	if err != nil {
+		return nil, err
+	}
+
+	var vdcIds []string
+	if adminOrg.AdminOrg.Vdcs != nil {
+		for _, vdc := range adminOrg.AdminOrg.Vdcs.Vdcs {
+			vdcIds = append(vdcIds, extractUuid(vdc.HREF))
+		}
+	}
+	var orgVms []*types.QueryResultVMRecordType
+	for _, vm := range vms {
+		if stringInSlice(extractUuid(vm.VdcHREF), vdcIds) {
+			orgVms = append(orgVms, vm)
+		}
+	}
+
+	return buildUserReport(users, orgVms), nil
+}
+
+// buildUserReport returns the users which need attention, sorted by name. vms are the VMs of the
+// organization, excluding those in vApp templates.
+func buildUserReport(users []*types.User, vms []*types.QueryResultVMRecordType) []UserReportEntry {
+	storedVms := make(map[string]int)
+	deployedVms := make(map[string]int)
+	for _, vm := range vms {
+		if vm.VAppTemplate {
+			continue
+		}
+		storedVms[vm.OwnerName]++
+		if vm.Deployed {
+			deployedVms[vm.OwnerName]++
+		}
+	}
+
+	var entries []UserReportEntry
+	for _, user := range users {
+		entry := UserReportEntry{
+			Name:            user.Name,
+			Href:            user.Href,
+			IsLocked:        user.IsLocked,
+			IsEnabled:       user.IsEnabled,
+			StoredVms:       storedVms[user.Name],
+			DeployedVms:     deployedVms[user.Name],
+			StoredVmQuota:   user.StoredVmQuota,
+			DeployedVmQuota: user.DeployedVmQuota,
+		}
+		if entry.IsLocked {
+			entry.Issues = append(entry.Issues, UserIssueLocked)
+		}
+		if !entry.IsEnabled {
+			entry.Issues = append(entry.Issues, UserIssueDisabled)
+		}
+		if entry.StoredVmQuota > 0 && entry.StoredVms >= entry.StoredVmQuota {
+			entry.Issues = append(entry.Issues, UserIssueStoredVmQuotaFull)
+		}
+		if entry.DeployedVmQuota > 0 && entry.DeployedVms >= entry.DeployedVmQuota {
+			entry.Issues = append(entry.Issues, UserIssueDeployedVmQuotaFull)
+		}
+		if len(entry.Issues) > 0 {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
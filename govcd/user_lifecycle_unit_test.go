@@ -0,0 +1,54 @@
+// +build unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_buildUserReport(t *testing.T) {
+	users := []*types.User{
+		{Name: "zoe", Href: "https://vcd.example.com/api/admin/user/1", IsEnabled: true},
+		{Name: "locked", Href: "https://vcd.example.com/api/admin/user/2", IsEnabled: true, IsLocked: true},
+		{Name: "disabled", Href: "https://vcd.example.com/api/admin/user/3"},
+		{Name: "full", Href: "https://vcd.example.com/api/admin/user/4", IsEnabled: true, StoredVmQuota: 2, DeployedVmQuota: 1},
+		{Name: "unlimited", Href: "https://vcd.example.com/api/admin/user/5", IsEnabled: true},
+		{Name: "deployed", Href: "https://vcd.example.com/api/admin/user/6", IsEnabled: true, StoredVmQuota: 5, DeployedVmQuota: 2},
+	}
+	vms := []*types.QueryResultVMRecordType{
+		{Name: "vm1", OwnerName: "full", Deployed: true},
+		{Name: "vm2", OwnerName: "full"},
+		{Name: "vm3", OwnerName: "unlimited", Deployed: true},
+		{Name: "vm4", OwnerName: "unlimited", Deployed: true},
+		{Name: "vm5", OwnerName: "deployed", Deployed: true},
+		{Name: "vm6", OwnerName: "deployed", Deployed: true},
+		{Name: "vm7", OwnerName: "deployed"},
+		{Name: "template-vm", OwnerName: "zoe", VAppTemplate: true},
+	}
+
+	want := []UserReportEntry{
+		{Name: "deployed", Href: "https://vcd.example.com/api/admin/user/6", IsEnabled: true, StoredVms: 3, DeployedVms: 2,
+			StoredVmQuota: 5, DeployedVmQuota: 2, Issues: []string{UserIssueDeployedVmQuotaFull}},
+		{Name: "disabled", Href: "https://vcd.example.com/api/admin/user/3", Issues: []string{UserIssueDisabled}},
+		{Name: "full", Href: "https://vcd.example.com/api/admin/user/4", IsEnabled: true, StoredVms: 2, DeployedVms: 1,
+			StoredVmQuota: 2, DeployedVmQuota: 1, Issues: []string{UserIssueStoredVmQuotaFull, UserIssueDeployedVmQuotaFull}},
+		{Name: "locked", Href: "https://vcd.example.com/api/admin/user/2", IsEnabled: true, IsLocked: true,
+			Issues: []string{UserIssueLocked}},
+	}
+
+	got := buildUserReport(users, vms)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildUserReport() = %+v, want %+v", got, want)
+	}
+
+	if got := buildUserReport(nil, nil); len(got) != 0 {
+		t.Errorf("buildUserReport() with no users = %+v, want empty", got)
+	}
+}
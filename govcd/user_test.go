@@ -222,6 +222,54 @@ func (vcd *TestVCD) Test_UserCRUD(check *C) {
 	}
 }
 
+func (vcd *TestVCD) Test_ImportUsers(check *C) {
+	adminOrg, err := vcd.client.GetAdminOrgByName(ctx, vcd.org.Org.Name)
+	check.Assert(err, IsNil)
+
+	var usersData []OrgUserConfiguration
+	for i := 1; i <= 3; i++ {
+		name := fmt.Sprintf("test_import_user%d", i)
+		usersData = append(usersData, OrgUserConfiguration{
+			Name: name, Password: name, RoleName: OrgUserRoleVappUser, IsEnabled: i != 3, StoredVmQuota: 1,
+		})
+		AddToCleanupList(name, "user", vcd.org.Org.Name, check.TestName())
+	}
+	// A user without a role can't be created
+	usersData = append(usersData, OrgUserConfiguration{Name: "test_import_invalid", Password: "test_import_invalid"})
+
+	results, err := adminOrg.ImportUsers(ctx, usersData)
+	check.Assert(err, NotNil)
+	check.Assert(len(results), Equals, len(usersData))
+	for i, result := range results {
+		check.Assert(result.Name, Equals, usersData[i].Name)
+		if i < 3 {
+			check.Assert(result.Error, IsNil)
+			check.Assert(result.User, NotNil)
+			check.Assert(result.User.User.Name, Equals, usersData[i].Name)
+		} else {
+			check.Assert(result.Error, NotNil)
+			check.Assert(result.User, IsNil)
+		}
+	}
+
+	// The disabled user is in the report
+	report, err := adminOrg.UserReport(ctx)
+	check.Assert(err, IsNil)
+	found := false
+	for _, entry := range report {
+		if entry.Name == usersData[2].Name {
+			found = true
+			check.Assert(entry.Issues, DeepEquals, []string{UserIssueDisabled})
+		}
+	}
+	check.Assert(found, Equals, true)
+
+	for _, result := range results[:3] {
+		err = result.User.Delete(ctx, false)
+		check.Assert(err, IsNil)
+	}
+}
+
 func init() {
 	testingTags["user"] = "user_test.go"
 }
@@ -0,0 +1,325 @@
+package govcd
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// CloneVApp copies the source vApp into this VDC with a new name. The source vApp can reside in
+// another VDC of the same Org.
+//
+// targetNetworks maps source vApp network names to Org VDC networks of this VDC. Each listed vApp
+// network keeps its name but is re-attached to the given parent network. vApp networks which are
+// not listed are copied as they are, which only works when the target VDC has access to their
+// parent networks. storageProfile is optional and its name is set as the default storage profile
+// of the new vApp. linkedClone requests VMs to be created as linked clones (fast provisioning).
+func (vdc *Vdc) CloneVApp(ctx context.Context, source *VApp, name string, targetNetworks map[string]*types.OrgVDCNetwork,
+	storageProfile *types.Reference, linkedClone bool) (*VApp, error) {
+	if source == nil || source.VApp == nil || source.VApp.HREF == "" {
+		return nil, fmt.Errorf("source vApp must be provided")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("name of the new vApp must be provided")
+	}
+
+	sourceNetworkConfig, err := source.GetNetworkConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving network configuration of vApp %s: %s", source.VApp.Name, err)
+	}
+	networkConfig, err := remapVAppNetworkConfig(sourceNetworkConfig, targetNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	cloneParams := &types.CloneVAppParams{
+		Ovf:         types.XMLNamespaceOVF,
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        name,
+		LinkedClone: linkedClone,
+		Description: source.VApp.Description,
+		InstantiationParams: &types.InstantiationParams{
+			NetworkConfigSection: networkConfig,
+		},
+		Source: &types.Reference{HREF: source.VApp.HREF},
+	}
+	if storageProfile != nil && storageProfile.Name != "" {
+		cloneParams.InstantiationParams.DefaultStorageProfileSection = &types.DefaultStorageProfileSection{
+			StorageProfile: storageProfile.Name,
+		}
+	}
+
+	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
+	if err != nil {
+		return nil, fmt.Errorf("error getting vdc href: %s", err)
+	}
+	vdcHref.Path += "/action/cloneVApp"
+
+	clonedVApp := &types.VApp{}
+	_, err = vdc.client.ExecuteRequest(ctx, vdcHref.String(), http.MethodPost,
+		types.MimeCloneVAppParams, "error cloning vApp: %s", cloneParams, clonedVApp)
+	if err != nil {
+		return nil, err
+	}
+
+	err = waitVAppTasks(ctx, vdc.client, clonedVApp)
+	if err != nil {
+		return nil, fmt.Errorf("error cloning vApp %s into %s: %s", source.VApp.Name, name, err)
+	}
+
+	return vdc.GetVAppByHref(ctx, clonedVApp.HREF)
+}
+
+// MoveVApp moves the source vApp from another VDC into this VDC. It requires VCD 10.0+ (API 33.0).
+//
+// targetNetworks maps source vApp network names to Org VDC networks of this VDC, the same way as in
+// CloneVApp. storageProfile is optional and, when set, is used for every VM of the moved vApp.
+func (vdc *Vdc) MoveVApp(ctx context.Context, source *VApp, targetNetworks map[string]*types.OrgVDCNetwork,
+	storageProfile *types.Reference) (*VApp, error) {
+	if source == nil || source.VApp == nil || source.VApp.HREF == "" {
+		return nil, fmt.Errorf("source vApp must be provided")
+	}
+	if vdc.client.APIVCDMaxVersionIs(ctx, "< 33.0") {
+		return nil, fmt.Errorf("moving a vApp requires at least VCD 10.0 (API 33.0)")
+	}
+
+	err := source.Refresh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing vApp %s: %s", source.VApp.Name, err)
+	}
+
+	sourceNetworkConfig, err := source.GetNetworkConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving network configuration of vApp %s: %s", source.VApp.Name, err)
+	}
+	networkConfig, err := remapVAppNetworkConfig(sourceNetworkConfig, targetNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	moveParams := &types.MoveVAppParams{
+		Ovf:                  types.XMLNamespaceOVF,
+		Xmlns:                types.XMLNamespaceVCloud,
+		Source:               &types.Reference{HREF: source.VApp.HREF},
+		NetworkConfigSection: networkConfig,
+	}
+	if source.VApp.Children != nil {
+		for _, vm := range source.VApp.Children.VM {
+			sourcedItem := &types.SourcedCompositionItemParam{
+				Source: &types.Reference{HREF: vm.HREF},
+			}
+			if storageProfile != nil && storageProfile.HREF != "" {
+				sourcedItem.StorageProfile = storageProfile
+			}
+			moveParams.SourcedItem = append(moveParams.SourcedItem, sourcedItem)
+		}
+	}
+
+	vdcHref, err := url.ParseRequestURI(vdc.Vdc.HREF)
+	if err != nil {
+		return nil, fmt.Errorf("error getting vdc href: %s", err)
+	}
+	vdcHref.Path += "/action/moveVApp"
+
+	task, err := vdc.client.ExecuteTaskRequest(ctx, vdcHref.String(), http.MethodPost,
+		types.MimeMoveVAppParams, "error moving vApp: %s", moveParams)
+	if err != nil {
+		return nil, err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error moving vApp %s: %s", source.VApp.Name, err)
+	}
+
+	return vdc.GetVAppByHref(ctx, source.VApp.HREF)
+}
+
+// CloneVMIntoVApp copies sourceVm, which can belong to any vApp, into this vApp with the given name.
+//
+// networkAssignments maps network names used by sourceVm NICs to vApp network names of this vApp.
+// storageProfile is optional. If it is not set, the default storage profile of the VDC is used.
+func (vapp *VApp) CloneVMIntoVApp(ctx context.Context, sourceVm *VM, name string, networkAssignments map[string]string,
+	storageProfile *types.Reference) (*VM, error) {
+	return vapp.recomposeWithSourcedVm(ctx, sourceVm, name, networkAssignments, storageProfile, false)
+}
+
+// MoveVMFrom moves vm from otherVApp into this vApp. The VM keeps its name.
+//
+// networkAssignments and storageProfile are handled the same way as in CloneVMIntoVApp.
+func (vapp *VApp) MoveVMFrom(ctx context.Context, otherVApp *VApp, vm *VM, networkAssignments map[string]string,
+	storageProfile *types.Reference) (*VM, error) {
+	if otherVApp == nil || otherVApp.VApp == nil {
+		return nil, fmt.Errorf("source vApp must be provided")
+	}
+	if vm == nil || vm.VM == nil {
+		return nil, fmt.Errorf("VM to move must be provided")
+	}
+	if otherVApp.VApp.HREF == vapp.VApp.HREF {
+		return nil, fmt.Errorf("VM %s already belongs to vApp %s", vm.VM.Name, vapp.VApp.Name)
+	}
+
+	err := otherVApp.Refresh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing vApp %s: %s", otherVApp.VApp.Name, err)
+	}
+	if !vappContainsVm(otherVApp.VApp, vm.VM.HREF) {
+		return nil, fmt.Errorf("VM %s does not belong to vApp %s", vm.VM.Name, otherVApp.VApp.Name)
+	}
+
+	return vapp.recomposeWithSourcedVm(ctx, vm, vm.VM.Name, networkAssignments, storageProfile, true)
+}
+
+// recomposeWithSourcedVm adds sourceVm to the vApp using a recompose SourcedItem. When sourceDelete
+// is true, the source VM is removed after the operation, which results in a move.
+func (vapp *VApp) recomposeWithSourcedVm(ctx context.Context, sourceVm *VM, name string, networkAssignments map[string]string,
+	storageProfile *types.Reference, sourceDelete bool) (*VM, error) {
+	if sourceVm == nil || sourceVm.VM == nil || sourceVm.VM.HREF == "" {
+		return nil, fmt.Errorf("source VM must be provided")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("VM name must be provided")
+	}
+
+	err := vapp.Refresh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing vApp: %s", err)
+	}
+
+	recomposeParams := &types.ReComposeVAppParams{
+		Ovf:         types.XMLNamespaceOVF,
+		Xsi:         types.XMLNamespaceXSI,
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        vapp.VApp.Name,
+		Description: vapp.VApp.Description,
+		SourcedItem: &types.SourcedCompositionItemParam{
+			SourceDelete: sourceDelete,
+			Source:       &types.Reference{HREF: sourceVm.VM.HREF},
+			VMGeneralParams: &types.VMGeneralParams{
+				Name: name,
+			},
+			NetworkAssignment: vmNetworkAssignments(networkAssignments),
+		},
+		AllEULAsAccepted: true,
+	}
+	if storageProfile != nil && storageProfile.HREF != "" {
+		recomposeParams.SourcedItem.StorageProfile = storageProfile
+	}
+
+	apiEndpoint, err := url.ParseRequestURI(vapp.VApp.HREF)
+	if err != nil {
+		return nil, fmt.Errorf("error getting vApp href: %s", err)
+	}
+	apiEndpoint.Path += "/action/recomposeVApp"
+
+	task, err := vapp.client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPost,
+		types.MimeRecomposeVappParams, "error recomposing vApp: %s", recomposeParams)
+	if err != nil {
+		return nil, err
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error adding VM %s to vApp %s: %s", name, vapp.VApp.Name, err)
+	}
+
+	return vapp.GetVMByName(ctx, name, true)
+}
+
+// remapVAppNetworkConfig returns a copy of sourceConfig, suitable to be sent in clone and move
+// requests, where parent networks of vApp networks listed in targetNetworks are replaced. It
+// returns an error if targetNetworks refers to a vApp network which does not exist.
+func remapVAppNetworkConfig(sourceConfig *types.NetworkConfigSection, targetNetworks map[string]*types.OrgVDCNetwork) (*types.NetworkConfigSection, error) {
+	if sourceConfig == nil {
+		sourceConfig = &types.NetworkConfigSection{}
+	}
+
+	for vAppNetworkName := range targetNetworks {
+		if !stringInSlice(vAppNetworkName, sourceConfig.NetworkNames()) {
+			return nil, fmt.Errorf("vApp network '%s' does not exist in source vApp", vAppNetworkName)
+		}
+	}
+
+	networkConfig := &types.NetworkConfigSection{
+		Info: "Configuration parameters for logical networks",
+		Ovf:  types.XMLNamespaceOVF,
+	}
+	for _, sourceNetwork := range sourceConfig.NetworkConfig {
+		// vApp networks without configuration (e.g. the "none" network) can't be sent back
+		if sourceNetwork.Configuration == nil {
+			continue
+		}
+		configuration := *sourceNetwork.Configuration
+		if targetNetwork, ok := targetNetworks[sourceNetwork.NetworkName]; ok {
+			if targetNetwork == nil {
+				return nil, fmt.Errorf("target network for vApp network '%s' is empty", sourceNetwork.NetworkName)
+			}
+			configuration.ParentNetwork = &types.Reference{
+				HREF: targetNetwork.HREF,
+				Name: targetNetwork.Name,
+				Type: targetNetwork.Type,
+			}
+		}
+		networkConfig.NetworkConfig = append(networkConfig.NetworkConfig, types.VAppNetworkConfiguration{
+			NetworkName:   sourceNetwork.NetworkName,
+			Description:   sourceNetwork.Description,
+			Configuration: &configuration,
+		})
+	}
+
+	return networkConfig, nil
+}
+
+// vmNetworkAssignments converts a map of VM network names to vApp network names into a list of
+// NetworkAssignment sorted by VM network name
+func vmNetworkAssignments(networkAssignments map[string]string) []*types.NetworkAssignment {
+	var innerNetworks []string
+	for innerNetwork := range networkAssignments {
+		innerNetworks = append(innerNetworks, innerNetwork)
+	}
+	sort.Strings(innerNetworks)
+
+	var assignments []*types.NetworkAssignment
+	for _, innerNetwork := range innerNetworks {
+		assignments = append(assignments, &types.NetworkAssignment{
+			InnerNetwork:     innerNetwork,
+			ContainerNetwork: networkAssignments[innerNetwork],
+		})
+	}
+	return assignments
+}
+
+// vappContainsVm returns true if the VM with the given HREF is one of the children of the vApp
+func vappContainsVm(vapp *types.VApp, vmHref string) bool {
+	if vapp.Children == nil {
+		return false
+	}
+	for _, vm := range vapp.Children.VM {
+		if vm.HREF == vmHref {
+			return true
+		}
+	}
+	return false
+}
+
+// waitVAppTasks waits for all tasks embedded in a vApp returned by an asynchronous operation
+func waitVAppTasks(ctx context.Context, client *Client, vapp *types.VApp) error {
+	if vapp.Tasks == nil {
+		return nil
+	}
+	task := NewTask(client)
+	for _, taskItem := range vapp.Tasks.Task {
+		task.Task = taskItem
+		err := task.WaitTaskCompletion(ctx)
+		if err != nil {
+			return fmt.Errorf("error performing task: %s", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// +build vapp unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_remapVAppNetworkConfig(t *testing.T) {
+	sourceConfig := &types.NetworkConfigSection{
+		NetworkConfig: []types.VAppNetworkConfiguration{
+			{
+				HREF:        "https://vcd/network/1",
+				NetworkName: "routed-net",
+				IsDeployed:  true,
+				Configuration: &types.NetworkConfiguration{
+					FenceMode:     types.FenceModeBridged,
+					ParentNetwork: &types.Reference{HREF: "https://vcd/network/old", Name: "routed-net"},
+				},
+			},
+			{
+				NetworkName: "isolated-net",
+				Configuration: &types.NetworkConfiguration{
+					FenceMode: types.FenceModeIsolated,
+				},
+			},
+			{
+				NetworkName: "none",
+			},
+		},
+	}
+	targetNetwork := &types.OrgVDCNetwork{HREF: "https://vcd/network/new", Name: "target-net",
+		Type: "application/vnd.vmware.vcloud.orgNetwork+xml"}
+
+	got, err := remapVAppNetworkConfig(sourceConfig, map[string]*types.OrgVDCNetwork{"routed-net": targetNetwork})
+	if err != nil {
+		t.Fatalf("remapVAppNetworkConfig() unexpected error: %s", err)
+	}
+	if len(got.NetworkConfig) != 2 {
+		t.Fatalf("expected 2 networks, got %d", len(got.NetworkConfig))
+	}
+	remapped := got.NetworkConfig[0]
+	if remapped.NetworkName != "routed-net" || remapped.HREF != "" || remapped.IsDeployed {
+		t.Errorf("unexpected remapped network %+v", remapped)
+	}
+	wantParent := &types.Reference{HREF: targetNetwork.HREF, Name: targetNetwork.Name, Type: targetNetwork.Type}
+	if !reflect.DeepEqual(remapped.Configuration.ParentNetwork, wantParent) {
+		t.Errorf("parent network = %+v, want %+v", remapped.Configuration.ParentNetwork, wantParent)
+	}
+	// The source configuration must stay untouched
+	if sourceConfig.NetworkConfig[0].Configuration.ParentNetwork.HREF != "https://vcd/network/old" {
+		t.Errorf("source configuration was modified")
+	}
+	if got.NetworkConfig[1].Configuration.ParentNetwork != nil {
+		t.Errorf("isolated network must not get a parent network")
+	}
+
+	_, err = remapVAppNetworkConfig(sourceConfig, map[string]*types.OrgVDCNetwork{"missing-net": targetNetwork})
+	if err == nil {
+		t.Errorf("remapVAppNetworkConfig() expected error for unknown vApp network")
+	}
+}
+
+func Test_vmNetworkAssignments(t *testing.T) {
+	got := vmNetworkAssignments(map[string]string{"net-b": "vapp-b", "net-a": "vapp-a"})
+	want := []*types.NetworkAssignment{
+		{InnerNetwork: "net-a", ContainerNetwork: "vapp-a"},
+		{InnerNetwork: "net-b", ContainerNetwork: "vapp-b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("vmNetworkAssignments() = %v, want %v", got, want)
+	}
+	if vmNetworkAssignments(nil) != nil {
+		t.Errorf("vmNetworkAssignments(nil) expected nil")
+	}
+}
+
+func Test_vappContainsVm(t *testing.T) {
+	vapp := &types.VApp{Children: &types.VAppChildren{VM: []*types.Vm{{HREF: "https://vcd/api/vApp/vm-1"}}}}
+	if !vappContainsVm(vapp, "https://vcd/api/vApp/vm-1") {
+		t.Errorf("vappContainsVm() expected to find child VM")
+	}
+	if vappContainsVm(vapp, "https://vcd/api/vApp/vm-2") {
+		t.Errorf("vappContainsVm() found a VM of another vApp")
+	}
+	if vappContainsVm(&types.VApp{}, "https://vcd/api/vApp/vm-1") {
+		t.Errorf("vappContainsVm() found a VM in an empty vApp")
+	}
+}
@@ -1565,3 +1565,77 @@ func (vcd *TestVCD) Test_AddNewVMWithComputeCapacity(check *C) {
 	_, err = adminVdc.SetAssignedComputePolicies(ctx, types.VdcComputePolicyReferences{VdcComputePolicyReference: beforeTestPolicyReferences})
 	check.Assert(err, IsNil)
 }
+
+// Test_CloneVAppAndMoveVm clones the test vApp twice, clones a VM into the first copy and moves it
+// from the first copy into the second one
+func (vcd *TestVCD) Test_CloneVAppAndMoveVm(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	sourceVapp := vcd.findFirstVapp(ctx)
+	sourceVmRef, vmName := vcd.findFirstVm(sourceVapp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	sourceVm, err := vcd.client.Client.GetVMByHref(ctx, sourceVmRef.HREF)
+	check.Assert(err, IsNil)
+
+	firstName := check.TestName() + "-1"
+	firstVapp, err := vcd.vdc.CloneVApp(ctx, &sourceVapp, firstName, nil, nil, false)
+	check.Assert(err, IsNil)
+	AddToCleanupList(firstName, "vapp", "", check.TestName())
+	check.Assert(firstVapp.VApp.Name, Equals, firstName)
+	check.Assert(len(firstVapp.VApp.Children.VM), Equals, len(sourceVapp.VApp.Children.VM))
+
+	secondName := check.TestName() + "-2"
+	secondVapp, err := vcd.vdc.CloneVApp(ctx, &sourceVapp, secondName, nil, nil, false)
+	check.Assert(err, IsNil)
+	AddToCleanupList(secondName, "vapp", "", check.TestName())
+
+	clonedVmName := check.TestName() + "-vm"
+	clonedVm, err := firstVapp.CloneVMIntoVApp(ctx, sourceVm, clonedVmName, nil, nil)
+	check.Assert(err, IsNil)
+	check.Assert(clonedVm.VM.Name, Equals, clonedVmName)
+
+	movedVm, err := secondVapp.MoveVMFrom(ctx, firstVapp, clonedVm, nil, nil)
+	check.Assert(err, IsNil)
+	check.Assert(movedVm.VM.Name, Equals, clonedVmName)
+
+	_, err = firstVapp.GetVMByName(ctx, clonedVmName, true)
+	check.Assert(ContainsNotFound(err), Equals, true)
+
+	err = deleteVapp(ctx, vcd, firstName)
+	check.Assert(err, IsNil)
+	err = deleteVapp(ctx, vcd, secondName)
+	check.Assert(err, IsNil)
+}
+
+// Test_VAppLease renews the lease of the test vApp with its current values and checks that
+// expiring vApps can be listed
+func (vcd *TestVCD) Test_VAppLease(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	vapp := vcd.findFirstVapp(ctx)
+	if vapp.VApp == nil {
+		check.Skip("Skipping test because no vApp was found")
+	}
+
+	lease, err := vapp.GetLease(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(lease, NotNil)
+
+	err = vapp.RenewLease(ctx, lease.DeploymentLeaseInSeconds, lease.StorageLeaseInSeconds)
+	check.Assert(err, IsNil)
+
+	renewedLease, err := vapp.GetLease(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(renewedLease.DeploymentLeaseInSeconds, Equals, lease.DeploymentLeaseInSeconds)
+	check.Assert(renewedLease.StorageLeaseInSeconds, Equals, lease.StorageLeaseInSeconds)
+
+	expiringVapps, err := vcd.vdc.ListExpiringVApps(ctx, 0)
+	check.Assert(err, IsNil)
+	for _, expiringVapp := range expiringVapps {
+		check.Assert(expiringVapp.Name, Not(Equals), vapp.VApp.Name)
+	}
+}
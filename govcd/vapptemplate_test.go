@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	. "gopkg.in/check.v1"
 )
 
@@ -48,3 +49,42 @@ func (vcd *TestVCD) Test_RefreshVAppTemplate(check *C) {
 	check.Assert(oldVAppTemplate.VAppTemplate.Name, Equals, vAppTemplate.VAppTemplate.Name)
 	check.Assert(oldVAppTemplate.VAppTemplate.HREF, Equals, vAppTemplate.VAppTemplate.HREF)
 }
+
+// Test_VAppTemplateOvfProperties discovers OVF properties of the test vApp template and checks that
+// undeclared properties are rejected before instantiation
+func (vcd *TestVCD) Test_VAppTemplateOvfProperties(check *C) {
+	ctx := context.Background()
+	fmt.Printf("Running: %s\n", check.TestName())
+	cat, err := vcd.org.GetCatalogByName(ctx, vcd.config.VCD.Catalog.Name, false)
+	if err != nil {
+		check.Skip("Test_VAppTemplateOvfProperties: Catalog not found. Test can't proceed")
+		return
+	}
+	if vcd.config.VCD.Catalog.CatalogItem == "" {
+		check.Skip("Test_VAppTemplateOvfProperties: Catalog Item not given. Test can't proceed")
+	}
+
+	catItem, err := cat.GetCatalogItemByName(ctx, vcd.config.VCD.Catalog.CatalogItem, false)
+	check.Assert(err, IsNil)
+	vAppTemplate, err := catItem.GetVAppTemplate(ctx)
+	check.Assert(err, IsNil)
+
+	properties, err := vAppTemplate.GetOvfProperties(ctx)
+	check.Assert(err, IsNil)
+	for _, property := range properties {
+		check.Assert(property.Key, Not(Equals), "")
+		check.Assert(property.OwnerHref, Not(Equals), "")
+	}
+
+	params := &types.InstantiateVAppTemplateParams{Name: check.TestName()}
+	err = vAppTemplate.SetOvfPropertiesForInstantiation(ctx, params, map[string]string{"undeclared.property": "value"})
+	check.Assert(err, NotNil)
+	check.Assert(params.InstantiationParams, IsNil)
+
+	// Without values nothing is added to the parameters. Validation may still fail if the template
+	// declares typed properties without default values
+	err = vAppTemplate.SetOvfPropertiesForInstantiation(ctx, params, nil)
+	if err == nil {
+		check.Assert(params.SourcedItem, IsNil)
+	}
+}
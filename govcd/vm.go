@@ -217,6 +217,152 @@ func (vm *VM) PowerOff(ctx context.Context) (Task, error) {
 		"", "error powering off VM: %s", nil)
 }
 
+// Shutdown triggers a guest OS shutdown. It requires VMware Tools to be running in the guest.
+func (vm *VM) Shutdown(ctx context.Context) (Task, error) {
+	return vm.powerAction(ctx, "/power/action/shutdown", "error shutting down VM: %s")
+}
+
+// Reboot triggers a guest OS reboot. It requires VMware Tools to be running in the guest.
+func (vm *VM) Reboot(ctx context.Context) (Task, error) {
+	return vm.powerAction(ctx, "/power/action/reboot", "error rebooting VM: %s")
+}
+
+// Reset performs a hard reset of the VM, without involving the guest OS
+func (vm *VM) Reset(ctx context.Context) (Task, error) {
+	return vm.powerAction(ctx, "/power/action/reset", "error resetting VM: %s")
+}
+
+// Suspend suspends a powered on VM
+func (vm *VM) Suspend(ctx context.Context) (Task, error) {
+	return vm.powerAction(ctx, "/power/action/suspend", "error suspending VM: %s")
+}
+
+// DiscardSuspendedState discards the suspended state of a VM, leaving it powered off
+func (vm *VM) DiscardSuspendedState(ctx context.Context) (Task, error) {
+	return vm.powerAction(ctx, "/action/discardSuspendedState", "error discarding suspended state of VM: %s")
+}
+
+// powerAction sends a POST request without payload to the given action path of the VM
+func (vm *VM) powerAction(ctx context.Context, actionPath, errorMessage string) (Task, error) {
+	apiEndpoint, err := url.ParseRequestURI(vm.VM.HREF)
+	if err != nil {
+		return Task{}, fmt.Errorf("error parsing VM HREF: %s", err)
+	}
+	apiEndpoint.Path += actionPath
+
+	return vm.client.ExecuteTaskRequest(ctx, apiEndpoint.String(), http.MethodPost, "", errorMessage, nil)
+}
+
+// EnsurePowerState brings the VM into the desired power state and waits until it is reached.
+// Valid desired states are types.VmPowerStatePoweredOn, types.VmPowerStatePoweredOff and
+// types.VmPowerStateSuspended. Transitions are chosen based on the current status returned by
+// GetStatus. Nothing is done if the VM is already in the desired state.
+func (vm *VM) EnsurePowerState(ctx context.Context, desired string) error {
+	currentStatus, err := vm.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	transitions, err := vmPowerStateTransitions(currentStatus, desired)
+	if err != nil {
+		return fmt.Errorf("unable to bring VM %s to state %s: %s", vm.VM.Name, desired, err)
+	}
+
+	for _, transition := range transitions {
+		var task Task
+		switch transition {
+		case vmPowerTransitionPowerOn:
+			task, err = vm.PowerOn(ctx)
+		case vmPowerTransitionPowerOff:
+			task, err = vm.PowerOff(ctx)
+		case vmPowerTransitionSuspend:
+			task, err = vm.Suspend(ctx)
+		case vmPowerTransitionDiscardSuspendedState:
+			task, err = vm.DiscardSuspendedState(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("error performing '%s' on VM %s: %s", transition, vm.VM.Name, err)
+		}
+		err = task.WaitTaskCompletion(ctx)
+		if err != nil {
+			return fmt.Errorf("error waiting for '%s' on VM %s: %s", transition, vm.VM.Name, err)
+		}
+	}
+
+	currentStatus, err = vm.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if normalizeVmPowerStatus(currentStatus) != desired {
+		return fmt.Errorf("VM %s is in state %s after transitions, expected %s", vm.VM.Name, currentStatus, desired)
+	}
+	return nil
+}
+
+// Power transitions used by EnsurePowerState
+const (
+	vmPowerTransitionPowerOn               = "powerOn"
+	vmPowerTransitionPowerOff              = "powerOff"
+	vmPowerTransitionSuspend               = "suspend"
+	vmPowerTransitionDiscardSuspendedState = "discardSuspendedState"
+)
+
+// vmPowerStateTransitions returns the ordered list of power transitions which bring a VM from
+// currentStatus to desired. It does not make any API calls.
+func vmPowerStateTransitions(currentStatus, desired string) ([]string, error) {
+	switch desired {
+	case types.VmPowerStatePoweredOn, types.VmPowerStatePoweredOff, types.VmPowerStateSuspended:
+	default:
+		return nil, fmt.Errorf("unsupported desired power state '%s'", desired)
+	}
+
+	currentStatus = normalizeVmPowerStatus(currentStatus)
+	if currentStatus == desired {
+		return nil, nil
+	}
+
+	switch currentStatus {
+	case types.VmPowerStatePoweredOn:
+		if desired == types.VmPowerStatePoweredOff {
+			return []string{vmPowerTransitionPowerOff}, nil
+		}
+		return []string{vmPowerTransitionSuspend}, nil
+	case types.VmPowerStatePoweredOff:
+		if desired == types.VmPowerStatePoweredOn {
+			return []string{vmPowerTransitionPowerOn}, nil
+		}
+		return []string{vmPowerTransitionPowerOn, vmPowerTransitionSuspend}, nil
+	case types.VmPowerStateSuspended:
+		if desired == types.VmPowerStatePoweredOn {
+			return []string{vmPowerTransitionPowerOn}, nil
+		}
+		return []string{vmPowerTransitionDiscardSuspendedState}, nil
+	}
+
+	return nil, fmt.Errorf("cannot change power state from current status '%s'", currentStatus)
+}
+
+// normalizeVmPowerStatus maps statuses of a VM which is not running (e.g. freshly created or
+// undeployed VM) to types.VmPowerStatePoweredOff
+func normalizeVmPowerStatus(status string) string {
+	switch status {
+	case "RESOLVED", "VAPP_UNDEPLOYED":
+		return types.VmPowerStatePoweredOff
+	}
+	return status
+}
+
+// isValidUndeployPowerAction checks that the given value is one of the accepted undeploy power actions
+func isValidUndeployPowerAction(powerAction string) bool {
+	return stringInSlice(powerAction, []string{
+		types.UndeployPowerActionPowerOff,
+		types.UndeployPowerActionSuspend,
+		types.UndeployPowerActionShutdown,
+		types.UndeployPowerActionForce,
+		types.UndeployPowerActionDefault,
+	})
+}
+
 // Sets number of available virtual logical processors
 // (i.e. CPUs x cores per socket)
 // Cpu cores count is inherited from template.
@@ -480,10 +626,21 @@ func (vm *VM) Customize(ctx context.Context, computername, script string, change
 
 // Undeploy triggers a VM undeploy and power off action. "Power off" action in UI behaves this way.
 func (vm *VM) Undeploy(ctx context.Context) (Task, error) {
+	return vm.UndeployWithPowerAction(ctx, types.UndeployPowerActionPowerOff)
+}
+
+// UndeployWithPowerAction triggers a VM undeploy using the given power action. Valid values are
+// types.UndeployPowerActionPowerOff, types.UndeployPowerActionSuspend,
+// types.UndeployPowerActionShutdown, types.UndeployPowerActionForce and
+// types.UndeployPowerActionDefault (uses the action configured in the VM startup section).
+func (vm *VM) UndeployWithPowerAction(ctx context.Context, powerAction string) (Task, error) {
+	if !isValidUndeployPowerAction(powerAction) {
+		return Task{}, fmt.Errorf("invalid undeploy power action '%s'", powerAction)
+	}
 
 	vu := &types.UndeployVAppParams{
 		Xmlns:               types.XMLNamespaceVCloud,
-		UndeployPowerAction: "powerOff",
+		UndeployPowerAction: powerAction,
 	}
 
 	apiEndpoint, _ := url.ParseRequestURI(vm.VM.HREF)
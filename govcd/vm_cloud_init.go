@@ -0,0 +1,398 @@
+package govcd
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Product section keys read by the cloud-init VMware (guestinfo) datasource
+const (
+	cloudInitGuestInfoUserData         = "guestinfo.userdata"
+	cloudInitGuestInfoUserDataEncoding = "guestinfo.userdata.encoding"
+	cloudInitGuestInfoMetaData         = "guestinfo.metadata"
+	cloudInitGuestInfoMetaDataEncoding = "guestinfo.metadata.encoding"
+)
+
+// Product section keys read by the cloud-init OVF datasource
+const (
+	cloudInitOvfUserData      = "user-data"
+	cloudInitOvfNetworkConfig = "network-config"
+)
+
+// Keys of the network configuration embedded in guestinfo metadata
+const (
+	cloudInitMetaDataNetwork         = "network"
+	cloudInitMetaDataNetworkEncoding = "network.encoding"
+)
+
+// Encodings understood by cloud-init
+const (
+	cloudInitEncodingBase64     = "base64"
+	cloudInitEncodingGzipBase64 = "gzip+base64"
+)
+
+// maxCloudInitValueSize is the maximum size of a single encoded cloud-init value. Larger values
+// are compressed with gzip before being rejected.
+const maxCloudInitValueSize = 64 * 1024
+
+// CloudInitData contains cloud-init user data, metadata and network configuration as plain text
+type CloudInitData struct {
+	UserData      string
+	MetaData      string
+	NetworkConfig string
+}
+
+// SetCloudInitUserData injects cloud-init configuration for the VMware guestinfo datasource. User
+// data and metadata are base64 encoded (gzip compressed when large) into the guestinfo.userdata
+// and guestinfo.metadata guest properties with the matching encoding markers. networkConfig is
+// optional and is embedded in the metadata, which must then be a YAML or JSON mapping.
+// Other guest properties of the VM are preserved. Changes are applied by cloud-init on next boot.
+func (vm *VM) SetCloudInitUserData(ctx context.Context, userData, metaData, networkConfig string) error {
+	properties, err := cloudInitGuestInfoProperties(userData, metaData, networkConfig)
+	if err != nil {
+		return err
+	}
+	return vm.setCloudInitProperties(ctx, properties)
+}
+
+// SetCloudInitOvfUserData injects cloud-init configuration for the OVF datasource, using the
+// user-data and network-config properties of the OVF environment. The VM must have the OVF
+// environment transport enabled (e.g. "com.vmware.guestInfo") for the guest to see the values.
+func (vm *VM) SetCloudInitOvfUserData(ctx context.Context, userData, networkConfig string) error {
+	properties, err := cloudInitOvfProperties(userData, networkConfig)
+	if err != nil {
+		return err
+	}
+	return vm.setCloudInitProperties(ctx, properties)
+}
+
+// GetCloudInitUserData reads back cloud-init configuration set with SetCloudInitUserData or
+// SetCloudInitOvfUserData. The guestinfo variant takes precedence when both are present.
+func (vm *VM) GetCloudInitUserData(ctx context.Context) (*CloudInitData, error) {
+	productSectionList, err := vm.GetProductSectionList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	if productSectionList.ProductSection != nil {
+		for _, property := range productSectionList.ProductSection.Property {
+			value := property.DefaultValue
+			if property.Value != nil {
+				value = property.Value.Value
+			}
+			values[property.Key] = value
+		}
+	}
+	return parseCloudInitProperties(values)
+}
+
+// setCloudInitProperties adds or replaces the given guest properties of the VM
+func (vm *VM) setCloudInitProperties(ctx context.Context, properties map[string]string) error {
+	productSectionList, err := vm.GetProductSectionList(ctx)
+	if err != nil {
+		return err
+	}
+	if productSectionList.ProductSection == nil {
+		productSectionList.ProductSection = &types.ProductSection{}
+	}
+	mergeProductSectionProperties(productSectionList.ProductSection, properties)
+
+	_, err = vm.SetProductSectionList(ctx, productSectionList)
+	if err != nil {
+		return fmt.Errorf("error setting cloud-init data for VM %s: %s", vm.VM.Name, err)
+	}
+	return nil
+}
+
+// mergeProductSectionProperties sets values of existing properties and appends missing ones.
+// Properties with an empty value are removed.
+func mergeProductSectionProperties(productSection *types.ProductSection, values map[string]string) {
+	var merged []*types.Property
+	handled := make(map[string]bool)
+	for _, property := range productSection.Property {
+		value, ok := values[property.Key]
+		if !ok {
+			merged = append(merged, property)
+			continue
+		}
+		handled[property.Key] = true
+		if value == "" {
+			continue
+		}
+		property.Value = &types.Value{Value: value}
+		merged = append(merged, property)
+	}
+
+	var missing []string
+	for key, value := range values {
+		if !handled[key] && value != "" {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	for _, key := range missing {
+		merged = append(merged, &types.Property{
+			Key:              key,
+			Type:             "string",
+			UserConfigurable: true,
+			Value:            &types.Value{Value: values[key]},
+		})
+	}
+	productSection.Property = merged
+}
+
+// cloudInitGuestInfoProperties builds the guest properties for the guestinfo datasource. Keys with
+// empty values remove previously set data.
+func cloudInitGuestInfoProperties(userData, metaData, networkConfig string) (map[string]string, error) {
+	if userData == "" && metaData == "" && networkConfig == "" {
+		return nil, fmt.Errorf("at least one of user data, metadata and network configuration must be set")
+	}
+
+	if networkConfig != "" {
+		encodedNetwork, networkEncoding, err := encodeCloudInitValue(networkConfig)
+		if err != nil {
+			return nil, fmt.Errorf("network configuration: %s", err)
+		}
+		metaData, err = embedCloudInitNetworkConfig(metaData, encodedNetwork, networkEncoding)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	properties := map[string]string{
+		cloudInitGuestInfoUserData:         "",
+		cloudInitGuestInfoUserDataEncoding: "",
+		cloudInitGuestInfoMetaData:         "",
+		cloudInitGuestInfoMetaDataEncoding: "",
+	}
+	if userData != "" {
+		encoded, encoding, err := encodeCloudInitValue(userData)
+		if err != nil {
+			return nil, fmt.Errorf("user data: %s", err)
+		}
+		properties[cloudInitGuestInfoUserData] = encoded
+		properties[cloudInitGuestInfoUserDataEncoding] = encoding
+	}
+	if metaData != "" {
+		encoded, encoding, err := encodeCloudInitValue(metaData)
+		if err != nil {
+			return nil, fmt.Errorf("metadata: %s", err)
+		}
+		properties[cloudInitGuestInfoMetaData] = encoded
+		properties[cloudInitGuestInfoMetaDataEncoding] = encoding
+	}
+	return properties, nil
+}
+
+// cloudInitOvfProperties builds the OVF environment properties for the OVF datasource. The OVF
+// datasource only understands base64, therefore values are not compressed.
+func cloudInitOvfProperties(userData, networkConfig string) (map[string]string, error) {
+	if userData == "" && networkConfig == "" {
+		return nil, fmt.Errorf("at least one of user data and network configuration must be set")
+	}
+	properties := make(map[string]string)
+	for key, value := range map[string]string{cloudInitOvfUserData: userData, cloudInitOvfNetworkConfig: networkConfig} {
+		encoded := base64.StdEncoding.EncodeToString([]byte(value))
+		if len(encoded) > maxCloudInitValueSize {
+			return nil, fmt.Errorf("%s is %d bytes after encoding, maximum is %d", key, len(encoded), maxCloudInitValueSize)
+		}
+		if value == "" {
+			encoded = ""
+		}
+		properties[key] = encoded
+	}
+	return properties, nil
+}
+
+// parseCloudInitProperties decodes cloud-init data from guest property values
+func parseCloudInitProperties(values map[string]string) (*CloudInitData, error) {
+	data := &CloudInitData{}
+	var err error
+
+	if values[cloudInitGuestInfoUserData] != "" || values[cloudInitGuestInfoMetaData] != "" {
+		data.UserData, err = decodeCloudInitValue(values[cloudInitGuestInfoUserData], values[cloudInitGuestInfoUserDataEncoding])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s: %s", cloudInitGuestInfoUserData, err)
+		}
+		metaData, err := decodeCloudInitValue(values[cloudInitGuestInfoMetaData], values[cloudInitGuestInfoMetaDataEncoding])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s: %s", cloudInitGuestInfoMetaData, err)
+		}
+		data.MetaData, data.NetworkConfig, err = extractCloudInitNetworkConfig(metaData)
+		if err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+
+	if values[cloudInitOvfUserData] != "" || values[cloudInitOvfNetworkConfig] != "" {
+		data.UserData, err = decodeCloudInitValue(values[cloudInitOvfUserData], cloudInitEncodingBase64)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s: %s", cloudInitOvfUserData, err)
+		}
+		data.NetworkConfig, err = decodeCloudInitValue(values[cloudInitOvfNetworkConfig], cloudInitEncodingBase64)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s: %s", cloudInitOvfNetworkConfig, err)
+		}
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("%s: cloud-init data", ErrorEntityNotFound)
+}
+
+// encodeCloudInitValue base64 encodes a value, compressing it with gzip if the plain encoding
+// exceeds maxCloudInitValueSize. It returns the encoded value and its encoding marker.
+func encodeCloudInitValue(value string) (string, string, error) {
+	encoded := base64.StdEncoding.EncodeToString([]byte(value))
+	if len(encoded) <= maxCloudInitValueSize {
+		return encoded, cloudInitEncodingBase64, nil
+	}
+
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write([]byte(value))
+	if err != nil {
+		return "", "", fmt.Errorf("error compressing value: %s", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return "", "", fmt.Errorf("error compressing value: %s", err)
+	}
+	encoded = base64.StdEncoding.EncodeToString(buffer.Bytes())
+	if len(encoded) > maxCloudInitValueSize {
+		return "", "", fmt.Errorf("value is %d bytes after compression and encoding, maximum is %d",
+			len(encoded), maxCloudInitValueSize)
+	}
+	return encoded, cloudInitEncodingGzipBase64, nil
+}
+
+// decodeCloudInitValue decodes a value with the given cloud-init encoding marker
+func decodeCloudInitValue(value, encoding string) (string, error) {
+	switch strings.ToLower(encoding) {
+	case "":
+		return value, nil
+	case cloudInitEncodingBase64, "b64":
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", err
+		}
+		return string(decoded), nil
+	case cloudInitEncodingGzipBase64, "gz+b64":
+		compressed, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", err
+		}
+		reader, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return "", err
+		}
+		defer reader.Close()
+		decoded, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return "", err
+		}
+		return string(decoded), nil
+	}
+	return "", fmt.Errorf("unsupported encoding '%s'", encoding)
+}
+
+// embedCloudInitNetworkConfig adds the encoded network configuration to guestinfo metadata, as
+// expected by the cloud-init VMware datasource. JSON metadata is extended as JSON, anything else
+// is treated as a YAML mapping.
+func embedCloudInitNetworkConfig(metaData, encodedNetwork, networkEncoding string) (string, error) {
+	trimmed := strings.TrimSpace(metaData)
+	if strings.HasPrefix(trimmed, "{") {
+		fields := make(map[string]interface{})
+		err := json.Unmarshal([]byte(trimmed), &fields)
+		if err != nil {
+			return "", fmt.Errorf("error parsing JSON metadata: %s", err)
+		}
+		if _, ok := fields[cloudInitMetaDataNetwork]; ok {
+			return "", fmt.Errorf("metadata already contains a network configuration")
+		}
+		fields[cloudInitMetaDataNetwork] = encodedNetwork
+		fields[cloudInitMetaDataNetworkEncoding] = networkEncoding
+		text, err := json.Marshal(fields)
+		if err != nil {
+			return "", fmt.Errorf("error encoding JSON metadata: %s", err)
+		}
+		return string(text), nil
+	}
+
+	for _, line := range strings.Split(metaData, "\n") {
+		if strings.HasPrefix(line, cloudInitMetaDataNetwork+":") {
+			return "", fmt.Errorf("metadata already contains a network configuration")
+		}
+	}
+	if trimmed != "" && !strings.HasSuffix(metaData, "\n") {
+		metaData += "\n"
+	}
+	return fmt.Sprintf("%s%s: %s\n%s: %s\n", metaData, cloudInitMetaDataNetwork, encodedNetwork,
+		cloudInitMetaDataNetworkEncoding, networkEncoding), nil
+}
+
+// extractCloudInitNetworkConfig splits metadata created by embedCloudInitNetworkConfig into the
+// original metadata and the decoded network configuration
+func extractCloudInitNetworkConfig(metaData string) (string, string, error) {
+	var encodedNetwork, networkEncoding string
+	trimmed := strings.TrimSpace(metaData)
+	if strings.HasPrefix(trimmed, "{") {
+		fields := make(map[string]interface{})
+		err := json.Unmarshal([]byte(trimmed), &fields)
+		if err != nil {
+			return "", "", fmt.Errorf("error parsing JSON metadata: %s", err)
+		}
+		network, ok := fields[cloudInitMetaDataNetwork].(string)
+		if !ok {
+			return metaData, "", nil
+		}
+		encodedNetwork = network
+		networkEncoding, _ = fields[cloudInitMetaDataNetworkEncoding].(string)
+		delete(fields, cloudInitMetaDataNetwork)
+		delete(fields, cloudInitMetaDataNetworkEncoding)
+		metaData = ""
+		if len(fields) > 0 {
+			text, err := json.Marshal(fields)
+			if err != nil {
+				return "", "", fmt.Errorf("error encoding JSON metadata: %s", err)
+			}
+			metaData = string(text)
+		}
+	} else {
+		var lines []string
+		for _, line := range strings.SplitAfter(metaData, "\n") {
+			switch {
+			case strings.HasPrefix(line, cloudInitMetaDataNetworkEncoding+":"):
+				networkEncoding = strings.TrimSpace(strings.TrimPrefix(line, cloudInitMetaDataNetworkEncoding+":"))
+			case strings.HasPrefix(line, cloudInitMetaDataNetwork+":"):
+				encodedNetwork = strings.TrimSpace(strings.TrimPrefix(line, cloudInitMetaDataNetwork+":"))
+			default:
+				lines = append(lines, line)
+			}
+		}
+		if encodedNetwork == "" {
+			return metaData, "", nil
+		}
+		metaData = strings.Join(lines, "")
+	}
+
+	networkConfig, err := decodeCloudInitValue(encodedNetwork, networkEncoding)
+	if err != nil {
+		return "", "", fmt.Errorf("error decoding network configuration: %s", err)
+	}
+	return metaData, networkConfig, nil
+}
@@ -0,0 +1,146 @@
+// +build vm unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_CloudInitGuestInfoRoundTrip(t *testing.T) {
+	userData := "#cloud-config\nusers:\n  - name: admin\n"
+	networkConfig := "version: 2\nethernets:\n  ens192:\n    dhcp4: true\n"
+
+	tests := []struct {
+		name          string
+		metaData      string
+		networkConfig string
+		wantMetaData  string
+	}{
+		{name: "UserDataOnly"},
+		{name: "YamlMetaData", metaData: "instance-id: vm-1\nlocal-hostname: vm-1\n"},
+		{name: "YamlMetaDataWithNetwork", metaData: "instance-id: vm-1\nlocal-hostname: vm-1",
+			networkConfig: networkConfig, wantMetaData: "instance-id: vm-1\nlocal-hostname: vm-1\n"},
+		{name: "JsonMetaDataWithNetwork", metaData: `{"instance-id":"vm-1"}`, networkConfig: networkConfig},
+		{name: "NetworkOnly", networkConfig: networkConfig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			properties, err := cloudInitGuestInfoProperties(userData, tt.metaData, tt.networkConfig)
+			if err != nil {
+				t.Fatalf("cloudInitGuestInfoProperties() unexpected error: %s", err)
+			}
+			if properties[cloudInitGuestInfoUserDataEncoding] != cloudInitEncodingBase64 {
+				t.Errorf("unexpected user data encoding '%s'", properties[cloudInitGuestInfoUserDataEncoding])
+			}
+			data, err := parseCloudInitProperties(properties)
+			if err != nil {
+				t.Fatalf("parseCloudInitProperties() unexpected error: %s", err)
+			}
+			wantMetaData := tt.metaData
+			if tt.wantMetaData != "" {
+				wantMetaData = tt.wantMetaData
+			}
+			want := &CloudInitData{UserData: userData, MetaData: wantMetaData, NetworkConfig: tt.networkConfig}
+			if !reflect.DeepEqual(data, want) {
+				t.Errorf("parseCloudInitProperties() = %+v, want %+v", data, want)
+			}
+		})
+	}
+
+	_, err := cloudInitGuestInfoProperties("", "", "")
+	if err == nil {
+		t.Errorf("cloudInitGuestInfoProperties() expected error for empty input")
+	}
+	_, err = cloudInitGuestInfoProperties(userData, "network: abc\n", networkConfig)
+	if err == nil {
+		t.Errorf("cloudInitGuestInfoProperties() expected error for metadata with network configuration")
+	}
+}
+
+func Test_CloudInitLargeValues(t *testing.T) {
+	// Compressible data larger than the limit is gzipped
+	userData := "#cloud-config\n" + strings.Repeat("# padding line\n", maxCloudInitValueSize/10)
+	properties, err := cloudInitGuestInfoProperties(userData, "", "")
+	if err != nil {
+		t.Fatalf("cloudInitGuestInfoProperties() unexpected error: %s", err)
+	}
+	if properties[cloudInitGuestInfoUserDataEncoding] != cloudInitEncodingGzipBase64 {
+		t.Errorf("expected gzip+base64 encoding, got '%s'", properties[cloudInitGuestInfoUserDataEncoding])
+	}
+	data, err := parseCloudInitProperties(properties)
+	if err != nil || data.UserData != userData {
+		t.Errorf("parseCloudInitProperties() did not return the original user data (error: %v)", err)
+	}
+
+	// Random data can't be compressed below the limit
+	random := make([]byte, maxCloudInitValueSize)
+	rand.New(rand.NewSource(1)).Read(random)
+	_, err = cloudInitGuestInfoProperties(string(random), "", "")
+	if err == nil {
+		t.Errorf("cloudInitGuestInfoProperties() expected error for oversized user data")
+	}
+	_, err = cloudInitOvfProperties(userData, "")
+	if err == nil {
+		t.Errorf("cloudInitOvfProperties() expected error for oversized user data")
+	}
+}
+
+func Test_CloudInitOvfRoundTrip(t *testing.T) {
+	properties, err := cloudInitOvfProperties("#cloud-config\n", "version: 2\n")
+	if err != nil {
+		t.Fatalf("cloudInitOvfProperties() unexpected error: %s", err)
+	}
+	data, err := parseCloudInitProperties(properties)
+	if err != nil {
+		t.Fatalf("parseCloudInitProperties() unexpected error: %s", err)
+	}
+	want := &CloudInitData{UserData: "#cloud-config\n", NetworkConfig: "version: 2\n"}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("parseCloudInitProperties() = %+v, want %+v", data, want)
+	}
+
+	_, err = parseCloudInitProperties(map[string]string{"other": "value"})
+	if !ContainsNotFound(err) {
+		t.Errorf("parseCloudInitProperties() expected not found error, got %v", err)
+	}
+}
+
+func Test_mergeProductSectionProperties(t *testing.T) {
+	productSection := &types.ProductSection{Property: []*types.Property{
+		{Key: "hostname", Type: "string", Value: &types.Value{Value: "vm-1"}},
+		{Key: cloudInitGuestInfoUserData, Type: "string", Value: &types.Value{Value: "old"}},
+		{Key: cloudInitGuestInfoMetaData, Type: "string", Value: &types.Value{Value: "old"}},
+	}}
+	mergeProductSectionProperties(productSection, map[string]string{
+		cloudInitGuestInfoUserData:         "new",
+		cloudInitGuestInfoUserDataEncoding: cloudInitEncodingBase64,
+		cloudInitGuestInfoMetaData:         "",
+	})
+
+	got := make(map[string]string)
+	var keys []string
+	for _, property := range productSection.Property {
+		got[property.Key] = property.Value.Value
+		keys = append(keys, property.Key)
+	}
+	want := map[string]string{
+		"hostname":                         "vm-1",
+		cloudInitGuestInfoUserData:         "new",
+		cloudInitGuestInfoUserDataEncoding: cloudInitEncodingBase64,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeProductSectionProperties() = %v, want %v", got, want)
+	}
+	if keys[0] != "hostname" {
+		t.Errorf("mergeProductSectionProperties() did not preserve property order: %v", keys)
+	}
+}
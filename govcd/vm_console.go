@@ -0,0 +1,101 @@
+package govcd
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// defaultMksPort is the port used by ESXi hosts for MKS connections when the ticket does not specify one
+const defaultMksPort = 902
+
+// AcquireMksTicket retrieves an MKS ticket which can be used to open a remote console to the VM.
+// The VM must be powered on.
+func (vm *VM) AcquireMksTicket(ctx context.Context) (*types.MksTicket, error) {
+	if vm.VM.HREF == "" {
+		return nil, fmt.Errorf("cannot acquire MKS ticket, VM HREF is empty")
+	}
+
+	mksTicket := &types.MksTicket{}
+	_, err := vm.client.ExecuteRequest(ctx, vm.VM.HREF+"/screen/action/acquireMksTicket", http.MethodPost,
+		types.MimeMksTicket, "error acquiring MKS ticket: %s", nil, mksTicket)
+	if err != nil {
+		return nil, err
+	}
+
+	return mksTicket, nil
+}
+
+// AcquireTicket retrieves a screen ticket for the VM and returns it parsed into host, VMX path and
+// ticket. The port is not part of a screen ticket, therefore the default MKS port (902) is set.
+// The VM must be powered on.
+func (vm *VM) AcquireTicket(ctx context.Context) (*types.MksTicket, error) {
+	if vm.VM.HREF == "" {
+		return nil, fmt.Errorf("cannot acquire screen ticket, VM HREF is empty")
+	}
+
+	screenTicket := &types.ScreenTicket{}
+	_, err := vm.client.ExecuteRequest(ctx, vm.VM.HREF+"/screen/action/acquireTicket", http.MethodPost,
+		types.MimeScreenTicket, "error acquiring screen ticket: %s", nil, screenTicket)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseScreenTicket(screenTicket.Value)
+}
+
+// parseScreenTicket converts a screen ticket in the form of
+// mks://<host>/<vmx path>?ticket=<ticket> into a types.MksTicket
+func parseScreenTicket(screenTicket string) (*types.MksTicket, error) {
+	ticketUrl, err := url.Parse(strings.TrimSpace(screenTicket))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing screen ticket: %s", err)
+	}
+	if ticketUrl.Scheme != "mks" {
+		return nil, fmt.Errorf("unexpected screen ticket scheme '%s'", ticketUrl.Scheme)
+	}
+
+	ticket := ticketUrl.Query().Get("ticket")
+	if ticketUrl.Hostname() == "" || ticket == "" {
+		return nil, fmt.Errorf("screen ticket does not contain host and ticket")
+	}
+
+	port := defaultMksPort
+	if ticketUrl.Port() != "" {
+		port, err = strconv.Atoi(ticketUrl.Port())
+		if err != nil {
+			return nil, fmt.Errorf("error parsing screen ticket port '%s': %s", ticketUrl.Port(), err)
+		}
+	}
+
+	return &types.MksTicket{
+		Host:   ticketUrl.Hostname(),
+		Vmx:    strings.TrimPrefix(ticketUrl.Path, "/"),
+		Ticket: ticket,
+		Port:   port,
+	}, nil
+}
+
+// WebMksUrl builds the WebMKS websocket URL (wss://<host>/<port>;<ticket>) which is used by the
+// WebMKS console library to connect to a VM console using a ticket returned by AcquireMksTicket
+func WebMksUrl(mksTicket *types.MksTicket) (string, error) {
+	if mksTicket == nil || mksTicket.Host == "" || mksTicket.Ticket == "" {
+		return "", fmt.Errorf("MKS ticket must have host and ticket set")
+	}
+
+	port := mksTicket.Port
+	if port == 0 {
+		port = defaultMksPort
+	}
+
+	return fmt.Sprintf("wss://%s/%d;%s", mksTicket.Host, port, mksTicket.Ticket), nil
+}
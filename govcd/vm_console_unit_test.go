@@ -0,0 +1,76 @@
+// +build vm unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_parseScreenTicket(t *testing.T) {
+	tests := []struct {
+		name         string
+		screenTicket string
+		want         *types.MksTicket
+		wantErr      bool
+	}{
+		{
+			name:         "Valid",
+			screenTicket: "mks://10.0.0.10/vm-123?ticket=cst-abc%3D%3D",
+			want:         &types.MksTicket{Host: "10.0.0.10", Vmx: "vm-123", Ticket: "cst-abc==", Port: 902},
+		},
+		{
+			name:         "ValidWithPortAndWhitespace",
+			screenTicket: "\n  mks://esx1.example.com:9443/datastore/vm/vm.vmx?ticket=abc\n",
+			want:         &types.MksTicket{Host: "esx1.example.com", Vmx: "datastore/vm/vm.vmx", Ticket: "abc", Port: 9443},
+		},
+		{
+			name:         "WrongScheme",
+			screenTicket: "https://10.0.0.10/vm-123?ticket=abc",
+			wantErr:      true,
+		},
+		{
+			name:         "NoTicket",
+			screenTicket: "mks://10.0.0.10/vm-123",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseScreenTicket(tt.screenTicket)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseScreenTicket() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseScreenTicket() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_WebMksUrl(t *testing.T) {
+	got, err := WebMksUrl(&types.MksTicket{Host: "esx1.example.com", Ticket: "abc", Port: 443})
+	if err != nil {
+		t.Fatalf("WebMksUrl() unexpected error: %s", err)
+	}
+	if got != "wss://esx1.example.com/443;abc" {
+		t.Errorf("WebMksUrl() = %s", got)
+	}
+
+	got, err = WebMksUrl(&types.MksTicket{Host: "esx1.example.com", Ticket: "abc"})
+	if err != nil || got != "wss://esx1.example.com/902;abc" {
+		t.Errorf("WebMksUrl() with default port = %s, %v", got, err)
+	}
+
+	_, err = WebMksUrl(&types.MksTicket{Host: "esx1.example.com"})
+	if err == nil {
+		t.Errorf("WebMksUrl() expected error for ticket without ticket value")
+	}
+}
@@ -0,0 +1,330 @@
+package govcd
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// maxCustomizationScriptLength is the maximum length of a customization script accepted by
+// GuestCustomizationBuilder
+const maxCustomizationScriptLength = 49000
+
+// Maximum computer name lengths. Windows uses NetBIOS names.
+const (
+	maxWindowsComputerNameLength = 15
+	maxLinuxComputerNameLength   = 63
+)
+
+// reComputerName matches host names made of letters, digits and hyphens, which don't start or end
+// with a hyphen
+var reComputerName = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`)
+
+// reDigitsOnly matches strings made of digits only
+var reDigitsOnly = regexp.MustCompile(`^[0-9]+$`)
+
+// GuestCustomizationBuilder builds a types.GuestCustomizationSection for Linux or Windows guests and
+// validates it before it is sent to VCD. Methods can be chained. Errors (e.g. an unreadable script
+// file) are collected and returned by Build.
+type GuestCustomizationBuilder struct {
+	section types.GuestCustomizationSection
+	windows bool
+	errors  []string
+}
+
+// NewLinuxGuestCustomization creates a builder with Linux defaults: customization enabled and an
+// auto-generated administrator (root) password
+func NewLinuxGuestCustomization(computerName string) *GuestCustomizationBuilder {
+	return &GuestCustomizationBuilder{
+		section: types.GuestCustomizationSection{
+			Enabled:               takeBoolPointer(true),
+			ChangeSid:             takeBoolPointer(false),
+			JoinDomainEnabled:     takeBoolPointer(false),
+			AdminPasswordEnabled:  takeBoolPointer(true),
+			AdminPasswordAuto:     takeBoolPointer(true),
+			AdminAutoLogonEnabled: takeBoolPointer(false),
+			ResetPasswordRequired: takeBoolPointer(false),
+			ComputerName:          computerName,
+		},
+	}
+}
+
+// NewWindowsGuestCustomization creates a builder with Windows defaults: customization enabled, SID
+// change, and an auto-generated administrator password which must be reset on first login
+func NewWindowsGuestCustomization(computerName string) *GuestCustomizationBuilder {
+	return &GuestCustomizationBuilder{
+		section: types.GuestCustomizationSection{
+			Enabled:               takeBoolPointer(true),
+			ChangeSid:             takeBoolPointer(true),
+			JoinDomainEnabled:     takeBoolPointer(false),
+			AdminPasswordEnabled:  takeBoolPointer(true),
+			AdminPasswordAuto:     takeBoolPointer(true),
+			AdminAutoLogonEnabled: takeBoolPointer(false),
+			ResetPasswordRequired: takeBoolPointer(true),
+			ComputerName:          computerName,
+		},
+		windows: true,
+	}
+}
+
+// WithAutoAdminPassword lets VCD generate the administrator password. The generated password can
+// be read from the guest customization section afterwards.
+func (builder *GuestCustomizationBuilder) WithAutoAdminPassword(resetOnFirstLogin bool) *GuestCustomizationBuilder {
+	builder.section.AdminPasswordEnabled = takeBoolPointer(true)
+	builder.section.AdminPasswordAuto = takeBoolPointer(true)
+	builder.section.AdminPassword = ""
+	builder.section.ResetPasswordRequired = takeBoolPointer(resetOnFirstLogin)
+	return builder
+}
+
+// WithAdminPassword sets the administrator password
+func (builder *GuestCustomizationBuilder) WithAdminPassword(password string, resetOnFirstLogin bool) *GuestCustomizationBuilder {
+	builder.section.AdminPasswordEnabled = takeBoolPointer(true)
+	builder.section.AdminPasswordAuto = takeBoolPointer(false)
+	builder.section.AdminPassword = password
+	builder.section.ResetPasswordRequired = takeBoolPointer(resetOnFirstLogin)
+	return builder
+}
+
+// WithoutAdminPassword leaves the administrator password of the guest unchanged
+func (builder *GuestCustomizationBuilder) WithoutAdminPassword() *GuestCustomizationBuilder {
+	builder.section.AdminPasswordEnabled = takeBoolPointer(false)
+	builder.section.AdminPasswordAuto = nil
+	builder.section.AdminPassword = ""
+	builder.section.ResetPasswordRequired = takeBoolPointer(false)
+	return builder
+}
+
+// WithAdminAutoLogon lets the administrator log in automatically the given number of times (1-100)
+func (builder *GuestCustomizationBuilder) WithAdminAutoLogon(count int) *GuestCustomizationBuilder {
+	builder.section.AdminAutoLogonEnabled = takeBoolPointer(true)
+	builder.section.AdminAutoLogonCount = count
+	return builder
+}
+
+// WithDomainJoin joins the guest to a Windows domain using the given credentials. machineObjectOU
+// is optional and defines the OU in which the computer account is created.
+func (builder *GuestCustomizationBuilder) WithDomainJoin(domainName, userName, password, machineObjectOU string) *GuestCustomizationBuilder {
+	builder.section.JoinDomainEnabled = takeBoolPointer(true)
+	builder.section.UseOrgSettings = takeBoolPointer(false)
+	builder.section.DomainName = domainName
+	builder.section.DomainUserName = userName
+	builder.section.DomainUserPassword = password
+	builder.section.MachineObjectOU = machineObjectOU
+	return builder
+}
+
+// WithOrgDomainJoin joins the guest to the Windows domain defined in the Org guest personalization
+// settings
+func (builder *GuestCustomizationBuilder) WithOrgDomainJoin() *GuestCustomizationBuilder {
+	builder.section.JoinDomainEnabled = takeBoolPointer(true)
+	builder.section.UseOrgSettings = takeBoolPointer(true)
+	builder.section.DomainName = ""
+	builder.section.DomainUserName = ""
+	builder.section.DomainUserPassword = ""
+	builder.section.MachineObjectOU = ""
+	return builder
+}
+
+// WithChangeSid defines whether customization changes the Windows SID
+func (builder *GuestCustomizationBuilder) WithChangeSid(changeSid bool) *GuestCustomizationBuilder {
+	builder.section.ChangeSid = takeBoolPointer(changeSid)
+	return builder
+}
+
+// WithScript sets the customization script. Line endings are normalized to CRLF for Windows and
+// to LF for Linux.
+func (builder *GuestCustomizationBuilder) WithScript(script string) *GuestCustomizationBuilder {
+	builder.section.CustomizationScript = normalizeCustomizationScript(script, builder.windows)
+	return builder
+}
+
+// WithScriptFile reads the customization script from a file. See WithScript.
+func (builder *GuestCustomizationBuilder) WithScriptFile(fileName string) *GuestCustomizationBuilder {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		builder.errors = append(builder.errors, fmt.Sprintf("error reading customization script: %s", err))
+		return builder
+	}
+	if !utf8.Valid(content) {
+		builder.errors = append(builder.errors, fmt.Sprintf("customization script %s is not a text file", fileName))
+		return builder
+	}
+	return builder.WithScript(string(content))
+}
+
+// Validate checks the settings for consistency without building the section
+func (builder *GuestCustomizationBuilder) Validate() error {
+	var errors []string
+	errors = append(errors, builder.errors...)
+	errors = append(errors, validateGuestCustomization(&builder.section, builder.windows)...)
+	if len(errors) > 0 {
+		return fmt.Errorf("invalid guest customization: %s", strings.Join(errors, "; "))
+	}
+	return nil
+}
+
+// Build validates the settings and returns the guest customization section
+func (builder *GuestCustomizationBuilder) Build() (*types.GuestCustomizationSection, error) {
+	err := builder.Validate()
+	if err != nil {
+		return nil, err
+	}
+	section := builder.section
+	section.Info = "Specifies Guest OS Customization Settings"
+	return &section, nil
+}
+
+// ApplyGuestCustomization validates the builder settings and sets them as guest customization
+// section of the VM. Customization runs the next time the VM is powered on with customization (see
+// PowerOnAndForceCustomization). Use WaitForGuestCustomization to wait for the result.
+func (vm *VM) ApplyGuestCustomization(ctx context.Context, builder *GuestCustomizationBuilder) (*types.GuestCustomizationSection, error) {
+	if builder == nil {
+		return nil, fmt.Errorf("guest customization builder must not be nil")
+	}
+	section, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	return vm.SetGuestCustomizationSection(ctx, section)
+}
+
+// WaitForGuestCustomization waits until guest customization of the VM finishes and returns the
+// final status (types.GuestCustStatusComplete). It returns an error if customization failed or
+// did not finish within timeOutAfterSeconds.
+func (vm *VM) WaitForGuestCustomization(ctx context.Context, timeOutAfterSeconds int) (string, error) {
+	deadline := time.Now().Add(time.Duration(timeOutAfterSeconds) * time.Second)
+	for {
+		status, err := vm.GetGuestCustomizationStatus(ctx)
+		if err != nil {
+			return "", fmt.Errorf("could not get VM customization status: %s", err)
+		}
+
+		done, err := parseGuestCustomizationStatus(status)
+		if done || err != nil {
+			return status, err
+		}
+
+		remaining := int(time.Until(deadline).Seconds())
+		if remaining < 5 {
+			return status, fmt.Errorf("timed out waiting for guest customization of VM %s, last status %s",
+				vm.VM.Name, status)
+		}
+		err = vm.BlockWhileGuestCustomizationStatus(ctx, status, remaining)
+		if err != nil {
+			return status, err
+		}
+	}
+}
+
+// parseGuestCustomizationStatus reports whether a guest customization status is final. It returns
+// an error if customization failed or the status is unknown.
+func parseGuestCustomizationStatus(status string) (bool, error) {
+	switch status {
+	case types.GuestCustStatusComplete:
+		return true, nil
+	case types.GuestCustStatusFailed:
+		return true, fmt.Errorf("guest customization failed")
+	case types.GuestCustStatusPending, types.GuestCustStatusPostPending, types.GuestCustStatusRebootPending:
+		return false, nil
+	}
+	return true, fmt.Errorf("unknown guest customization status '%s'", status)
+}
+
+// normalizeCustomizationScript converts line endings to CRLF for Windows and to LF for Linux
+func normalizeCustomizationScript(script string, windows bool) string {
+	script = strings.ReplaceAll(script, "\r\n", "\n")
+	if windows {
+		script = strings.ReplaceAll(script, "\n", "\r\n")
+	}
+	return script
+}
+
+// validateGuestCustomization returns the list of problems found in a guest customization section
+func validateGuestCustomization(section *types.GuestCustomizationSection, windows bool) []string {
+	var errors []string
+	isTrue := func(value *bool) bool { return value != nil && *value }
+
+	maxNameLength := maxLinuxComputerNameLength
+	if windows {
+		maxNameLength = maxWindowsComputerNameLength
+	}
+	switch {
+	case section.ComputerName == "":
+		errors = append(errors, "computer name must be set")
+	case len(section.ComputerName) > maxNameLength:
+		errors = append(errors, fmt.Sprintf("computer name '%s' is longer than %d characters", section.ComputerName, maxNameLength))
+	case !reComputerName.MatchString(section.ComputerName):
+		errors = append(errors, fmt.Sprintf("computer name '%s' may only contain letters, digits and hyphens and must not start or end with a hyphen",
+			section.ComputerName))
+	case reDigitsOnly.MatchString(section.ComputerName):
+		errors = append(errors, fmt.Sprintf("computer name '%s' must not consist of digits only", section.ComputerName))
+	}
+
+	if isTrue(section.AdminPasswordEnabled) {
+		if isTrue(section.AdminPasswordAuto) && section.AdminPassword != "" {
+			errors = append(errors, "admin password can't be set when it is auto-generated")
+		}
+		if !isTrue(section.AdminPasswordAuto) && section.AdminPassword == "" {
+			errors = append(errors, "admin password must be set or auto-generated")
+		}
+	} else if isTrue(section.ResetPasswordRequired) || section.AdminPassword != "" {
+		errors = append(errors, "admin password settings require admin password to be enabled")
+	}
+
+	if isTrue(section.AdminAutoLogonEnabled) {
+		if section.AdminAutoLogonCount < 1 || section.AdminAutoLogonCount > 100 {
+			errors = append(errors, fmt.Sprintf("admin auto logon count must be between 1 and 100, got %d", section.AdminAutoLogonCount))
+		}
+		if !isTrue(section.AdminPasswordEnabled) {
+			errors = append(errors, "admin auto logon requires admin password to be enabled")
+		}
+	}
+
+	if isTrue(section.JoinDomainEnabled) {
+		if !windows {
+			errors = append(errors, "domain join is only supported for Windows guests")
+		}
+		if !isTrue(section.UseOrgSettings) {
+			if section.DomainName == "" {
+				errors = append(errors, "domain name must be set to join a domain")
+			}
+			if section.DomainUserName == "" || section.DomainUserPassword == "" {
+				errors = append(errors, "domain user name and password must be set to join a domain")
+			}
+		}
+	}
+
+	if isTrue(section.ChangeSid) && !windows {
+		errors = append(errors, "SID change is only supported for Windows guests")
+	}
+
+	script := section.CustomizationScript
+	if script != "" {
+		if len(script) > maxCustomizationScriptLength {
+			errors = append(errors, fmt.Sprintf("customization script is %d bytes long, maximum is %d",
+				len(script), maxCustomizationScriptLength))
+		}
+		if strings.ContainsRune(script, 0) {
+			errors = append(errors, "customization script must not contain NUL characters")
+		}
+		if !windows && !strings.HasPrefix(script, "#!") {
+			errors = append(errors, "Linux customization script must start with an interpreter line (e.g. #!/bin/sh)")
+		}
+		if windows && strings.HasPrefix(script, "#!") {
+			errors = append(errors, "Windows customization script must be a batch script, not a script with an interpreter line")
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,133 @@
+// +build vm unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_GuestCustomizationBuilder(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *GuestCustomizationBuilder
+		wantErr bool
+	}{
+		{name: "LinuxDefaults", builder: NewLinuxGuestCustomization("web-01")},
+		{name: "WindowsDefaults", builder: NewWindowsGuestCustomization("WIN-01")},
+		{name: "WindowsDomainJoin", builder: NewWindowsGuestCustomization("WIN-01").
+			WithDomainJoin("corp.example.com", "joiner", "secret", "OU=Servers,DC=corp,DC=example,DC=com")},
+		{name: "WindowsOrgDomainJoin", builder: NewWindowsGuestCustomization("WIN-01").WithOrgDomainJoin()},
+		{name: "WindowsAutoLogon", builder: NewWindowsGuestCustomization("WIN-01").
+			WithAdminPassword("Pa55w0rd", false).WithAdminAutoLogon(3)},
+		{name: "LinuxScript", builder: NewLinuxGuestCustomization("web-01").WithScript("#!/bin/sh\necho hello\n")},
+		{name: "WindowsScript", builder: NewWindowsGuestCustomization("WIN-01").WithScript("@echo off\necho hello\n")},
+		{name: "LinuxNoAdminPassword", builder: NewLinuxGuestCustomization("web-01").WithoutAdminPassword()},
+		{name: "EmptyComputerName", builder: NewLinuxGuestCustomization(""), wantErr: true},
+		{name: "LongWindowsComputerName", builder: NewWindowsGuestCustomization("WINDOWS-SERVER-01"), wantErr: true},
+		{name: "InvalidComputerName", builder: NewLinuxGuestCustomization("web_01"), wantErr: true},
+		{name: "HyphenComputerName", builder: NewLinuxGuestCustomization("-web"), wantErr: true},
+		{name: "NumericComputerName", builder: NewLinuxGuestCustomization("12345"), wantErr: true},
+		{name: "EmptyAdminPassword", builder: NewLinuxGuestCustomization("web-01").WithAdminPassword("", false),
+			wantErr: true},
+		{name: "AutoLogonOutOfRange", builder: NewWindowsGuestCustomization("WIN-01").WithAdminAutoLogon(101),
+			wantErr: true},
+		{name: "AutoLogonWithoutPassword", builder: NewWindowsGuestCustomization("WIN-01").WithoutAdminPassword().
+			WithAdminAutoLogon(1), wantErr: true},
+		{name: "LinuxDomainJoin", builder: NewLinuxGuestCustomization("web-01").
+			WithDomainJoin("corp.example.com", "joiner", "secret", ""), wantErr: true},
+		{name: "DomainJoinWithoutCredentials", builder: NewWindowsGuestCustomization("WIN-01").
+			WithDomainJoin("corp.example.com", "", "", ""), wantErr: true},
+		{name: "LinuxChangeSid", builder: NewLinuxGuestCustomization("web-01").WithChangeSid(true), wantErr: true},
+		{name: "LinuxScriptWithoutShebang", builder: NewLinuxGuestCustomization("web-01").WithScript("echo hello"),
+			wantErr: true},
+		{name: "WindowsScriptWithShebang", builder: NewWindowsGuestCustomization("WIN-01").WithScript("#!/bin/sh"),
+			wantErr: true},
+		{name: "ScriptTooLong", builder: NewLinuxGuestCustomization("web-01").
+			WithScript("#!/bin/sh\n" + strings.Repeat("#", maxCustomizationScriptLength)), wantErr: true},
+		{name: "MissingScriptFile", builder: NewLinuxGuestCustomization("web-01").WithScriptFile("/non/existing/file"),
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (section.Enabled == nil || !*section.Enabled) {
+				t.Errorf("Build() returned section with customization disabled")
+			}
+		})
+	}
+}
+
+func Test_GuestCustomizationBuilderScriptFile(t *testing.T) {
+	scriptFile, err := ioutil.TempFile("", "customization-*.cmd")
+	if err != nil {
+		t.Fatalf("error creating temporary file: %s", err)
+	}
+	defer os.Remove(scriptFile.Name())
+	_, err = scriptFile.WriteString("@echo off\necho first\r\necho second\n")
+	if err != nil {
+		t.Fatalf("error writing temporary file: %s", err)
+	}
+	_ = scriptFile.Close()
+
+	section, err := NewWindowsGuestCustomization("WIN-01").WithScriptFile(scriptFile.Name()).Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %s", err)
+	}
+	expected := "@echo off\r\necho first\r\necho second\r\n"
+	if section.CustomizationScript != expected {
+		t.Errorf("Windows script = %q, want %q", section.CustomizationScript, expected)
+	}
+
+	section, err = NewLinuxGuestCustomization("web-01").WithScript("#!/bin/sh\r\necho first\r\n").Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %s", err)
+	}
+	if section.CustomizationScript != "#!/bin/sh\necho first\n" {
+		t.Errorf("Linux script = %q, want LF line endings", section.CustomizationScript)
+	}
+
+	// Binary content is rejected
+	err = ioutil.WriteFile(scriptFile.Name(), []byte{0xff, 0xfe, 0x00, 0x01}, 0600)
+	if err != nil {
+		t.Fatalf("error writing temporary file: %s", err)
+	}
+	_, err = NewWindowsGuestCustomization("WIN-01").WithScriptFile(scriptFile.Name()).Build()
+	if err == nil {
+		t.Errorf("Build() expected error for binary script file")
+	}
+}
+
+func Test_parseGuestCustomizationStatus(t *testing.T) {
+	tests := []struct {
+		status   string
+		wantDone bool
+		wantErr  bool
+	}{
+		{status: types.GuestCustStatusComplete, wantDone: true},
+		{status: types.GuestCustStatusFailed, wantDone: true, wantErr: true},
+		{status: types.GuestCustStatusPending},
+		{status: types.GuestCustStatusPostPending},
+		{status: types.GuestCustStatusRebootPending},
+		{status: "UNKNOWN", wantDone: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			done, err := parseGuestCustomizationStatus(tt.status)
+			if done != tt.wantDone || (err != nil) != tt.wantErr {
+				t.Errorf("parseGuestCustomizationStatus() = %t, %v, want %t, error %t", done, err, tt.wantDone, tt.wantErr)
+			}
+		})
+	}
+}
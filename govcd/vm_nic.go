@@ -0,0 +1,284 @@
+package govcd
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// NicSettings defines the configuration of a single VM NIC. It is used by VM.AddNic and VM.UpdateNic
+// as a typed alternative to the map based VM.ChangeNetworkConfig.
+type NicSettings struct {
+	// Network is the name of the vApp network to connect the NIC to. It is ignored when
+	// IpAllocationMode is types.IPAllocationModeNone, in which case the NIC is not connected.
+	Network string
+	// IpAllocationMode is one of types.IPAllocationModeDHCP, types.IPAllocationModePool,
+	// types.IPAllocationModeManual or types.IPAllocationModeNone
+	IpAllocationMode string
+	// IpAddress must be set for types.IPAllocationModeManual only
+	IpAddress string
+	// MacAddress is optional. VCD generates one if it is not set
+	MacAddress string
+	// AdapterType is optional (e.g. types.NetworkAdapterTypeVmxnet3). VCD uses the default adapter
+	// type of the guest OS if it is not set
+	AdapterType string
+	// IsConnected defines whether the NIC is connected on power on
+	IsConnected bool
+	// NeedsCustomization requests guest customization to configure this NIC
+	NeedsCustomization bool
+}
+
+// AddNic adds a NIC with the given settings to the VM using the lowest free NIC index. The new NIC
+// becomes primary if the VM had no NICs. It returns the index of the new NIC.
+func (vm *VM) AddNic(ctx context.Context, nic NicSettings) (int, error) {
+	networkSection, err := vm.getNetworkConnectionSectionForNicUpdate(ctx, nic)
+	if err != nil {
+		return -1, err
+	}
+
+	index := addNicToSection(networkSection, nic)
+
+	err = vm.UpdateNetworkConnectionSection(ctx, networkSection)
+	if err != nil {
+		return -1, fmt.Errorf("error adding NIC to VM %s: %s", vm.VM.Name, err)
+	}
+	return index, nil
+}
+
+// UpdateNic replaces the settings of the NIC with the given index
+func (vm *VM) UpdateNic(ctx context.Context, index int, nic NicSettings) error {
+	networkSection, err := vm.getNetworkConnectionSectionForNicUpdate(ctx, nic)
+	if err != nil {
+		return err
+	}
+
+	err = updateNicInSection(networkSection, index, nic)
+	if err != nil {
+		return err
+	}
+
+	err = vm.UpdateNetworkConnectionSection(ctx, networkSection)
+	if err != nil {
+		return fmt.Errorf("error updating NIC %d of VM %s: %s", index, vm.VM.Name, err)
+	}
+	return nil
+}
+
+// RemoveNic removes the NIC with the given index. If it was the primary NIC, the remaining NIC
+// with the lowest index becomes primary.
+func (vm *VM) RemoveNic(ctx context.Context, index int) error {
+	networkSection, err := vm.GetNetworkConnectionSection(ctx)
+	if err != nil {
+		return fmt.Errorf("could not retrieve network connection for VM: %s", err)
+	}
+
+	err = removeNicFromSection(networkSection, index)
+	if err != nil {
+		return err
+	}
+
+	err = vm.UpdateNetworkConnectionSection(ctx, networkSection)
+	if err != nil {
+		return fmt.Errorf("error removing NIC %d from VM %s: %s", index, vm.VM.Name, err)
+	}
+	return nil
+}
+
+// SetPrimaryNic makes the NIC with the given index the primary NIC of the VM
+func (vm *VM) SetPrimaryNic(ctx context.Context, index int) error {
+	networkSection, err := vm.GetNetworkConnectionSection(ctx)
+	if err != nil {
+		return fmt.Errorf("could not retrieve network connection for VM: %s", err)
+	}
+
+	if findNicInSection(networkSection, index) == nil {
+		return fmt.Errorf("%s: NIC with index %d in VM %s", ErrorEntityNotFound, index, vm.VM.Name)
+	}
+	if networkSection.PrimaryNetworkConnectionIndex == index {
+		return nil
+	}
+	networkSection.PrimaryNetworkConnectionIndex = index
+
+	err = vm.UpdateNetworkConnectionSection(ctx, networkSection)
+	if err != nil {
+		return fmt.Errorf("error setting primary NIC %d of VM %s: %s", index, vm.VM.Name, err)
+	}
+	return nil
+}
+
+// getNetworkConnectionSectionForNicUpdate validates NIC settings against the networks of the
+// parent vApp and returns the current network connection section of the VM
+func (vm *VM) getNetworkConnectionSectionForNicUpdate(ctx context.Context, nic NicSettings) (*types.NetworkConnectionSection, error) {
+	var availableNetworks []string
+	if nic.IpAllocationMode != types.IPAllocationModeNone {
+		vapp, err := vm.GetParentVApp(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve parent vApp of VM %s: %s", vm.VM.Name, err)
+		}
+		networkConfig, err := vapp.GetNetworkConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve networks of vApp %s: %s", vapp.VApp.Name, err)
+		}
+		availableNetworks = networkConfig.NetworkNames()
+	}
+
+	err := validateNicSettings(nic, availableNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	networkSection, err := vm.GetNetworkConnectionSection(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve network connection for VM: %s", err)
+	}
+	return networkSection, nil
+}
+
+// validateNicSettings checks NIC settings before they are sent to VCD. availableNetworks contains
+// the names of vApp networks the NIC can be connected to.
+func validateNicSettings(nic NicSettings, availableNetworks []string) error {
+	switch nic.IpAllocationMode {
+	case types.IPAllocationModeDHCP, types.IPAllocationModePool, types.IPAllocationModeNone:
+		if nic.IpAddress != "" {
+			return fmt.Errorf("IP address can only be set for IP allocation mode %s", types.IPAllocationModeManual)
+		}
+	case types.IPAllocationModeManual:
+		if net.ParseIP(nic.IpAddress) == nil {
+			return fmt.Errorf("IP allocation mode %s requires a valid IP address, got '%s'",
+				types.IPAllocationModeManual, nic.IpAddress)
+		}
+	default:
+		return fmt.Errorf("invalid IP allocation mode '%s'", nic.IpAllocationMode)
+	}
+
+	if nic.IpAllocationMode != types.IPAllocationModeNone && !stringInSlice(nic.Network, availableNetworks) {
+		return fmt.Errorf("network '%s' is not available in the vApp (available: %s)", nic.Network,
+			strings.Join(availableNetworks, ", "))
+	}
+
+	if nic.MacAddress != "" {
+		mac, err := net.ParseMAC(nic.MacAddress)
+		if err != nil || len(mac) != 6 {
+			return fmt.Errorf("invalid MAC address '%s'", nic.MacAddress)
+		}
+	}
+
+	if nic.AdapterType != "" && normalizeNetworkAdapterType(nic.AdapterType) == "" {
+		return fmt.Errorf("invalid network adapter type '%s'", nic.AdapterType)
+	}
+	return nil
+}
+
+// normalizeNetworkAdapterType returns the canonical spelling of a network adapter type or an empty
+// string if it is not known
+func normalizeNetworkAdapterType(adapterType string) string {
+	for _, knownType := range []string{
+		types.NetworkAdapterTypeVmxnet3,
+		types.NetworkAdapterTypeVmxnet2,
+		types.NetworkAdapterTypeVmxnet,
+		types.NetworkAdapterTypeE1000,
+		types.NetworkAdapterTypeE1000E,
+		types.NetworkAdapterTypePcnet32,
+		types.NetworkAdapterTypeSriov,
+		types.NetworkAdapterTypeFlexible,
+	} {
+		if strings.EqualFold(adapterType, knownType) {
+			return knownType
+		}
+	}
+	return ""
+}
+
+// nicSettingsToNetworkConnection converts NicSettings into a NetworkConnection with the given index
+func nicSettingsToNetworkConnection(index int, nic NicSettings) *types.NetworkConnection {
+	networkName := nic.Network
+	if nic.IpAllocationMode == types.IPAllocationModeNone {
+		networkName = types.NoneNetwork
+	}
+	return &types.NetworkConnection{
+		Network:                 networkName,
+		NeedsCustomization:      nic.NeedsCustomization,
+		NetworkConnectionIndex:  index,
+		IPAddress:               nic.IpAddress,
+		IsConnected:             nic.IsConnected,
+		MACAddress:              strings.ToLower(nic.MacAddress),
+		IPAddressAllocationMode: nic.IpAllocationMode,
+		NetworkAdapterType:      normalizeNetworkAdapterType(nic.AdapterType),
+	}
+}
+
+// findNicInSection returns the NIC with the given index or nil if it does not exist
+func findNicInSection(networkSection *types.NetworkConnectionSection, index int) *types.NetworkConnection {
+	for _, nic := range networkSection.NetworkConnection {
+		if nic.NetworkConnectionIndex == index {
+			return nic
+		}
+	}
+	return nil
+}
+
+// addNicToSection appends a NIC using the lowest free index and returns that index
+func addNicToSection(networkSection *types.NetworkConnectionSection, nic NicSettings) int {
+	index := 0
+	for findNicInSection(networkSection, index) != nil {
+		index++
+	}
+	if len(networkSection.NetworkConnection) == 0 {
+		networkSection.PrimaryNetworkConnectionIndex = index
+	}
+	networkSection.NetworkConnection = append(networkSection.NetworkConnection, nicSettingsToNetworkConnection(index, nic))
+	return index
+}
+
+// updateNicInSection replaces the NIC with the given index. The MAC address and adapter type of
+// the existing NIC are kept when they are not set in nic.
+func updateNicInSection(networkSection *types.NetworkConnectionSection, index int, nic NicSettings) error {
+	for i, existingNic := range networkSection.NetworkConnection {
+		if existingNic.NetworkConnectionIndex != index {
+			continue
+		}
+		updatedNic := nicSettingsToNetworkConnection(index, nic)
+		if updatedNic.MACAddress == "" {
+			updatedNic.MACAddress = existingNic.MACAddress
+		}
+		if updatedNic.NetworkAdapterType == "" {
+			updatedNic.NetworkAdapterType = existingNic.NetworkAdapterType
+		}
+		networkSection.NetworkConnection[i] = updatedNic
+		return nil
+	}
+	return fmt.Errorf("%s: NIC with index %d", ErrorEntityNotFound, index)
+}
+
+// removeNicFromSection removes the NIC with the given index and moves the primary NIC to the
+// lowest remaining index if needed
+func removeNicFromSection(networkSection *types.NetworkConnectionSection, index int) error {
+	var remaining []*types.NetworkConnection
+	for _, nic := range networkSection.NetworkConnection {
+		if nic.NetworkConnectionIndex != index {
+			remaining = append(remaining, nic)
+		}
+	}
+	if len(remaining) == len(networkSection.NetworkConnection) {
+		return fmt.Errorf("%s: NIC with index %d", ErrorEntityNotFound, index)
+	}
+	networkSection.NetworkConnection = remaining
+
+	if networkSection.PrimaryNetworkConnectionIndex == index && len(remaining) > 0 {
+		lowestIndex := remaining[0].NetworkConnectionIndex
+		for _, nic := range remaining {
+			if nic.NetworkConnectionIndex < lowestIndex {
+				lowestIndex = nic.NetworkConnectionIndex
+			}
+		}
+		networkSection.PrimaryNetworkConnectionIndex = lowestIndex
+	}
+	return nil
+}
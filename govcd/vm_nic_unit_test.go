@@ -0,0 +1,109 @@
+// +build vm unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_validateNicSettings(t *testing.T) {
+	availableNetworks := []string{"vapp-net", "org-net"}
+	tests := []struct {
+		name    string
+		nic     NicSettings
+		wantErr bool
+	}{
+		{name: "Dhcp", nic: NicSettings{Network: "vapp-net", IpAllocationMode: types.IPAllocationModeDHCP}},
+		{name: "Pool", nic: NicSettings{Network: "org-net", IpAllocationMode: types.IPAllocationModePool,
+			MacAddress: "00:50:56:01:29:C8", AdapterType: "vmxnet3"}},
+		{name: "Manual", nic: NicSettings{Network: "org-net", IpAllocationMode: types.IPAllocationModeManual,
+			IpAddress: "192.168.1.10"}},
+		{name: "NoneWithoutNetwork", nic: NicSettings{IpAllocationMode: types.IPAllocationModeNone}},
+		{name: "InvalidMode", nic: NicSettings{Network: "vapp-net", IpAllocationMode: "STATIC"}, wantErr: true},
+		{name: "ManualWithoutIp", nic: NicSettings{Network: "vapp-net", IpAllocationMode: types.IPAllocationModeManual},
+			wantErr: true},
+		{name: "ManualWithInvalidIp", nic: NicSettings{Network: "vapp-net", IpAllocationMode: types.IPAllocationModeManual,
+			IpAddress: "192.168.1"}, wantErr: true},
+		{name: "PoolWithIp", nic: NicSettings{Network: "vapp-net", IpAllocationMode: types.IPAllocationModePool,
+			IpAddress: "192.168.1.10"}, wantErr: true},
+		{name: "UnknownNetwork", nic: NicSettings{Network: "other-net", IpAllocationMode: types.IPAllocationModeDHCP},
+			wantErr: true},
+		{name: "InvalidMac", nic: NicSettings{Network: "vapp-net", IpAllocationMode: types.IPAllocationModeDHCP,
+			MacAddress: "00:50:56:01"}, wantErr: true},
+		{name: "LongMac", nic: NicSettings{Network: "vapp-net", IpAllocationMode: types.IPAllocationModeDHCP,
+			MacAddress: "00:00:00:00:fe:80:00:00"}, wantErr: true},
+		{name: "InvalidAdapter", nic: NicSettings{Network: "vapp-net", IpAllocationMode: types.IPAllocationModeDHCP,
+			AdapterType: "RTL8139"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNicSettings(tt.nic, availableNetworks)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateNicSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_NicSectionOperations(t *testing.T) {
+	networkSection := &types.NetworkConnectionSection{}
+
+	// First NIC becomes primary
+	index := addNicToSection(networkSection, NicSettings{Network: "vapp-net", IpAllocationMode: types.IPAllocationModePool,
+		AdapterType: "e1000e"})
+	if index != 0 || networkSection.PrimaryNetworkConnectionIndex != 0 {
+		t.Fatalf("expected first NIC with index 0 to be primary, got index %d, primary %d", index,
+			networkSection.PrimaryNetworkConnectionIndex)
+	}
+	if networkSection.NetworkConnection[0].NetworkAdapterType != types.NetworkAdapterTypeE1000E {
+		t.Errorf("adapter type was not normalized: %s", networkSection.NetworkConnection[0].NetworkAdapterType)
+	}
+
+	index = addNicToSection(networkSection, NicSettings{IpAllocationMode: types.IPAllocationModeNone})
+	if index != 1 || networkSection.NetworkConnection[1].Network != types.NoneNetwork {
+		t.Fatalf("expected second NIC with index 1 on network %s, got %d on %s", types.NoneNetwork, index,
+			networkSection.NetworkConnection[1].Network)
+	}
+
+	// Update keeps the existing adapter type and MAC address when they are not given
+	networkSection.NetworkConnection[0].MACAddress = "00:50:56:01:29:c8"
+	err := updateNicInSection(networkSection, 0, NicSettings{Network: "org-net", IpAllocationMode: types.IPAllocationModeManual,
+		IpAddress: "192.168.1.10"})
+	if err != nil {
+		t.Fatalf("updateNicInSection() unexpected error: %s", err)
+	}
+	updated := findNicInSection(networkSection, 0)
+	if updated.Network != "org-net" || updated.MACAddress != "00:50:56:01:29:c8" ||
+		updated.NetworkAdapterType != types.NetworkAdapterTypeE1000E || updated.IPAddress != "192.168.1.10" {
+		t.Errorf("unexpected updated NIC %+v", updated)
+	}
+	if updateNicInSection(networkSection, 5, NicSettings{}) == nil {
+		t.Errorf("updateNicInSection() expected error for missing NIC")
+	}
+
+	// Removing the primary NIC moves primary to the lowest remaining index
+	err = removeNicFromSection(networkSection, 0)
+	if err != nil {
+		t.Fatalf("removeNicFromSection() unexpected error: %s", err)
+	}
+	if len(networkSection.NetworkConnection) != 1 || networkSection.PrimaryNetworkConnectionIndex != 1 {
+		t.Errorf("expected one NIC with primary index 1, got %d NICs with primary %d",
+			len(networkSection.NetworkConnection), networkSection.PrimaryNetworkConnectionIndex)
+	}
+	if removeNicFromSection(networkSection, 0) == nil {
+		t.Errorf("removeNicFromSection() expected error for missing NIC")
+	}
+
+	// The freed index is reused
+	index = addNicToSection(networkSection, NicSettings{Network: "vapp-net", IpAllocationMode: types.IPAllocationModeDHCP})
+	if index != 0 || networkSection.PrimaryNetworkConnectionIndex != 1 {
+		t.Errorf("expected new NIC with index 0 and primary 1, got index %d and primary %d", index,
+			networkSection.PrimaryNetworkConnectionIndex)
+	}
+}
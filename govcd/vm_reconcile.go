@@ -0,0 +1,546 @@
+package govcd
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// DesiredVmSpec describes the desired hardware configuration of a VM for VM.Reconcile and
+// VM.PlanReconcile. Fields which are nil (or empty) are left unchanged.
+type DesiredVmSpec struct {
+	NumCpus             *int
+	NumCoresPerSocket   *int
+	MemoryMb            *int64
+	CpuHotAddEnabled    *bool
+	MemoryHotAddEnabled *bool
+
+	// InternalDisks is the complete list of desired internal disks. Disks are matched by DiskId.
+	// Disks without DiskId are created and existing disks which are not listed are removed.
+	// Shrinking a disk is not supported.
+	InternalDisks []*types.DiskSettings
+
+	// Nics is the complete list of desired NICs. The position in the slice is the NIC index.
+	Nics []NicSettings
+	// PrimaryNicIndex sets the primary NIC. When nil, the current primary NIC is kept if it still
+	// exists, otherwise NIC 0 becomes primary.
+	PrimaryNicIndex *int
+
+	StorageProfile     *types.Reference
+	ComputePolicyId    string // ID of a VM sizing policy
+	GuestCustomization *types.GuestCustomizationSection
+
+	// ForcePowerOff powers the VM off without shutting down the guest OS when a change can't be
+	// applied to a running VM. By default the guest OS is shut down, which requires VMware Tools.
+	ForcePowerOff bool
+}
+
+// Steps of a VmReconcilePlan, in the order in which they are executed
+const (
+	VmReconcileStepShutdown              = "shut down guest OS"
+	VmReconcileStepPowerOff              = "power off"
+	VmReconcileStepDiscardSuspendedState = "discard suspended state" // The VM is left powered off
+	VmReconcileStepUpdateCapabilities    = "update CPU and memory hot add"
+	VmReconcileStepReconfigure           = "reconfigure VM"
+	VmReconcileStepPowerOn               = "power on"
+)
+
+// VmReconcilePlan is the result of comparing a DesiredVmSpec with the current VM configuration.
+// It can be inspected before being applied (dry run).
+type VmReconcilePlan struct {
+	// Changes contains a human readable description of each detected difference
+	Changes []string
+	// PowerOffRequired is true when some changes can't be applied to a running or suspended VM
+	PowerOffRequired bool
+	// Steps lists the operations which are executed, in order. All compatible changes are batched
+	// into a single VmReconcileStepReconfigure.
+	Steps []string
+
+	capabilities    *types.VmCapabilities
+	reconfigure     *types.Vm
+	computePolicyId string
+}
+
+// IsEmpty returns true if the VM already matches the desired specification
+func (plan *VmReconcilePlan) IsEmpty() bool {
+	return len(plan.Steps) == 0
+}
+
+// PlanReconcile compares desired with the current VM configuration and returns the plan which
+// VM.Reconcile would execute, without changing the VM
+func (vm *VM) PlanReconcile(ctx context.Context, desired DesiredVmSpec) (*VmReconcilePlan, error) {
+	err := vm.Refresh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing VM: %s", err)
+	}
+	if vm.VM.VmSpecSection == nil {
+		return nil, fmt.Errorf("VM %s has no VmSpecSection. Reconcile requires at least VCD 9.7", vm.VM.Name)
+	}
+
+	var currentNetwork *types.NetworkConnectionSection
+	var availableNetworks []string
+	if desired.Nics != nil {
+		currentNetwork, err = vm.GetNetworkConnectionSection(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve network connection for VM: %s", err)
+		}
+		vapp, err := vm.GetParentVApp(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve parent vApp of VM %s: %s", vm.VM.Name, err)
+		}
+		networkConfig, err := vapp.GetNetworkConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve networks of vApp %s: %s", vapp.VApp.Name, err)
+		}
+		availableNetworks = networkConfig.NetworkNames()
+	}
+
+	return planVmReconcile(vm.VM, currentNetwork, availableNetworks, types.VAppStatuses[vm.VM.Status], desired)
+}
+
+// Reconcile brings the VM to the desired specification. Compatible changes are batched into one
+// reconfigure operation. The VM is shut down only when a change can't be applied while it is
+// running, and powered on again afterwards, even when a change fails. The suspended state of a
+// suspended VM is discarded when such a change is needed, and the VM is left powered off. It returns
+// the executed plan.
+func (vm *VM) Reconcile(ctx context.Context, desired DesiredVmSpec) (*VmReconcilePlan, error) {
+	plan, err := vm.PlanReconcile(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	err = vm.ApplyReconcilePlan(ctx, plan)
+	if err != nil {
+		return plan, err
+	}
+	return plan, nil
+}
+
+// ApplyReconcilePlan executes a plan returned by VM.PlanReconcile. When a step fails after the VM
+// was shut down, the VM is powered on again before returning the error.
+func (vm *VM) ApplyReconcilePlan(ctx context.Context, plan *VmReconcilePlan) error {
+	if plan == nil {
+		return fmt.Errorf("reconcile plan must not be nil")
+	}
+
+	poweredOff := false
+	for _, step := range plan.Steps {
+		err := vm.applyReconcileStep(ctx, plan, step)
+		if err != nil {
+			// Don't leave a previously running VM powered off
+			if plan.PowerOffRequired && poweredOff && step != VmReconcileStepPowerOn {
+				powerOnErr := vm.applyReconcileStep(ctx, plan, VmReconcileStepPowerOn)
+				if powerOnErr != nil {
+					return fmt.Errorf("%s. The VM could not be powered on again: %s", err, powerOnErr)
+				}
+			}
+			return err
+		}
+		if step == VmReconcileStepShutdown || step == VmReconcileStepPowerOff {
+			poweredOff = true
+		}
+	}
+
+	return vm.Refresh(ctx)
+}
+
+// applyReconcileStep executes one step of a plan and waits for its completion
+func (vm *VM) applyReconcileStep(ctx context.Context, plan *VmReconcilePlan, step string) error {
+	var task Task
+	var err error
+	switch step {
+	case VmReconcileStepShutdown:
+		task, err = vm.Shutdown(ctx)
+	case VmReconcileStepPowerOff:
+		task, err = vm.PowerOff(ctx)
+	case VmReconcileStepDiscardSuspendedState:
+		task, err = vm.DiscardSuspendedState(ctx)
+	case VmReconcileStepUpdateCapabilities:
+		task, err = vm.UpdateVmCpuAndMemoryHotAddAsync(ctx, plan.capabilities.CPUHotAddEnabled,
+			plan.capabilities.MemoryHotAddEnabled)
+	case VmReconcileStepReconfigure:
+		task, err = vm.reconfigureForPlan(ctx, plan)
+	case VmReconcileStepPowerOn:
+		task, err = vm.PowerOn(ctx)
+	default:
+		return fmt.Errorf("unknown reconcile step '%s'", step)
+	}
+	if err != nil {
+		return fmt.Errorf("error performing '%s' on VM %s: %s", step, vm.VM.Name, err)
+	}
+	err = task.WaitTaskCompletion(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for '%s' on VM %s: %s", step, vm.VM.Name, err)
+	}
+	return nil
+}
+
+// reconfigureForPlan sends the batched reconfigureVm request of the plan
+func (vm *VM) reconfigureForPlan(ctx context.Context, plan *VmReconcilePlan) (Task, error) {
+	payload := *plan.reconfigure
+	if plan.computePolicyId != "" {
+		if vm.client.APIVCDMaxVersionIs(ctx, "< 33.0") {
+			return Task{}, fmt.Errorf("compute policy can't be used - VCD version doesn't support it")
+		}
+		computePolicyHref, err := vm.client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0,
+			types.OpenApiEndpointVdcComputePolicies, plan.computePolicyId)
+		if err != nil {
+			return Task{}, fmt.Errorf("error constructing HREF for compute policy")
+		}
+		payload.ComputePolicy = &types.ComputePolicy{VmSizingPolicy: &types.Reference{HREF: computePolicyHref.String()}}
+	}
+
+	// `reconfigureVm` updates VM name, Description, and any or all of the following sections.
+	// Sections not included in the request body will not be updated.
+	return vm.client.ExecuteTaskRequest(ctx, vm.VM.HREF+"/action/reconfigureVm", http.MethodPost,
+		types.MimeVM, "error reconfiguring VM: %s", &payload)
+}
+
+// planVmReconcile builds a reconcile plan without making API calls. currentNetwork and
+// availableNetworks are only used when desired.Nics is set.
+func planVmReconcile(current *types.Vm, currentNetwork *types.NetworkConnectionSection, availableNetworks []string,
+	currentStatus string, desired DesiredVmSpec) (*VmReconcilePlan, error) {
+	if current.VmSpecSection == nil {
+		return nil, fmt.Errorf("VM has no VmSpecSection")
+	}
+
+	plan := &VmReconcilePlan{}
+	poweredOn := currentStatus == types.VmPowerStatePoweredOn
+	suspended := currentStatus == types.VmPowerStateSuspended
+	needsPowerOff := false
+	reconfigure := &types.Vm{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Ovf:         types.XMLNamespaceOVF,
+		Name:        current.Name,
+		Description: current.Description,
+	}
+	reconfigureNeeded := false
+
+	// Hot add capabilities can only be changed while the VM is powered off
+	currentCpuHotAdd, currentMemoryHotAdd := false, false
+	if current.VMCapabilities != nil {
+		currentCpuHotAdd = current.VMCapabilities.CPUHotAddEnabled
+		currentMemoryHotAdd = current.VMCapabilities.MemoryHotAddEnabled
+	}
+	capabilities := &types.VmCapabilities{CPUHotAddEnabled: currentCpuHotAdd, MemoryHotAddEnabled: currentMemoryHotAdd}
+	if desired.CpuHotAddEnabled != nil && *desired.CpuHotAddEnabled != currentCpuHotAdd {
+		capabilities.CPUHotAddEnabled = *desired.CpuHotAddEnabled
+		plan.Changes = append(plan.Changes, fmt.Sprintf("CPU hot add: %t -> %t", currentCpuHotAdd, *desired.CpuHotAddEnabled))
+		plan.capabilities = capabilities
+		needsPowerOff = true
+	}
+	if desired.MemoryHotAddEnabled != nil && *desired.MemoryHotAddEnabled != currentMemoryHotAdd {
+		capabilities.MemoryHotAddEnabled = *desired.MemoryHotAddEnabled
+		plan.Changes = append(plan.Changes, fmt.Sprintf("memory hot add: %t -> %t", currentMemoryHotAdd, *desired.MemoryHotAddEnabled))
+		plan.capabilities = capabilities
+		needsPowerOff = true
+	}
+
+	// Work on a copy of VmSpecSection so that the VM structure is not altered
+	vmSpec := *current.VmSpecSection
+	vmSpecChanged := false
+
+	if desired.NumCpus != nil && (vmSpec.NumCpus == nil || *desired.NumCpus != *vmSpec.NumCpus) {
+		currentCpus := 0
+		if vmSpec.NumCpus != nil {
+			currentCpus = *vmSpec.NumCpus
+		}
+		if *desired.NumCpus < 1 {
+			return nil, fmt.Errorf("number of CPUs must be at least 1")
+		}
+		plan.Changes = append(plan.Changes, fmt.Sprintf("CPUs: %d -> %d", currentCpus, *desired.NumCpus))
+		if *desired.NumCpus < currentCpus || !currentCpuHotAdd {
+			needsPowerOff = true
+		}
+		vmSpec.NumCpus = takeIntAddress(*desired.NumCpus)
+		vmSpecChanged = true
+	}
+
+	if desired.NumCoresPerSocket != nil && (vmSpec.NumCoresPerSocket == nil || *desired.NumCoresPerSocket != *vmSpec.NumCoresPerSocket) {
+		currentCores := 0
+		if vmSpec.NumCoresPerSocket != nil {
+			currentCores = *vmSpec.NumCoresPerSocket
+		}
+		plan.Changes = append(plan.Changes, fmt.Sprintf("cores per socket: %d -> %d", currentCores, *desired.NumCoresPerSocket))
+		vmSpec.NumCoresPerSocket = takeIntAddress(*desired.NumCoresPerSocket)
+		vmSpecChanged = true
+		needsPowerOff = true
+	}
+
+	if desired.MemoryMb != nil {
+		var currentMemory int64
+		if vmSpec.MemoryResourceMb != nil {
+			currentMemory = vmSpec.MemoryResourceMb.Configured
+		}
+		if *desired.MemoryMb != currentMemory {
+			if *desired.MemoryMb < 1 {
+				return nil, fmt.Errorf("memory size must be positive")
+			}
+			plan.Changes = append(plan.Changes, fmt.Sprintf("memory MB: %d -> %d", currentMemory, *desired.MemoryMb))
+			if *desired.MemoryMb < currentMemory || !currentMemoryHotAdd {
+				needsPowerOff = true
+			}
+			memory := types.MemoryResourceMb{}
+			if vmSpec.MemoryResourceMb != nil {
+				memory = *vmSpec.MemoryResourceMb
+			}
+			memory.Configured = *desired.MemoryMb
+			vmSpec.MemoryResourceMb = &memory
+			vmSpecChanged = true
+		}
+	}
+
+	if desired.InternalDisks != nil {
+		diskChanges, diskNeedsPowerOff, err := diffInternalDisks(vmSpec.DiskSection, desired.InternalDisks)
+		if err != nil {
+			return nil, err
+		}
+		if len(diskChanges) > 0 {
+			plan.Changes = append(plan.Changes, diskChanges...)
+			vmSpec.DiskSection = &types.DiskSection{DiskSettings: withIndependentDisks(vmSpec.DiskSection, desired.InternalDisks)}
+			vmSpecChanged = true
+			needsPowerOff = needsPowerOff || diskNeedsPowerOff
+		}
+	}
+
+	if vmSpecChanged {
+		reconfigure.VmSpecSection = &vmSpec
+		reconfigure.VmSpecSection.Modified = takeBoolPointer(true)
+		reconfigureNeeded = true
+	}
+
+	if desired.StorageProfile != nil && desired.StorageProfile.HREF != "" &&
+		(current.StorageProfile == nil || current.StorageProfile.HREF != desired.StorageProfile.HREF) {
+		currentName := ""
+		if current.StorageProfile != nil {
+			currentName = current.StorageProfile.Name
+		}
+		plan.Changes = append(plan.Changes, fmt.Sprintf("storage profile: '%s' -> '%s'", currentName, desired.StorageProfile.Name))
+		reconfigure.StorageProfile = desired.StorageProfile
+		reconfigureNeeded = true
+	}
+
+	if desired.ComputePolicyId != "" {
+		currentPolicyId := ""
+		if current.ComputePolicy != nil && current.ComputePolicy.VmSizingPolicy != nil {
+			currentPolicyId = current.ComputePolicy.VmSizingPolicy.ID
+		}
+		if currentPolicyId != desired.ComputePolicyId {
+			plan.Changes = append(plan.Changes, fmt.Sprintf("sizing policy: '%s' -> '%s'", currentPolicyId, desired.ComputePolicyId))
+			plan.computePolicyId = desired.ComputePolicyId
+			reconfigureNeeded = true
+		}
+	}
+
+	if desired.Nics != nil {
+		networkSection, nicChanges, err := diffNics(currentNetwork, availableNetworks, desired.Nics, desired.PrimaryNicIndex)
+		if err != nil {
+			return nil, err
+		}
+		if len(nicChanges) > 0 {
+			plan.Changes = append(plan.Changes, nicChanges...)
+			reconfigure.NetworkConnectionSection = networkSection
+			reconfigureNeeded = true
+		}
+	}
+
+	if desired.GuestCustomization != nil && !guestCustomizationEqual(current.GuestCustomizationSection, desired.GuestCustomization) {
+		plan.Changes = append(plan.Changes, "guest customization settings")
+		guestCustomization := *desired.GuestCustomization
+		guestCustomization.Info = "Specifies Guest OS Customization Settings"
+		reconfigure.GuestCustomizationSection = &guestCustomization
+		reconfigureNeeded = true
+	}
+
+	plan.PowerOffRequired = (poweredOn || suspended) && needsPowerOff
+	switch {
+	case !plan.PowerOffRequired:
+	case suspended:
+		// A suspended VM can't be shut down. It is left powered off, as its suspended state is lost.
+		plan.Steps = append(plan.Steps, VmReconcileStepDiscardSuspendedState)
+	case desired.ForcePowerOff:
+		plan.Steps = append(plan.Steps, VmReconcileStepPowerOff)
+	default:
+		plan.Steps = append(plan.Steps, VmReconcileStepShutdown)
+	}
+	if plan.capabilities != nil {
+		plan.Steps = append(plan.Steps, VmReconcileStepUpdateCapabilities)
+	}
+	if reconfigureNeeded {
+		plan.reconfigure = reconfigure
+		plan.Steps = append(plan.Steps, VmReconcileStepReconfigure)
+	}
+	if plan.PowerOffRequired && poweredOn {
+		plan.Steps = append(plan.Steps, VmReconcileStepPowerOn)
+	}
+
+	return plan, nil
+}
+
+// diffInternalDisks compares current and desired internal disks. It returns a description of the
+// changes and whether any of them requires the VM to be powered off.
+func diffInternalDisks(currentDisks *types.DiskSection, desiredDisks []*types.DiskSettings) ([]string, bool, error) {
+	var changes []string
+	needsPowerOff := false
+
+	currentById := make(map[string]*types.DiskSettings)
+	if currentDisks != nil {
+		for _, disk := range currentDisks.DiskSettings {
+			// Independent disks are not internal disks and are managed separately
+			if disk.Disk == nil {
+				currentById[disk.DiskId] = disk
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, disk := range desiredDisks {
+		if disk == nil {
+			return nil, false, fmt.Errorf("internal disk must not be nil")
+		}
+		if disk.DiskId == "" {
+			changes = append(changes, fmt.Sprintf("add disk of %d MB on bus %d unit %d", disk.SizeMb, disk.BusNumber, disk.UnitNumber))
+			// IDE disks can't be hot added
+			if disk.AdapterType == "1" {
+				needsPowerOff = true
+			}
+			continue
+		}
+		currentDisk, ok := currentById[disk.DiskId]
+		if !ok {
+			return nil, false, fmt.Errorf("%s: internal disk with ID %s", ErrorEntityNotFound, disk.DiskId)
+		}
+		seen[disk.DiskId] = true
+		if disk.SizeMb < currentDisk.SizeMb {
+			return nil, false, fmt.Errorf("disk %s can't be shrunk from %d MB to %d MB", disk.DiskId, currentDisk.SizeMb, disk.SizeMb)
+		}
+		if disk.SizeMb > currentDisk.SizeMb {
+			changes = append(changes, fmt.Sprintf("disk %s size MB: %d -> %d", disk.DiskId, currentDisk.SizeMb, disk.SizeMb))
+		}
+		if disk.StorageProfile != nil && (currentDisk.StorageProfile == nil || currentDisk.StorageProfile.HREF != disk.StorageProfile.HREF) {
+			changes = append(changes, fmt.Sprintf("disk %s storage profile -> '%s'", disk.DiskId, disk.StorageProfile.Name))
+		}
+		if disk.Iops != nil && (currentDisk.Iops == nil || *currentDisk.Iops != *disk.Iops) {
+			changes = append(changes, fmt.Sprintf("disk %s IOPS -> %d", disk.DiskId, *disk.Iops))
+		}
+	}
+
+	for diskId := range currentById {
+		if !seen[diskId] {
+			changes = append(changes, fmt.Sprintf("remove disk %s", diskId))
+			needsPowerOff = true
+		}
+	}
+
+	return changes, needsPowerOff, nil
+}
+
+// withIndependentDisks returns the desired internal disks followed by the independent disks attached
+// to the VM. Independent disks which are not part of a reconfigure request are detached by VCD.
+func withIndependentDisks(currentDisks *types.DiskSection, desiredDisks []*types.DiskSettings) []*types.DiskSettings {
+	disks := make([]*types.DiskSettings, 0, len(desiredDisks))
+	disks = append(disks, desiredDisks...)
+	if currentDisks != nil {
+		for _, disk := range currentDisks.DiskSettings {
+			if disk.Disk != nil {
+				disks = append(disks, disk)
+			}
+		}
+	}
+	return disks
+}
+
+// diffNics builds the desired network connection section and returns a description of the
+// differences with currentNetwork
+func diffNics(currentNetwork *types.NetworkConnectionSection, availableNetworks []string, desiredNics []NicSettings,
+	desiredPrimary *int) (*types.NetworkConnectionSection, []string, error) {
+	if currentNetwork == nil {
+		currentNetwork = &types.NetworkConnectionSection{}
+	}
+
+	var changes []string
+	networkSection := &types.NetworkConnectionSection{
+		Info: "Specifies the available VM network connections",
+	}
+	for index, nic := range desiredNics {
+		err := validateNicSettings(nic, availableNetworks)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid NIC %d: %s", index, err)
+		}
+		desiredNic := nicSettingsToNetworkConnection(index, nic)
+		currentNic := findNicInSection(currentNetwork, index)
+		if currentNic == nil {
+			changes = append(changes, fmt.Sprintf("add NIC %d on network '%s'", index, desiredNic.Network))
+		} else {
+			if desiredNic.MACAddress == "" {
+				desiredNic.MACAddress = currentNic.MACAddress
+			}
+			if desiredNic.NetworkAdapterType == "" {
+				desiredNic.NetworkAdapterType = currentNic.NetworkAdapterType
+			}
+			if nicDiffers(currentNic, desiredNic) {
+				changes = append(changes, fmt.Sprintf("update NIC %d on network '%s'", index, desiredNic.Network))
+			}
+		}
+		networkSection.NetworkConnection = append(networkSection.NetworkConnection, desiredNic)
+	}
+	for _, currentNic := range currentNetwork.NetworkConnection {
+		if currentNic.NetworkConnectionIndex >= len(desiredNics) {
+			changes = append(changes, fmt.Sprintf("remove NIC %d", currentNic.NetworkConnectionIndex))
+		}
+	}
+
+	primary := currentNetwork.PrimaryNetworkConnectionIndex
+	if desiredPrimary != nil {
+		primary = *desiredPrimary
+	}
+	if len(desiredNics) > 0 && (primary < 0 || primary >= len(desiredNics)) {
+		if desiredPrimary != nil {
+			return nil, nil, fmt.Errorf("primary NIC index %d does not exist", primary)
+		}
+		primary = 0
+	}
+	if primary != currentNetwork.PrimaryNetworkConnectionIndex && len(desiredNics) > 0 {
+		changes = append(changes, fmt.Sprintf("primary NIC: %d -> %d", currentNetwork.PrimaryNetworkConnectionIndex, primary))
+	}
+	networkSection.PrimaryNetworkConnectionIndex = primary
+
+	return networkSection, changes, nil
+}
+
+// nicDiffers compares the user controllable fields of two NICs
+func nicDiffers(currentNic, desiredNic *types.NetworkConnection) bool {
+	if currentNic.Network != desiredNic.Network ||
+		currentNic.IPAddressAllocationMode != desiredNic.IPAddressAllocationMode ||
+		currentNic.IsConnected != desiredNic.IsConnected ||
+		!strings.EqualFold(currentNic.MACAddress, desiredNic.MACAddress) ||
+		!strings.EqualFold(currentNic.NetworkAdapterType, desiredNic.NetworkAdapterType) {
+		return true
+	}
+	return desiredNic.IPAddressAllocationMode == types.IPAllocationModeManual && currentNic.IPAddress != desiredNic.IPAddress
+}
+
+// guestCustomizationEqual compares guest customization settings ignoring fields which are set by
+// VCD (links, namespaces, VM ID) and passwords, which are not returned when reading
+func guestCustomizationEqual(current, desired *types.GuestCustomizationSection) bool {
+	if current == nil {
+		return false
+	}
+	normalize := func(section types.GuestCustomizationSection) types.GuestCustomizationSection {
+		section.Ovf, section.Xsi, section.Xmlns = "", "", ""
+		section.HREF, section.Type, section.Info = "", "", ""
+		section.VirtualMachineID = ""
+		section.Link = nil
+		section.AdminPassword, section.DomainUserPassword = "", ""
+		return section
+	}
+	return reflect.DeepEqual(normalize(*current), normalize(*desired))
+}
@@ -0,0 +1,213 @@
+// +build vm unit ALL
+
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package govcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_planVmReconcile(t *testing.T) {
+	newVm := func(cpuHotAdd, memoryHotAdd bool) *types.Vm {
+		return &types.Vm{
+			Name:           "vm",
+			VMCapabilities: &types.VmCapabilities{CPUHotAddEnabled: cpuHotAdd, MemoryHotAddEnabled: memoryHotAdd},
+			VmSpecSection: &types.VmSpecSection{
+				NumCpus:           takeIntAddress(2),
+				NumCoresPerSocket: takeIntAddress(1),
+				MemoryResourceMb:  &types.MemoryResourceMb{Configured: 1024},
+				DiskSection: &types.DiskSection{DiskSettings: []*types.DiskSettings{
+					{DiskId: "2000", SizeMb: 1024, AdapterType: "5"},
+					{DiskId: "2001", SizeMb: 2048, AdapterType: "5", UnitNumber: 1},
+				}},
+			},
+			StorageProfile: &types.Reference{HREF: "https://vcd/sp/1", Name: "sp1"},
+		}
+	}
+	currentNetwork := &types.NetworkConnectionSection{
+		PrimaryNetworkConnectionIndex: 0,
+		NetworkConnection: []*types.NetworkConnection{
+			{Network: "net1", NetworkConnectionIndex: 0, IPAddressAllocationMode: types.IPAllocationModePool,
+				MACAddress: "00:50:56:01:29:c8", NetworkAdapterType: types.NetworkAdapterTypeVmxnet3, IsConnected: true},
+		},
+	}
+	availableNetworks := []string{"net1", "net2"}
+
+	tests := []struct {
+		name          string
+		vm            *types.Vm
+		status        string
+		desired       DesiredVmSpec
+		wantSteps     []string
+		wantChanges   int
+		wantPowerOff  bool
+		wantErr       bool
+		checkSpecSent bool
+	}{
+		{name: "NoChanges", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired: DesiredVmSpec{NumCpus: takeIntAddress(2), MemoryMb: takeInt64Address(1024)}},
+		{name: "CpuIncreaseWithHotAdd", vm: newVm(true, true), status: types.VmPowerStatePoweredOn,
+			desired:   DesiredVmSpec{NumCpus: takeIntAddress(4), MemoryMb: takeInt64Address(2048)},
+			wantSteps: []string{VmReconcileStepReconfigure}, wantChanges: 2, checkSpecSent: true},
+		{name: "CpuIncreaseWithoutHotAdd", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired:     DesiredVmSpec{NumCpus: takeIntAddress(4)},
+			wantSteps:   []string{VmReconcileStepShutdown, VmReconcileStepReconfigure, VmReconcileStepPowerOn},
+			wantChanges: 1, wantPowerOff: true, checkSpecSent: true},
+		{name: "CpuIncreaseWithoutHotAddForcePowerOff", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired:     DesiredVmSpec{NumCpus: takeIntAddress(4), ForcePowerOff: true},
+			wantSteps:   []string{VmReconcileStepPowerOff, VmReconcileStepReconfigure, VmReconcileStepPowerOn},
+			wantChanges: 1, wantPowerOff: true, checkSpecSent: true},
+		{name: "CpuIncreaseWithoutHotAddPoweredOff", vm: newVm(false, false), status: types.VmPowerStatePoweredOff,
+			desired:   DesiredVmSpec{NumCpus: takeIntAddress(4)},
+			wantSteps: []string{VmReconcileStepReconfigure}, wantChanges: 1, checkSpecSent: true},
+		{name: "CpuIncreaseWithoutHotAddSuspended", vm: newVm(false, false), status: types.VmPowerStateSuspended,
+			desired:     DesiredVmSpec{NumCpus: takeIntAddress(4)},
+			wantSteps:   []string{VmReconcileStepDiscardSuspendedState, VmReconcileStepReconfigure},
+			wantChanges: 1, wantPowerOff: true, checkSpecSent: true},
+		{name: "MemoryDecreaseWithHotAdd", vm: newVm(true, true), status: types.VmPowerStatePoweredOn,
+			desired:     DesiredVmSpec{MemoryMb: takeInt64Address(512)},
+			wantSteps:   []string{VmReconcileStepShutdown, VmReconcileStepReconfigure, VmReconcileStepPowerOn},
+			wantChanges: 1, wantPowerOff: true, checkSpecSent: true},
+		{name: "EnableHotAddAndCpu", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired: DesiredVmSpec{CpuHotAddEnabled: takeBoolPointer(true), NumCpus: takeIntAddress(4)},
+			wantSteps: []string{VmReconcileStepShutdown, VmReconcileStepUpdateCapabilities, VmReconcileStepReconfigure,
+				VmReconcileStepPowerOn},
+			wantChanges: 2, wantPowerOff: true, checkSpecSent: true},
+		{name: "CoresPerSocket", vm: newVm(true, true), status: types.VmPowerStatePoweredOn,
+			desired:     DesiredVmSpec{NumCoresPerSocket: takeIntAddress(2)},
+			wantSteps:   []string{VmReconcileStepShutdown, VmReconcileStepReconfigure, VmReconcileStepPowerOn},
+			wantChanges: 1, wantPowerOff: true, checkSpecSent: true},
+		{name: "GrowAndAddDisk", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired: DesiredVmSpec{InternalDisks: []*types.DiskSettings{
+				{DiskId: "2000", SizeMb: 2048, AdapterType: "5"},
+				{DiskId: "2001", SizeMb: 2048, AdapterType: "5", UnitNumber: 1},
+				{SizeMb: 512, AdapterType: "5", UnitNumber: 2},
+			}},
+			wantSteps: []string{VmReconcileStepReconfigure}, wantChanges: 2, checkSpecSent: true},
+		{name: "AddIdeDisk", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired: DesiredVmSpec{InternalDisks: []*types.DiskSettings{
+				{DiskId: "2000", SizeMb: 1024, AdapterType: "5"},
+				{DiskId: "2001", SizeMb: 2048, AdapterType: "5", UnitNumber: 1},
+				{SizeMb: 512, AdapterType: "1"},
+			}},
+			wantSteps:   []string{VmReconcileStepShutdown, VmReconcileStepReconfigure, VmReconcileStepPowerOn},
+			wantChanges: 1, wantPowerOff: true, checkSpecSent: true},
+		{name: "RemoveDisk", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired: DesiredVmSpec{InternalDisks: []*types.DiskSettings{
+				{DiskId: "2000", SizeMb: 1024, AdapterType: "5"},
+			}},
+			wantSteps:   []string{VmReconcileStepShutdown, VmReconcileStepReconfigure, VmReconcileStepPowerOn},
+			wantChanges: 1, wantPowerOff: true, checkSpecSent: true},
+		{name: "ShrinkDisk", vm: newVm(false, false), status: types.VmPowerStatePoweredOff,
+			desired: DesiredVmSpec{InternalDisks: []*types.DiskSettings{
+				{DiskId: "2000", SizeMb: 512, AdapterType: "5"},
+				{DiskId: "2001", SizeMb: 2048, AdapterType: "5", UnitNumber: 1},
+			}},
+			wantErr: true},
+		{name: "UnknownDisk", vm: newVm(false, false), status: types.VmPowerStatePoweredOff,
+			desired: DesiredVmSpec{InternalDisks: []*types.DiskSettings{{DiskId: "3000", SizeMb: 512}}},
+			wantErr: true},
+		{name: "StorageProfileAndPolicy", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired: DesiredVmSpec{StorageProfile: &types.Reference{HREF: "https://vcd/sp/2", Name: "sp2"},
+				ComputePolicyId: "urn:vcloud:vdcComputePolicy:1"},
+			wantSteps: []string{VmReconcileStepReconfigure}, wantChanges: 2},
+		{name: "SameStorageProfile", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired: DesiredVmSpec{StorageProfile: &types.Reference{HREF: "https://vcd/sp/1", Name: "sp1"}}},
+		{name: "SameNics", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired: DesiredVmSpec{Nics: []NicSettings{
+				{Network: "net1", IpAllocationMode: types.IPAllocationModePool, IsConnected: true},
+			}}},
+		{name: "AddNicAndChangePrimary", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired: DesiredVmSpec{Nics: []NicSettings{
+				{Network: "net1", IpAllocationMode: types.IPAllocationModePool, IsConnected: true},
+				{Network: "net2", IpAllocationMode: types.IPAllocationModeDHCP, IsConnected: true},
+			}, PrimaryNicIndex: takeIntAddress(1)},
+			wantSteps: []string{VmReconcileStepReconfigure}, wantChanges: 2},
+		{name: "InvalidNic", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired: DesiredVmSpec{Nics: []NicSettings{
+				{Network: "net3", IpAllocationMode: types.IPAllocationModePool},
+			}},
+			wantErr: true},
+		{name: "InvalidPrimaryNic", vm: newVm(false, false), status: types.VmPowerStatePoweredOn,
+			desired: DesiredVmSpec{Nics: []NicSettings{
+				{Network: "net1", IpAllocationMode: types.IPAllocationModePool, IsConnected: true},
+			}, PrimaryNicIndex: takeIntAddress(3)},
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := planVmReconcile(tt.vm, currentNetwork, availableNetworks, tt.status, tt.desired)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("planVmReconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(plan.Steps, tt.wantSteps) {
+				t.Errorf("planVmReconcile() steps = %v, want %v", plan.Steps, tt.wantSteps)
+			}
+			if len(plan.Changes) != tt.wantChanges {
+				t.Errorf("planVmReconcile() changes = %v, want %d changes", plan.Changes, tt.wantChanges)
+			}
+			if plan.PowerOffRequired != tt.wantPowerOff {
+				t.Errorf("planVmReconcile() PowerOffRequired = %t, want %t", plan.PowerOffRequired, tt.wantPowerOff)
+			}
+			if plan.IsEmpty() != (len(tt.wantSteps) == 0) {
+				t.Errorf("planVmReconcile() IsEmpty = %t with steps %v", plan.IsEmpty(), plan.Steps)
+			}
+			if tt.checkSpecSent && (plan.reconfigure == nil || plan.reconfigure.VmSpecSection == nil) {
+				t.Errorf("planVmReconcile() expected VmSpecSection in reconfigure payload")
+			}
+		})
+	}
+
+	// The planner must not alter the current VM structure
+	vm := newVm(true, true)
+	_, err := planVmReconcile(vm, currentNetwork, availableNetworks, types.VmPowerStatePoweredOn,
+		DesiredVmSpec{NumCpus: takeIntAddress(8), MemoryMb: takeInt64Address(4096)})
+	if err != nil {
+		t.Fatalf("planVmReconcile() unexpected error: %s", err)
+	}
+	if *vm.VmSpecSection.NumCpus != 2 || vm.VmSpecSection.MemoryResourceMb.Configured != 1024 {
+		t.Errorf("planVmReconcile() altered the current VM specification")
+	}
+}
+
+// Test_planVmReconcileKeepsIndependentDisks checks that independent disks attached to the VM are
+// sent back with the reconfigure request, as VCD detaches the disks missing from it
+func Test_planVmReconcileKeepsIndependentDisks(t *testing.T) {
+	independentDisk := &types.DiskSettings{DiskId: "2016", SizeMb: 4096, AdapterType: "5", UnitNumber: 2,
+		Disk: &types.Reference{HREF: "https://vcd/api/disk/1"}}
+	vm := &types.Vm{
+		Name: "vm",
+		VmSpecSection: &types.VmSpecSection{
+			DiskSection: &types.DiskSection{DiskSettings: []*types.DiskSettings{
+				{DiskId: "2000", SizeMb: 1024, AdapterType: "5"},
+				independentDisk,
+			}},
+		},
+	}
+	desired := DesiredVmSpec{InternalDisks: []*types.DiskSettings{{DiskId: "2000", SizeMb: 2048, AdapterType: "5"}}}
+
+	plan, err := planVmReconcile(vm, nil, nil, types.VmPowerStatePoweredOn, desired)
+	if err != nil {
+		t.Fatalf("planVmReconcile() unexpected error: %s", err)
+	}
+	if len(plan.Changes) != 1 {
+		t.Errorf("planVmReconcile() changes = %v, want only the disk resize", plan.Changes)
+	}
+
+	want := []*types.DiskSettings{desired.InternalDisks[0], independentDisk}
+	if !reflect.DeepEqual(plan.reconfigure.VmSpecSection.DiskSection.DiskSettings, want) {
+		t.Errorf("planVmReconcile() disks = %v, want %v", plan.reconfigure.VmSpecSection.DiskSection.DiskSettings, want)
+	}
+	if len(desired.InternalDisks) != 1 {
+		t.Errorf("planVmReconcile() altered the desired internal disks")
+	}
+}
@@ -728,6 +728,258 @@ func (vcd *TestVCD) Test_VMPowerOnPowerOff(check *C) {
 	check.Assert(vmStatus, Equals, "POWERED_OFF")
 }
 
+// Test_VMEnsurePowerState checks that EnsurePowerState moves a VM through powered on, suspended
+// and powered off states and that UndeployWithPowerAction rejects unknown actions
+func (vcd *TestVCD) Test_VMEnsurePowerState(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	ctx := context.Background()
+	vapp := vcd.findFirstVapp(ctx)
+	existingVm, vmName := vcd.findFirstVm(vapp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	vm, err := vcd.client.Client.GetVMByHref(ctx, existingVm.HREF)
+	check.Assert(err, IsNil)
+
+	for _, desired := range []string{types.VmPowerStatePoweredOn, types.VmPowerStateSuspended,
+		types.VmPowerStatePoweredOn, types.VmPowerStatePoweredOff} {
+		err = vm.EnsurePowerState(ctx, desired)
+		check.Assert(err, IsNil)
+		vmStatus, err := vm.GetStatus(ctx)
+		check.Assert(err, IsNil)
+		check.Assert(vmStatus, Equals, desired)
+	}
+
+	// Requesting the current state again is a no-op
+	err = vm.EnsurePowerState(ctx, types.VmPowerStatePoweredOff)
+	check.Assert(err, IsNil)
+
+	_, err = vm.UndeployWithPowerAction(ctx, "invalid")
+	check.Assert(err, NotNil)
+}
+
+// Test_VMAcquireConsoleTickets powers on a VM and checks that MKS and screen tickets can be acquired
+func (vcd *TestVCD) Test_VMAcquireConsoleTickets(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	ctx := context.Background()
+	vapp := vcd.findFirstVapp(ctx)
+	existingVm, vmName := vcd.findFirstVm(vapp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	vm, err := vcd.client.Client.GetVMByHref(ctx, existingVm.HREF)
+	check.Assert(err, IsNil)
+
+	err = vm.EnsurePowerState(ctx, types.VmPowerStatePoweredOn)
+	check.Assert(err, IsNil)
+
+	mksTicket, err := vm.AcquireMksTicket(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(mksTicket.Host, Not(Equals), "")
+	check.Assert(mksTicket.Ticket, Not(Equals), "")
+	check.Assert(mksTicket.Port, Not(Equals), 0)
+
+	webMksUrl, err := WebMksUrl(mksTicket)
+	check.Assert(err, IsNil)
+	check.Assert(strings.HasPrefix(webMksUrl, "wss://"+mksTicket.Host+"/"), Equals, true)
+
+	screenTicket, err := vm.AcquireTicket(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(screenTicket.Host, Not(Equals), "")
+	check.Assert(screenTicket.Ticket, Not(Equals), "")
+
+	err = vm.EnsurePowerState(ctx, types.VmPowerStatePoweredOff)
+	check.Assert(err, IsNil)
+}
+
+// Test_VMNicManagement adds a NIC to a VM, updates it, makes it primary and removes it again
+func (vcd *TestVCD) Test_VMNicManagement(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	ctx := context.Background()
+	vapp := vcd.findFirstVapp(ctx)
+	existingVm, vmName := vcd.findFirstVm(vapp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	vm, err := vcd.client.Client.GetVMByHref(ctx, existingVm.HREF)
+	check.Assert(err, IsNil)
+
+	initialSection, err := vm.GetNetworkConnectionSection(ctx)
+	check.Assert(err, IsNil)
+
+	// Invalid settings are rejected before calling the API
+	_, err = vm.AddNic(ctx, NicSettings{Network: "non-existing-network", IpAllocationMode: types.IPAllocationModeDHCP})
+	check.Assert(err, NotNil)
+
+	index, err := vm.AddNic(ctx, NicSettings{IpAllocationMode: types.IPAllocationModeNone,
+		AdapterType: types.NetworkAdapterTypeE1000E})
+	check.Assert(err, IsNil)
+
+	section, err := vm.GetNetworkConnectionSection(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(len(section.NetworkConnection), Equals, len(initialSection.NetworkConnection)+1)
+	addedNic := findNicInSection(section, index)
+	check.Assert(addedNic, NotNil)
+	check.Assert(addedNic.Network, Equals, types.NoneNetwork)
+
+	err = vm.UpdateNic(ctx, index, NicSettings{IpAllocationMode: types.IPAllocationModeNone, IsConnected: false})
+	check.Assert(err, IsNil)
+
+	err = vm.SetPrimaryNic(ctx, index)
+	check.Assert(err, IsNil)
+	section, err = vm.GetNetworkConnectionSection(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(section.PrimaryNetworkConnectionIndex, Equals, index)
+
+	err = vm.RemoveNic(ctx, index)
+	check.Assert(err, IsNil)
+	if len(initialSection.NetworkConnection) > 0 {
+		err = vm.SetPrimaryNic(ctx, initialSection.PrimaryNetworkConnectionIndex)
+		check.Assert(err, IsNil)
+	}
+
+	section, err = vm.GetNetworkConnectionSection(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(len(section.NetworkConnection), Equals, len(initialSection.NetworkConnection))
+	check.Assert(section.PrimaryNetworkConnectionIndex, Equals, initialSection.PrimaryNetworkConnectionIndex)
+}
+
+// Test_VMReconcile checks that a dry run plan does not change the VM and that Reconcile applies
+// CPU and memory changes in one reconfigure operation
+func (vcd *TestVCD) Test_VMReconcile(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	ctx := context.Background()
+	vapp := vcd.findFirstVapp(ctx)
+	existingVm, vmName := vcd.findFirstVm(vapp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	vm, err := vcd.client.Client.GetVMByHref(ctx, existingVm.HREF)
+	check.Assert(err, IsNil)
+	if vm.VM.VmSpecSection == nil {
+		check.Skip("skipping test because VM has no VmSpecSection")
+	}
+
+	initialCpus := *vm.VM.VmSpecSection.NumCpus
+	initialMemory := vm.VM.VmSpecSection.MemoryResourceMb.Configured
+
+	// A spec matching the current VM produces an empty plan
+	plan, err := vm.PlanReconcile(ctx, DesiredVmSpec{NumCpus: takeIntAddress(initialCpus),
+		MemoryMb: takeInt64Address(initialMemory)})
+	check.Assert(err, IsNil)
+	check.Assert(plan.IsEmpty(), Equals, true)
+
+	// The test VM may not run VMware Tools, which is needed to shut down the guest OS
+	desired := DesiredVmSpec{NumCpus: takeIntAddress(initialCpus + 1), MemoryMb: takeInt64Address(initialMemory + 512),
+		ForcePowerOff: true}
+	plan, err = vm.PlanReconcile(ctx, desired)
+	check.Assert(err, IsNil)
+	check.Assert(len(plan.Changes), Equals, 2)
+	check.Assert(plan.IsEmpty(), Equals, false)
+	check.Assert(*vm.VM.VmSpecSection.NumCpus, Equals, initialCpus)
+
+	_, err = vm.Reconcile(ctx, desired)
+	check.Assert(err, IsNil)
+	check.Assert(*vm.VM.VmSpecSection.NumCpus, Equals, initialCpus+1)
+	check.Assert(vm.VM.VmSpecSection.MemoryResourceMb.Configured, Equals, initialMemory+512)
+
+	// Restore the initial configuration
+	_, err = vm.Reconcile(ctx, DesiredVmSpec{NumCpus: takeIntAddress(initialCpus), MemoryMb: takeInt64Address(initialMemory),
+		ForcePowerOff: true})
+	check.Assert(err, IsNil)
+	check.Assert(*vm.VM.VmSpecSection.NumCpus, Equals, initialCpus)
+	check.Assert(vm.VM.VmSpecSection.MemoryResourceMb.Configured, Equals, initialMemory)
+}
+
+// Test_VMApplyGuestCustomization sets guest customization built with GuestCustomizationBuilder and
+// restores the initial settings afterwards
+func (vcd *TestVCD) Test_VMApplyGuestCustomization(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	ctx := context.Background()
+	vapp := vcd.findFirstVapp(ctx)
+	existingVm, vmName := vcd.findFirstVm(vapp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	vm, err := vcd.client.Client.GetVMByHref(ctx, existingVm.HREF)
+	check.Assert(err, IsNil)
+
+	initialSection, err := vm.GetGuestCustomizationSection(ctx)
+	check.Assert(err, IsNil)
+
+	// Validation happens before calling the API
+	_, err = vm.ApplyGuestCustomization(ctx, NewLinuxGuestCustomization("invalid_name"))
+	check.Assert(err, NotNil)
+
+	builder := NewLinuxGuestCustomization("reconciled-vm").
+		WithAdminPassword("Pa55w0rd!", true).
+		WithScript("#!/bin/sh\r\necho customized\r\n")
+	section, err := vm.ApplyGuestCustomization(ctx, builder)
+	check.Assert(err, IsNil)
+	check.Assert(section.ComputerName, Equals, "reconciled-vm")
+	check.Assert(*section.AdminPasswordAuto, Equals, false)
+	check.Assert(*section.ResetPasswordRequired, Equals, true)
+	check.Assert(section.CustomizationScript, Equals, "#!/bin/sh\necho customized\n")
+
+	initialSection.AdminPassword = ""
+	_, err = vm.SetGuestCustomizationSection(ctx, initialSection)
+	check.Assert(err, IsNil)
+}
+
+// Test_VMCloudInitUserData injects cloud-init data using both datasources, reads it back and
+// restores the initial guest properties
+func (vcd *TestVCD) Test_VMCloudInitUserData(check *C) {
+	if vcd.skipVappTests {
+		check.Skip("Skipping test because vapp was not successfully created at setup")
+	}
+	ctx := context.Background()
+	vapp := vcd.findFirstVapp(ctx)
+	existingVm, vmName := vcd.findFirstVm(vapp)
+	if vmName == "" {
+		check.Skip("skipping test because no VM is found")
+	}
+	vm, err := vcd.client.Client.GetVMByHref(ctx, existingVm.HREF)
+	check.Assert(err, IsNil)
+
+	initialProperties, err := vm.GetProductSectionList(ctx)
+	check.Assert(err, IsNil)
+
+	userData := "#cloud-config\nhostname: " + vmName + "\n"
+	metaData := "instance-id: " + vmName + "\n"
+	networkConfig := "version: 2\nethernets:\n  ens192:\n    dhcp4: true\n"
+
+	err = vm.SetCloudInitOvfUserData(ctx, userData, networkConfig)
+	check.Assert(err, IsNil)
+	data, err := vm.GetCloudInitUserData(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(data.UserData, Equals, userData)
+	check.Assert(data.NetworkConfig, Equals, networkConfig)
+
+	err = vm.SetCloudInitUserData(ctx, userData, metaData, networkConfig)
+	check.Assert(err, IsNil)
+	data, err = vm.GetCloudInitUserData(ctx)
+	check.Assert(err, IsNil)
+	check.Assert(data.UserData, Equals, userData)
+	check.Assert(data.MetaData, Equals, metaData)
+	check.Assert(data.NetworkConfig, Equals, networkConfig)
+
+	if initialProperties.ProductSection == nil {
+		initialProperties.ProductSection = &types.ProductSection{}
+	}
+	_, err = vm.SetProductSectionList(ctx, initialProperties)
+	check.Assert(err, IsNil)
+}
+
 func (vcd *TestVCD) Test_GetNetworkConnectionSection(check *C) {
 	if vcd.skipVappTests {
 		check.Skip("Skipping test because vapp was not successfully created at setup")
@@ -372,3 +372,45 @@ func TestProductSectionList_SortByPropertyKeyName(t *testing.T) {
 		})
 	}
 }
+
+func Test_vmPowerStateTransitions(t *testing.T) {
+	tests := []struct {
+		name          string
+		currentStatus string
+		desired       string
+		want          []string
+		wantErr       bool
+	}{
+		{name: "OnToOn", currentStatus: "POWERED_ON", desired: types.VmPowerStatePoweredOn, want: nil},
+		{name: "OnToOff", currentStatus: "POWERED_ON", desired: types.VmPowerStatePoweredOff,
+			want: []string{vmPowerTransitionPowerOff}},
+		{name: "OnToSuspended", currentStatus: "POWERED_ON", desired: types.VmPowerStateSuspended,
+			want: []string{vmPowerTransitionSuspend}},
+		{name: "OffToOn", currentStatus: "POWERED_OFF", desired: types.VmPowerStatePoweredOn,
+			want: []string{vmPowerTransitionPowerOn}},
+		{name: "OffToSuspended", currentStatus: "POWERED_OFF", desired: types.VmPowerStateSuspended,
+			want: []string{vmPowerTransitionPowerOn, vmPowerTransitionSuspend}},
+		{name: "ResolvedToOff", currentStatus: "RESOLVED", desired: types.VmPowerStatePoweredOff, want: nil},
+		{name: "UndeployedToOn", currentStatus: "VAPP_UNDEPLOYED", desired: types.VmPowerStatePoweredOn,
+			want: []string{vmPowerTransitionPowerOn}},
+		{name: "SuspendedToOn", currentStatus: "SUSPENDED", desired: types.VmPowerStatePoweredOn,
+			want: []string{vmPowerTransitionPowerOn}},
+		{name: "SuspendedToOff", currentStatus: "SUSPENDED", desired: types.VmPowerStatePoweredOff,
+			want: []string{vmPowerTransitionDiscardSuspendedState}},
+		{name: "UnsupportedDesired", currentStatus: "POWERED_ON", desired: "DEPLOYED", wantErr: true},
+		{name: "UnsupportedCurrent", currentStatus: "WAITING_FOR_INPUT", desired: types.VmPowerStatePoweredOn,
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vmPowerStateTransitions(tt.currentStatus, tt.desired)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("vmPowerStateTransitions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("vmPowerStateTransitions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
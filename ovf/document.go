@@ -0,0 +1,389 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package ovf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// xmlNamespaceXml is the namespace bound to the reserved "xml" prefix
+const xmlNamespaceXml = "http://www.w3.org/XML/1998/namespace"
+
+// document is a generic XML document which keeps all the content of the original descriptor
+// (including sections unknown to this package and namespace prefixes), so that it can be written
+// back after editing
+type document struct {
+	prolog []xml.Token // Tokens before the root element, such as the XML declaration
+	root   *element
+	epilog []xml.Token // Tokens after the root element
+}
+
+// element is an XML element of a document
+type element struct {
+	name     xml.Name
+	attr     []xml.Attr
+	children []xml.Token // *element, xml.CharData, xml.Comment, xml.ProcInst or xml.Directive
+}
+
+// parseDocument builds a document from XML data
+func parseDocument(data []byte) (*document, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	doc := &document{}
+	var stack []*element
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			newElement := &element{name: token.Name, attr: append([]xml.Attr{}, token.Attr...)}
+			if len(stack) == 0 {
+				if doc.root != nil {
+					return nil, fmt.Errorf("document has more than one root element")
+				}
+				doc.root = newElement
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, newElement)
+			}
+			stack = append(stack, newElement)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		default:
+			token = xml.CopyToken(token)
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, token)
+			} else if doc.root == nil {
+				doc.prolog = append(doc.prolog, token)
+			} else {
+				doc.epilog = append(doc.epilog, token)
+			}
+		}
+	}
+
+	if doc.root == nil {
+		return nil, fmt.Errorf("document has no root element")
+	}
+	return doc, nil
+}
+
+// marshal writes the document, restoring the namespace prefixes of the original document
+func (doc *document) marshal() ([]byte, error) {
+	var buffer bytes.Buffer
+	for _, token := range doc.prolog {
+		writeToken(&buffer, token)
+	}
+	err := doc.root.write(&buffer, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range doc.epilog {
+		writeToken(&buffer, token)
+	}
+	return buffer.Bytes(), nil
+}
+
+// namespaceScope maps the prefixes declared by an element and its ancestors to their namespace. The
+// empty prefix is the default namespace.
+type namespaceScope struct {
+	prefixes map[string]string
+	parent   *namespaceScope
+}
+
+// prefixFor returns the prefix bound to namespace, searching the innermost declarations first
+func (scope *namespaceScope) prefixFor(namespace string, allowDefault bool) (string, bool) {
+	for current := scope; current != nil; current = current.parent {
+		prefixes := make([]string, 0, len(current.prefixes))
+		for prefix := range current.prefixes {
+			prefixes = append(prefixes, prefix)
+		}
+		sort.Strings(prefixes)
+		for _, prefix := range prefixes {
+			if current.prefixes[prefix] != namespace || (prefix == "" && !allowDefault) {
+				continue
+			}
+			// The prefix must not be redefined by a nearer declaration
+			if inner, _ := scope.namespaceOf(prefix); inner == namespace {
+				return prefix, true
+			}
+		}
+	}
+	return "", false
+}
+
+// namespaceOf returns the namespace bound to prefix
+func (scope *namespaceScope) namespaceOf(prefix string) (string, bool) {
+	for current := scope; current != nil; current = current.parent {
+		if uri, found := current.prefixes[prefix]; found {
+			return uri, true
+		}
+	}
+	return "", false
+}
+
+// write writes the element and its children
+func (el *element) write(buffer *bytes.Buffer, parentScope *namespaceScope) error {
+	scope := &namespaceScope{prefixes: make(map[string]string), parent: parentScope}
+	for _, attr := range el.attr {
+		switch {
+		case attr.Name.Space == "xmlns":
+			scope.prefixes[attr.Name.Local] = attr.Value
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			scope.prefixes[""] = attr.Value
+		}
+	}
+
+	name := el.qualifiedName(scope, el.name, true)
+	buffer.WriteString("<" + name)
+	for _, attr := range el.attr {
+		var attrName string
+		switch {
+		case attr.Name.Space == "xmlns":
+			attrName = "xmlns:" + attr.Name.Local
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			attrName = "xmlns"
+		default:
+			attrName = el.qualifiedName(scope, attr.Name, false)
+		}
+		buffer.WriteString(" " + attrName + `="`)
+		escape(buffer, attr.Value, true)
+		buffer.WriteString(`"`)
+	}
+
+	if len(el.children) == 0 {
+		buffer.WriteString("/>")
+		return nil
+	}
+	buffer.WriteString(">")
+	for _, child := range el.children {
+		if childElement, ok := child.(*element); ok {
+			err := childElement.write(buffer, scope)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		writeToken(buffer, child)
+	}
+	buffer.WriteString("</" + name + ">")
+	return nil
+}
+
+// qualifiedName returns the name with the prefix bound to its namespace. Attributes are never in
+// the default namespace, so they require an explicit prefix.
+func (el *element) qualifiedName(scope *namespaceScope, name xml.Name, isElement bool) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	if name.Space == xmlNamespaceXml {
+		return "xml:" + name.Local
+	}
+	prefix, found := scope.prefixFor(name.Space, isElement)
+	if !found {
+		// Undeclared prefixes are kept by the decoder as namespace
+		return name.Space + ":" + name.Local
+	}
+	if prefix == "" {
+		return name.Local
+	}
+	return prefix + ":" + name.Local
+}
+
+// writeToken writes a token other than an element
+func writeToken(buffer *bytes.Buffer, token xml.Token) {
+	switch token := token.(type) {
+	case xml.CharData:
+		escape(buffer, string(token), false)
+	case xml.Comment:
+		buffer.WriteString("<!--" + string(token) + "-->")
+	case xml.ProcInst:
+		buffer.WriteString("<?" + token.Target)
+		if len(token.Inst) > 0 {
+			buffer.WriteString(" " + string(token.Inst))
+		}
+		buffer.WriteString("?>")
+	case xml.Directive:
+		buffer.WriteString("<!" + string(token) + ">")
+	}
+}
+
+// escape writes text with the XML special characters escaped. Unlike xml.EscapeText, new lines
+// are only escaped in attribute values, so that the indentation of the document is kept.
+func escape(buffer *bytes.Buffer, text string, isAttr bool) {
+	for _, character := range text {
+		switch {
+		case character == '&':
+			buffer.WriteString("&amp;")
+		case character == '<':
+			buffer.WriteString("&lt;")
+		case character == '>':
+			buffer.WriteString("&gt;")
+		case character == '"' && isAttr:
+			buffer.WriteString("&quot;")
+		case character == '\n' && isAttr:
+			buffer.WriteString("&#xA;")
+		case character == '\t' && isAttr:
+			buffer.WriteString("&#x9;")
+		case character == '\r':
+			buffer.WriteString("&#xD;")
+		default:
+			buffer.WriteRune(character)
+		}
+	}
+}
+
+// childElements returns the direct children of the element with the given local name, in any
+// namespace
+func (el *element) childElements(local string) []*element {
+	var result []*element
+	for _, child := range el.children {
+		if childElement, ok := child.(*element); ok && childElement.name.Local == local {
+			result = append(result, childElement)
+		}
+	}
+	return result
+}
+
+// walk calls visit for the element and all its descendants
+func (el *element) walk(visit func(*element)) {
+	visit(el)
+	for _, child := range el.children {
+		if childElement, ok := child.(*element); ok {
+			childElement.walk(visit)
+		}
+	}
+}
+
+// removeDescendants removes the descendants for which remove returns true and returns how many
+// were removed
+func (el *element) removeDescendants(remove func(*element) bool) int {
+	removed := 0
+	var kept []xml.Token
+	for _, child := range el.children {
+		if childElement, ok := child.(*element); ok {
+			if remove(childElement) {
+				removed++
+				continue
+			}
+			removed += childElement.removeDescendants(remove)
+		}
+		kept = append(kept, child)
+	}
+	el.children = kept
+	return removed
+}
+
+// attrValue returns the value of the attribute with the given local name, ignoring namespace
+// declarations
+func (el *element) attrValue(local string) (string, bool) {
+	for _, attr := range el.attr {
+		if attr.Name.Local == local && attr.Name.Space != "xmlns" {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// setAttrValue sets the value of the attribute of el with the given local name. If the attribute
+// doesn't exist, it is added in namespace. As attributes can't use the default namespace, a prefix
+// is declared on the root element when namespace has none.
+func (doc *document) setAttrValue(el *element, namespace, local, value string) {
+	for i, attr := range el.attr {
+		if attr.Name.Local == local && attr.Name.Space != "xmlns" {
+			el.attr[i].Value = value
+			return
+		}
+	}
+	if namespace != "" && namespace != xmlNamespaceXml {
+		doc.declarePrefix(namespace)
+	}
+	el.attr = append(el.attr, xml.Attr{Name: xml.Name{Space: namespace, Local: local}, Value: value})
+}
+
+// declarePrefix declares a prefix for namespace on the root element, unless the root element
+// already binds a prefix other than the default one to it
+func (doc *document) declarePrefix(namespace string) {
+	for _, attr := range doc.root.attr {
+		if attr.Name.Space == "xmlns" && attr.Value == namespace {
+			return
+		}
+	}
+
+	// The prefix must not be bound anywhere in the document, so that no element redefines it
+	used := make(map[string]bool)
+	doc.root.walk(func(el *element) {
+		for _, attr := range el.attr {
+			if attr.Name.Space == "xmlns" {
+				used[attr.Name.Local] = true
+			}
+		}
+	})
+	prefix := "ovf"
+	for i := 1; used[prefix]; i++ {
+		prefix = fmt.Sprintf("ns%d", i)
+	}
+	doc.root.attr = append(doc.root.attr, xml.Attr{Name: xml.Name{Space: "xmlns", Local: prefix}, Value: namespace})
+}
+
+// copy returns a deep copy of the document
+func (doc *document) copy() *document {
+	return &document{
+		prolog: copyTokens(doc.prolog),
+		root:   doc.root.copy(),
+		epilog: copyTokens(doc.epilog),
+	}
+}
+
+// copy returns a deep copy of the element and its descendants
+func (el *element) copy() *element {
+	return &element{
+		name:     el.name,
+		attr:     append([]xml.Attr{}, el.attr...),
+		children: copyTokens(el.children),
+	}
+}
+
+// copyTokens returns a deep copy of tokens, which are elements or tokens other than elements
+func copyTokens(tokens []xml.Token) []xml.Token {
+	if tokens == nil {
+		return nil
+	}
+	result := make([]xml.Token, len(tokens))
+	for i, token := range tokens {
+		if childElement, ok := token.(*element); ok {
+			result[i] = childElement.copy()
+		} else {
+			result[i] = xml.CopyToken(token)
+		}
+	}
+	return result
+}
+
+// text returns the character data of the element
+func (el *element) text() string {
+	var result []byte
+	for _, child := range el.children {
+		if charData, ok := child.(xml.CharData); ok {
+			result = append(result, charData...)
+		}
+	}
+	return string(result)
+}
+
+// setText replaces the content of the element with value
+func (el *element) setText(value string) {
+	el.children = []xml.Token{xml.CharData(value)}
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package ovf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RemoveEulas removes all the license agreements of the descriptor and returns how many were
+// removed
+func (envelope *Envelope) RemoveEulas() (int, error) {
+	removed := 0
+	err := envelope.edit(func() error {
+		removed = envelope.document.root.removeDescendants(func(el *element) bool {
+			return el.name.Local == "EulaSection"
+		})
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// RenameNetwork renames a logical network of the NetworkSection, and updates the network adapters
+// and the vCD network configuration which refer to it
+func (envelope *Envelope) RenameNetwork(oldName, newName string) error {
+	if newName == "" {
+		return fmt.Errorf("new network name must not be empty")
+	}
+	return envelope.edit(func() error {
+		return envelope.renameNetwork(oldName, newName)
+	})
+}
+
+// renameNetwork changes the document for RenameNetwork
+func (envelope *Envelope) renameNetwork(oldName, newName string) error {
+	var network *element
+	for _, networkSection := range envelope.document.root.childElements("NetworkSection") {
+		for _, candidate := range networkSection.childElements("Network") {
+			name, _ := candidate.attrValue("name")
+			if name == newName {
+				return fmt.Errorf("network '%s' already exists", newName)
+			}
+			if name == oldName {
+				network = candidate
+			}
+		}
+	}
+	if network == nil {
+		return fmt.Errorf("network '%s' not found in the NetworkSection", oldName)
+	}
+	envelope.document.setAttrValue(network, envelope.namespace(), "name", newName)
+
+	envelope.document.root.walk(func(el *element) {
+		switch el.name.Local {
+		case "Connection":
+			if strings.TrimSpace(el.text()) == oldName {
+				el.setText(newName)
+			}
+		case "NetworkConfig", "NetworkConnection":
+			// vCD specific sections
+			for _, attrName := range []string{"networkName", "network"} {
+				if value, found := el.attrValue(attrName); found && value == oldName {
+					envelope.document.setAttrValue(el, "", attrName, newName)
+				}
+			}
+		}
+	})
+	return nil
+}
+
+// SetPropertyDefault sets the default value of the product properties with the given key. The key
+// is either the plain key of the property or its fully qualified form "class.key.instance".
+func (envelope *Envelope) SetPropertyDefault(key, value string) error {
+	return envelope.edit(func() error {
+		return envelope.setPropertyDefault(key, value)
+	})
+}
+
+// setPropertyDefault changes the document for SetPropertyDefault
+func (envelope *Envelope) setPropertyDefault(key, value string) error {
+	found := false
+	envelope.document.root.walk(func(productSection *element) {
+		if productSection.name.Local != "ProductSection" {
+			return
+		}
+		class, _ := productSection.attrValue("class")
+		instance, _ := productSection.attrValue("instance")
+		for _, property := range productSection.childElements("Property") {
+			propertyKey, _ := property.attrValue("key")
+			if propertyKey == key || qualifiedPropertyKey(class, propertyKey, instance) == key {
+				envelope.document.setAttrValue(property, envelope.namespace(), "value", value)
+				found = true
+			}
+		}
+	})
+	if !found {
+		return fmt.Errorf("property '%s' not found", key)
+	}
+	return nil
+}
+
+// edit applies change to the document and updates the envelope. If change or the update fails, the
+// document is restored, so that a failed edit leaves the envelope unchanged.
+func (envelope *Envelope) edit(change func() error) error {
+	original := envelope.document.copy()
+	err := change()
+	if err == nil {
+		err = envelope.refresh()
+	}
+	if err != nil {
+		envelope.document = original
+	}
+	return err
+}
+
+// qualifiedPropertyKey returns the fully qualified key of a property, as used by the OVF environment
+func qualifiedPropertyKey(class, key, instance string) string {
+	qualifiedKey := key
+	if class != "" {
+		qualifiedKey = class + "." + qualifiedKey
+	}
+	if instance != "" {
+		qualifiedKey = qualifiedKey + "." + instance
+	}
+	return qualifiedKey
+}
+
+// namespace returns the OVF namespace of the descriptor
+func (envelope *Envelope) namespace() string {
+	return envelope.document.root.name.Space
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package ovf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// reparse writes the envelope and parses it again, to check that the edits are kept
+func reparse(t *testing.T, envelope *Envelope) *Envelope {
+	marshaled, err := envelope.Marshal()
+	if err != nil {
+		t.Fatalf("error writing descriptor: %s", err)
+	}
+	parsed, err := Parse(bytes.NewReader(marshaled))
+	if err != nil {
+		t.Fatalf("error parsing edited descriptor: %s\n%s", err, marshaled)
+	}
+	return parsed
+}
+
+func TestRemoveEulas(t *testing.T) {
+	envelope, err := Parse(strings.NewReader(ovf2Descriptor))
+	if err != nil {
+		t.Fatalf("error parsing descriptor: %s", err)
+	}
+
+	removed, err := envelope.RemoveEulas()
+	if err != nil || removed != 1 {
+		t.Fatalf("RemoveEulas() = %d, %v, want 1, nil", removed, err)
+	}
+	if len(envelope.VirtualSystem.EulaSections) != 0 {
+		t.Errorf("license agreements still present: %+v", envelope.VirtualSystem.EulaSections)
+	}
+
+	edited := reparse(t, envelope)
+	if len(edited.VirtualSystem.EulaSections) != 0 {
+		t.Errorf("license agreements still present after writing: %+v", edited.VirtualSystem.EulaSections)
+	}
+	if len(edited.VirtualSystem.ProductSections) != 1 {
+		t.Errorf("product section lost when removing the license agreements")
+	}
+
+	removed, err = envelope.RemoveEulas()
+	if err != nil || removed != 0 {
+		t.Errorf("RemoveEulas() on a descriptor without license = %d, %v, want 0, nil", removed, err)
+	}
+}
+
+func TestRenameNetwork(t *testing.T) {
+	envelope, err := ParseFile(testResources + "test_vapp_template_ovf/descriptor.ovf")
+	if err != nil {
+		t.Fatalf("error parsing descriptor: %s", err)
+	}
+
+	if err = envelope.RenameNetwork("unknown", "other"); err == nil {
+		t.Errorf("renaming an unknown network should fail")
+	}
+	if err = envelope.RenameNetwork("none", "none"); err == nil {
+		t.Errorf("renaming a network to an existing name should fail")
+	}
+
+	err = envelope.RenameNetwork("none", "production")
+	if err != nil {
+		t.Fatalf("error renaming network: %s", err)
+	}
+
+	edited := reparse(t, envelope)
+	if edited.NetworkSection.Networks[0].Name != "production" {
+		t.Errorf("network not renamed: %+v", edited.NetworkSection.Networks)
+	}
+	adapter := edited.VirtualSystems()[0].VirtualHardwareSections[0].Items[0]
+	if adapter.Connections[0] != "production" {
+		t.Errorf("network adapter not updated: %+v", adapter)
+	}
+	if err = edited.Validate(); err != nil {
+		t.Errorf("edited descriptor should be valid: %s", err)
+	}
+
+	marshaled, _ := envelope.Marshal()
+	if !strings.Contains(string(marshaled), `<vcloud:NetworkConfig networkName="production">`) {
+		t.Errorf("vCD network configuration not updated")
+	}
+	if !strings.Contains(string(marshaled), `<ovf:Network ovf:name="production">`) {
+		t.Errorf("namespace prefixes not kept")
+	}
+}
+
+func TestSetPropertyDefault(t *testing.T) {
+	envelope, err := Parse(strings.NewReader(ovf2Descriptor))
+	if err != nil {
+		t.Fatalf("error parsing descriptor: %s", err)
+	}
+
+	if err = envelope.SetPropertyDefault("unknown", "value"); err == nil {
+		t.Errorf("setting an unknown property should fail")
+	}
+
+	err = envelope.SetPropertyDefault("hostname", "appliance.example.com")
+	if err != nil {
+		t.Fatalf("error setting property: %s", err)
+	}
+	err = envelope.SetPropertyDefault("com.example.dns.1", "1.1.1.1")
+	if err != nil {
+		t.Fatalf("error setting property with qualified key: %s", err)
+	}
+
+	properties := reparse(t, envelope).VirtualSystem.ProductSections[0].Properties
+	if properties[0].Value != "appliance.example.com" {
+		t.Errorf("unexpected default of hostname: %+v", properties[0])
+	}
+	if properties[1].Value != "1.1.1.1" {
+		t.Errorf("unexpected default of dns: %+v", properties[1])
+	}
+}
+
+func TestSetPropertyDefaultNamespace(t *testing.T) {
+	// The OVF namespace is only the default namespace, which attributes can't use
+	descriptor := `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1">
+  <VirtualSystem id="appliance">
+    <Info>A virtual machine</Info>
+    <ProductSection>
+      <Info>Appliance settings</Info>
+      <Property key="hostname" type="string"/>
+    </ProductSection>
+  </VirtualSystem>
+</Envelope>
+`
+	envelope, err := Parse(strings.NewReader(descriptor))
+	if err != nil {
+		t.Fatalf("error parsing descriptor: %s", err)
+	}
+
+	err = envelope.SetPropertyDefault("hostname", "appliance.example.com")
+	if err != nil {
+		t.Fatalf("error setting property: %s", err)
+	}
+
+	marshaled, _ := envelope.Marshal()
+	if !strings.Contains(string(marshaled), `<Property key="hostname" type="string" ovf:value="appliance.example.com"/>`) {
+		t.Errorf("value not written with a declared prefix:\n%s", marshaled)
+	}
+	properties := reparse(t, envelope).VirtualSystem.ProductSections[0].Properties
+	if properties[0].Value != "appliance.example.com" {
+		t.Errorf("unexpected default of hostname: %+v", properties[0])
+	}
+}
+
+func TestFailedEdit(t *testing.T) {
+	envelope, err := Parse(strings.NewReader(ovf2Descriptor))
+	if err != nil {
+		t.Fatalf("error parsing descriptor: %s", err)
+	}
+	original, _ := envelope.Marshal()
+
+	err = envelope.edit(func() error {
+		envelope.document.root.removeDescendants(func(el *element) bool { return true })
+		return fmt.Errorf("edit failed")
+	})
+	if err == nil {
+		t.Fatalf("edit() should return the error of the change")
+	}
+	marshaled, _ := envelope.Marshal()
+	if !bytes.Equal(marshaled, original) {
+		t.Errorf("failed edit changed the descriptor:\n%s", marshaled)
+	}
+}
@@ -0,0 +1,262 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+// Package ovf parses, validates and edits OVF descriptors (OVF 1.x and 2.x), as contained in OVA
+// packages uploaded to vCD catalogs.
+package ovf
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Namespaces of the OVF envelope
+const (
+	NamespaceOvf1 = "http://schemas.dmtf.org/ovf/envelope/1"
+	NamespaceOvf2 = "http://schemas.dmtf.org/ovf/envelope/2"
+)
+
+// Envelope is the root element of an OVF descriptor. The exported fields are a read-only view of
+// the descriptor: changes must be made with the editing methods (such as RemoveEulas), which keep
+// the sections unknown to this package when the descriptor is written back with Marshal.
+type Envelope struct {
+	XMLName                 xml.Name                 `xml:"Envelope"`
+	References              []File                   `xml:"References>File"`
+	DiskSection             *DiskSection             `xml:"DiskSection"`
+	NetworkSection          *NetworkSection          `xml:"NetworkSection"`
+	VirtualSystem           *VirtualSystem           `xml:"VirtualSystem"`
+	VirtualSystemCollection *VirtualSystemCollection `xml:"VirtualSystemCollection"`
+
+	document *document
+}
+
+// File is a file referenced by the descriptor
+type File struct {
+	HREF        string `xml:"href,attr"`
+	ID          string `xml:"id,attr"`
+	Size        int64  `xml:"size,attr,omitempty"`      // 0 when not declared
+	ChunkSize   int64  `xml:"chunkSize,attr,omitempty"` // Set when the file is split into chunks
+	Compression string `xml:"compression,attr,omitempty"`
+}
+
+// DiskSection describes the virtual disks of the package
+type DiskSection struct {
+	Info  string `xml:"Info"`
+	Disks []Disk `xml:"Disk"`
+}
+
+// Disk is a virtual disk
+type Disk struct {
+	DiskID                  string `xml:"diskId,attr"`
+	FileRef                 string `xml:"fileRef,attr,omitempty"` // ID of the File containing the disk. Empty for blank disks
+	Capacity                string `xml:"capacity,attr"`
+	CapacityAllocationUnits string `xml:"capacityAllocationUnits,attr,omitempty"`
+	Format                  string `xml:"format,attr,omitempty"`
+	PopulatedSize           int64  `xml:"populatedSize,attr,omitempty"`
+}
+
+// NetworkSection describes the logical networks used by the package
+type NetworkSection struct {
+	Info     string    `xml:"Info"`
+	Networks []Network `xml:"Network"`
+}
+
+// Network is a logical network
+type Network struct {
+	Name        string `xml:"name,attr"`
+	Description string `xml:"Description"`
+}
+
+// VirtualSystemCollection is a group of virtual systems, such as a vApp
+type VirtualSystemCollection struct {
+	ID                       string                    `xml:"id,attr"`
+	Info                     string                    `xml:"Info"`
+	Name                     string                    `xml:"Name"`
+	ProductSections          []ProductSection          `xml:"ProductSection"`
+	EulaSections             []EulaSection             `xml:"EulaSection"`
+	VirtualSystems           []VirtualSystem           `xml:"VirtualSystem"`
+	VirtualSystemCollections []VirtualSystemCollection `xml:"VirtualSystemCollection"`
+}
+
+// VirtualSystem is a virtual machine
+type VirtualSystem struct {
+	ID                      string                   `xml:"id,attr"`
+	Info                    string                   `xml:"Info"`
+	Name                    string                   `xml:"Name"`
+	OperatingSystemSection  *OperatingSystemSection  `xml:"OperatingSystemSection"`
+	ProductSections         []ProductSection         `xml:"ProductSection"`
+	EulaSections            []EulaSection            `xml:"EulaSection"`
+	VirtualHardwareSections []VirtualHardwareSection `xml:"VirtualHardwareSection"`
+}
+
+// OperatingSystemSection describes the guest operating system
+type OperatingSystemSection struct {
+	ID          int    `xml:"id,attr"`
+	Version     string `xml:"version,attr,omitempty"`
+	OsType      string `xml:"osType,attr,omitempty"` // VMware specific guest OS identifier
+	Info        string `xml:"Info"`
+	Description string `xml:"Description"`
+}
+
+// VirtualHardwareSection describes the virtual hardware of a virtual system
+type VirtualHardwareSection struct {
+	Info   string  `xml:"Info"`
+	System *System `xml:"System"`
+	Items  []Item  `xml:"Item"`
+	// OVF 2.x specific items
+	EthernetPortItems []Item `xml:"EthernetPortItem"`
+	StorageItems      []Item `xml:"StorageItem"`
+}
+
+// System describes the virtual hardware family
+type System struct {
+	ElementName             string `xml:"ElementName"`
+	InstanceID              string `xml:"InstanceID"`
+	VirtualSystemIdentifier string `xml:"VirtualSystemIdentifier"`
+	VirtualSystemType       string `xml:"VirtualSystemType"`
+}
+
+// Item is a virtual hardware resource (RASD)
+type Item struct {
+	ElementName          string   `xml:"ElementName"`
+	Description          string   `xml:"Description"`
+	InstanceID           string   `xml:"InstanceID"`
+	ResourceType         int      `xml:"ResourceType"`
+	ResourceSubType      string   `xml:"ResourceSubType"`
+	Address              string   `xml:"Address"`
+	AddressOnParent      string   `xml:"AddressOnParent"`
+	Parent               string   `xml:"Parent"`
+	AllocationUnits      string   `xml:"AllocationUnits"`
+	VirtualQuantity      int64    `xml:"VirtualQuantity"`
+	VirtualQuantityUnits string   `xml:"VirtualQuantityUnits"`
+	Reservation          int64    `xml:"Reservation"`
+	Limit                int64    `xml:"Limit"`
+	Weight               int64    `xml:"Weight"`
+	AutomaticAllocation  bool     `xml:"AutomaticAllocation"`
+	Connections          []string `xml:"Connection"`   // Names of the networks of a network adapter
+	HostResources        []string `xml:"HostResource"` // e.g. "ovf:/disk/vmdisk1" for a hard disk
+}
+
+// ProductSection describes a product installed in a virtual system, and its properties
+type ProductSection struct {
+	Class       string     `xml:"class,attr,omitempty"`
+	Instance    string     `xml:"instance,attr,omitempty"`
+	Info        string     `xml:"Info"`
+	Product     string     `xml:"Product"`
+	Vendor      string     `xml:"Vendor"`
+	Version     string     `xml:"Version"`
+	FullVersion string     `xml:"FullVersion"`
+	ProductUrl  string     `xml:"ProductUrl"`
+	VendorUrl   string     `xml:"VendorUrl"`
+	Properties  []Property `xml:"Property"`
+}
+
+// Property is a configurable value of a product
+type Property struct {
+	Key              string `xml:"key,attr"`
+	Type             string `xml:"type,attr"`
+	Value            string `xml:"value,attr,omitempty"` // Default value
+	UserConfigurable bool   `xml:"userConfigurable,attr,omitempty"`
+	Password         bool   `xml:"password,attr,omitempty"`
+	Label            string `xml:"Label"`
+	Description      string `xml:"Description"`
+}
+
+// EulaSection contains a license agreement which must be accepted when deploying the package
+type EulaSection struct {
+	Info    string `xml:"Info"`
+	License string `xml:"License"`
+}
+
+// Parse reads an OVF descriptor
+func Parse(reader io.Reader) (*Envelope, error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading OVF descriptor: %s", err)
+	}
+
+	doc, err := parseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing OVF descriptor: %s", err)
+	}
+	if doc.root.name.Local != "Envelope" {
+		return nil, fmt.Errorf("OVF descriptor root element is %s instead of Envelope", doc.root.name.Local)
+	}
+	if doc.root.name.Space != NamespaceOvf1 && doc.root.name.Space != NamespaceOvf2 {
+		return nil, fmt.Errorf("unsupported OVF namespace '%s'", doc.root.name.Space)
+	}
+
+	envelope := &Envelope{document: doc}
+	err = xml.Unmarshal(data, envelope)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing OVF descriptor: %s", err)
+	}
+	return envelope, nil
+}
+
+// ParseFile reads the OVF descriptor at path
+func ParseFile(path string) (*Envelope, error) {
+	// #nosec G304 - the path of the descriptor is provided by the caller
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+// Version returns the major version of the OVF specification used by the descriptor ("1.x" or "2.x")
+func (envelope *Envelope) Version() string {
+	if envelope.document.root.name.Space == NamespaceOvf2 {
+		return "2.x"
+	}
+	return "1.x"
+}
+
+// VirtualSystems returns all the virtual systems of the descriptor, including those nested in
+// collections
+func (envelope *Envelope) VirtualSystems() []VirtualSystem {
+	var result []VirtualSystem
+	if envelope.VirtualSystem != nil {
+		result = append(result, *envelope.VirtualSystem)
+	}
+	if envelope.VirtualSystemCollection != nil {
+		result = append(result, envelope.VirtualSystemCollection.allVirtualSystems()...)
+	}
+	return result
+}
+
+// allVirtualSystems returns the virtual systems of the collection and of its nested collections
+func (collection *VirtualSystemCollection) allVirtualSystems() []VirtualSystem {
+	result := append([]VirtualSystem{}, collection.VirtualSystems...)
+	for i := range collection.VirtualSystemCollections {
+		result = append(result, collection.VirtualSystemCollections[i].allVirtualSystems()...)
+	}
+	return result
+}
+
+// Marshal returns the descriptor, including the changes made with the editing methods. Elements,
+// namespace prefixes, comments and whitespace between elements are kept as they were parsed.
+func (envelope *Envelope) Marshal() ([]byte, error) {
+	return envelope.document.marshal()
+}
+
+// refresh rebuilds the exported view of the descriptor after an edit
+func (envelope *Envelope) refresh() error {
+	data, err := envelope.document.marshal()
+	if err != nil {
+		return err
+	}
+	refreshed := Envelope{document: envelope.document}
+	err = xml.Unmarshal(data, &refreshed)
+	if err != nil {
+		return fmt.Errorf("error parsing edited OVF descriptor: %s", err)
+	}
+	*envelope = refreshed
+	return nil
+}
@@ -0,0 +1,238 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package ovf
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+const testResources = "../test-resources/"
+
+// ovf2Descriptor is an OVF 2.x descriptor with a product section, a license agreement and OVF 2.x
+// specific virtual hardware items
+const ovf2Descriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/2" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/2" xmlns:epasd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_EthernetPortAllocationSettingData" xmlns:sasd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_StorageAllocationSettingData" xmlns:vssd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_VirtualSystemSettingData">
+  <References>
+    <File ovf:href="appliance-disk1.vmdk" ovf:id="file1" ovf:size="1024"/>
+  </References>
+  <DiskSection>
+    <Info>Virtual disk information</Info>
+    <Disk ovf:capacity="2" ovf:capacityAllocationUnits="byte * 2^30" ovf:diskId="vmdisk1" ovf:fileRef="file1"/>
+  </DiskSection>
+  <NetworkSection>
+    <Info>The list of logical networks</Info>
+    <Network ovf:name="VM Network">
+      <Description>The VM Network network</Description>
+    </Network>
+  </NetworkSection>
+  <VirtualSystem ovf:id="appliance">
+    <Info>A virtual machine</Info>
+    <Name>appliance</Name>
+    <ProductSection ovf:class="com.example" ovf:instance="1">
+      <Info>Appliance settings</Info>
+      <Product>Example appliance</Product>
+      <Vendor>Example</Vendor>
+      <Version>1.0</Version>
+      <Property ovf:key="hostname" ovf:type="string" ovf:userConfigurable="true">
+        <Label>Host name</Label>
+      </Property>
+      <Property ovf:key="dns" ovf:type="string" ovf:value="8.8.8.8"/>
+    </ProductSection>
+    <EulaSection>
+      <Info>End user license agreement</Info>
+      <License>Use at your own risk &amp; have fun</License>
+    </EulaSection>
+    <VirtualHardwareSection>
+      <Info>Virtual hardware requirements</Info>
+      <System>
+        <vssd:ElementName>Virtual Hardware Family</vssd:ElementName>
+        <vssd:InstanceID>0</vssd:InstanceID>
+        <vssd:VirtualSystemType>vmx-13</vssd:VirtualSystemType>
+      </System>
+      <EthernetPortItem>
+        <epasd:Connection>VM Network</epasd:Connection>
+        <epasd:ElementName>Network adapter 1</epasd:ElementName>
+        <epasd:InstanceID>3</epasd:InstanceID>
+        <epasd:ResourceType>10</epasd:ResourceType>
+      </EthernetPortItem>
+      <StorageItem>
+        <sasd:ElementName>Hard disk 1</sasd:ElementName>
+        <sasd:HostResource>ovf:/disk/vmdisk1</sasd:HostResource>
+        <sasd:InstanceID>4</sasd:InstanceID>
+        <sasd:ResourceType>17</sasd:ResourceType>
+      </StorageItem>
+    </VirtualHardwareSection>
+  </VirtualSystem>
+</Envelope>
+`
+
+func TestParse(t *testing.T) {
+	envelope, err := ParseFile(testResources + "test_vapp_template_ovf/descriptor.ovf")
+	if err != nil {
+		t.Fatalf("error parsing descriptor: %s", err)
+	}
+
+	if envelope.Version() != "1.x" {
+		t.Errorf("expected version 1.x, got %s", envelope.Version())
+	}
+	if len(envelope.References) != 1 || envelope.References[0].Size != 68096 {
+		t.Errorf("unexpected references: %+v", envelope.References)
+	}
+	if envelope.DiskSection == nil || len(envelope.DiskSection.Disks) != 1 ||
+		envelope.DiskSection.Disks[0].FileRef != envelope.References[0].ID {
+		t.Errorf("unexpected disk section: %+v", envelope.DiskSection)
+	}
+	if envelope.NetworkSection == nil || len(envelope.NetworkSection.Networks) != 1 ||
+		envelope.NetworkSection.Networks[0].Name != "none" {
+		t.Errorf("unexpected network section: %+v", envelope.NetworkSection)
+	}
+	if envelope.VirtualSystemCollection == nil || envelope.VirtualSystemCollection.Name != "test_vapp_template" {
+		t.Fatalf("unexpected virtual system collection: %+v", envelope.VirtualSystemCollection)
+	}
+
+	virtualSystems := envelope.VirtualSystems()
+	if len(virtualSystems) != 1 || virtualSystems[0].Name != "testvm1" {
+		t.Fatalf("unexpected virtual systems: %+v", virtualSystems)
+	}
+	if virtualSystems[0].OperatingSystemSection == nil || virtualSystems[0].OperatingSystemSection.OsType != "windows9Server64Guest" {
+		t.Errorf("unexpected operating system section: %+v", virtualSystems[0].OperatingSystemSection)
+	}
+	hardware := virtualSystems[0].VirtualHardwareSections[0]
+	if hardware.System == nil || hardware.System.VirtualSystemType != "vmx-11" {
+		t.Errorf("unexpected virtual hardware system: %+v", hardware.System)
+	}
+	if len(hardware.Items) != 8 {
+		t.Errorf("expected 8 virtual hardware items, got %d", len(hardware.Items))
+	}
+	if hardware.Items[0].ResourceType != 10 || len(hardware.Items[0].Connections) != 1 || hardware.Items[0].Connections[0] != "none" {
+		t.Errorf("unexpected network adapter: %+v", hardware.Items[0])
+	}
+}
+
+func TestParseOvf2(t *testing.T) {
+	envelope, err := Parse(strings.NewReader(ovf2Descriptor))
+	if err != nil {
+		t.Fatalf("error parsing descriptor: %s", err)
+	}
+
+	if envelope.Version() != "2.x" {
+		t.Errorf("expected version 2.x, got %s", envelope.Version())
+	}
+	if envelope.VirtualSystem == nil {
+		t.Fatalf("virtual system not found")
+	}
+
+	productSections := envelope.VirtualSystem.ProductSections
+	if len(productSections) != 1 || len(productSections[0].Properties) != 2 {
+		t.Fatalf("unexpected product sections: %+v", productSections)
+	}
+	hostname := productSections[0].Properties[0]
+	if hostname.Key != "hostname" || !hostname.UserConfigurable || hostname.Label != "Host name" {
+		t.Errorf("unexpected property: %+v", hostname)
+	}
+	if productSections[0].Properties[1].Value != "8.8.8.8" {
+		t.Errorf("unexpected property default: %+v", productSections[0].Properties[1])
+	}
+
+	eulas := envelope.VirtualSystem.EulaSections
+	if len(eulas) != 1 || eulas[0].License != "Use at your own risk & have fun" {
+		t.Errorf("unexpected license agreements: %+v", eulas)
+	}
+
+	hardware := envelope.VirtualSystem.VirtualHardwareSections[0]
+	if len(hardware.EthernetPortItems) != 1 || hardware.EthernetPortItems[0].Connections[0] != "VM Network" {
+		t.Errorf("unexpected ethernet port items: %+v", hardware.EthernetPortItems)
+	}
+	if len(hardware.StorageItems) != 1 || hardware.StorageItems[0].HostResources[0] != "ovf:/disk/vmdisk1" {
+		t.Errorf("unexpected storage items: %+v", hardware.StorageItems)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor string
+	}{
+		{name: "NotXml", descriptor: "not a descriptor"},
+		{name: "OtherRoot", descriptor: `<VirtualSystem xmlns="http://schemas.dmtf.org/ovf/envelope/1"/>`},
+		{name: "UnknownNamespace", descriptor: `<Envelope xmlns="http://example.com/envelope"/>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.descriptor))
+			if err == nil {
+				t.Errorf("Parse() should fail")
+			}
+		})
+	}
+}
+
+// TestMarshalUnchanged checks that a descriptor which was not edited is written back identically
+func TestMarshalUnchanged(t *testing.T) {
+	for _, descriptor := range []string{testResources + "test_vapp_template_ovf/descriptor.ovf", ""} {
+		var original []byte
+		var err error
+		if descriptor == "" {
+			original = []byte(ovf2Descriptor)
+		} else {
+			original, err = ioutil.ReadFile(descriptor)
+			if err != nil {
+				t.Fatalf("error reading descriptor: %s", err)
+			}
+		}
+
+		envelope, err := Parse(bytes.NewReader(original))
+		if err != nil {
+			t.Fatalf("error parsing descriptor: %s", err)
+		}
+		marshaled, err := envelope.Marshal()
+		if err != nil {
+			t.Fatalf("error writing descriptor: %s", err)
+		}
+		if !bytes.Equal(marshaled, original) {
+			t.Errorf("descriptor changed when written back:\n%s", marshaled)
+		}
+	}
+}
+
+func TestReadOva(t *testing.T) {
+	tests := []struct {
+		ova            string
+		virtualSystems int
+		withManifest   bool
+		chunkSize      int64
+	}{
+		{ova: "test_vapp_template.ova", virtualSystems: 1},
+		{ova: "vapp_with_3_vms.ova", virtualSystems: 3, withManifest: true},
+		{ova: "template_with_custom_chunk_size.ova", virtualSystems: 1, chunkSize: 40960},
+		{ova: "template_without_vmdk_size.ova", virtualSystems: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ova, func(t *testing.T) {
+			envelope, manifest, descriptorName, err := ReadOva(testResources + tt.ova)
+			if err != nil {
+				t.Fatalf("error reading OVA: %s", err)
+			}
+			if descriptorName != "descriptor.ovf" {
+				t.Errorf("unexpected descriptor name %s", descriptorName)
+			}
+			if len(envelope.VirtualSystems()) != tt.virtualSystems {
+				t.Errorf("expected %d virtual systems, got %d", tt.virtualSystems, len(envelope.VirtualSystems()))
+			}
+			if (manifest != nil) != tt.withManifest {
+				t.Errorf("unexpected manifest: %v", manifest)
+			}
+			if envelope.References[0].ChunkSize != tt.chunkSize {
+				t.Errorf("expected chunk size %d, got %d", tt.chunkSize, envelope.References[0].ChunkSize)
+			}
+			if err = envelope.Validate(); err != nil {
+				t.Errorf("descriptor should be valid: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package ovf
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1" // #nosec G505 - SHA1 digests are part of the OVF 1.x specification
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Digest algorithms of the manifest
+const (
+	DigestSha1   = "SHA1"
+	DigestSha256 = "SHA256"
+	DigestSha512 = "SHA512"
+)
+
+// manifestLine matches a manifest entry such as "SHA256(descriptor.ovf)= 0123abcd"
+var manifestLine = regexp.MustCompile(`^(SHA1|SHA256|SHA512)\((.+)\)\s*=\s*([0-9a-fA-F]+)$`)
+
+// Digest is the checksum of a file listed in a manifest
+type Digest struct {
+	Algorithm string // DigestSha1, DigestSha256 or DigestSha512
+	Value     string // Lower case hex encoded checksum
+}
+
+// Manifest contains the digests of the files of an OVF package (.mf file), indexed by file name
+type Manifest map[string]Digest
+
+// ParseManifest reads a manifest
+func ParseManifest(reader io.Reader) (Manifest, error) {
+	manifest := make(Manifest)
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		matches := manifestLine.FindStringSubmatch(line)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid manifest entry at line %d: %s", lineNumber, line)
+		}
+		manifest[matches[2]] = Digest{Algorithm: matches[1], Value: strings.ToLower(matches[3])}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading manifest: %s", err)
+	}
+	return manifest, nil
+}
+
+// Verify checks that the content read from reader matches the digest of the file name
+func (manifest Manifest) Verify(name string, reader io.Reader) error {
+	digest, found := manifest[name]
+	if !found {
+		return fmt.Errorf("file %s is not listed in the manifest", name)
+	}
+
+	hasher, err := newHash(digest.Algorithm)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(hasher, reader)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %s", name, err)
+	}
+
+	actual := hex.EncodeToString(hasher.Sum(nil))
+	if actual != digest.Value {
+		return fmt.Errorf("%s digest of %s does not match the manifest: expected %s, got %s",
+			digest.Algorithm, name, digest.Value, actual)
+	}
+	return nil
+}
+
+// Set records the digest of content for the file name, such as an edited descriptor. The algorithm
+// already used for the file is kept, and SHA256 is used for new files.
+func (manifest Manifest) Set(name string, content []byte) error {
+	algorithm := DigestSha256
+	if digest, found := manifest[name]; found {
+		algorithm = digest.Algorithm
+	}
+
+	hasher, err := newHash(algorithm)
+	if err != nil {
+		return err
+	}
+	_, _ = hasher.Write(content)
+	manifest[name] = Digest{Algorithm: algorithm, Value: hex.EncodeToString(hasher.Sum(nil))}
+	return nil
+}
+
+// Marshal returns the manifest in the .mf file format, with the files sorted by name
+func (manifest Manifest) Marshal() []byte {
+	names := make([]string, 0, len(manifest))
+	for name := range manifest {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buffer bytes.Buffer
+	for _, name := range names {
+		digest := manifest[name]
+		buffer.WriteString(fmt.Sprintf("%s(%s)= %s\n", digest.Algorithm, name, digest.Value))
+	}
+	return buffer.Bytes()
+}
+
+// newHash returns the hash function of a digest algorithm
+func newHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case DigestSha1:
+		return sha1.New(), nil // #nosec G401 - SHA1 digests are part of the OVF 1.x specification
+	case DigestSha256:
+		return sha256.New(), nil
+	case DigestSha512:
+		return sha512.New(), nil
+	}
+	return nil, fmt.Errorf("unsupported digest algorithm '%s'", algorithm)
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package ovf
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseManifest(t *testing.T) {
+	manifest, err := ParseManifest(strings.NewReader(
+		"SHA1(descriptor.ovf)= 685D5D524B01C50881913E1B2EA5BD1400344347\n\n" +
+			"SHA256(disk 1.vmdk) = 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824\n"))
+	if err != nil {
+		t.Fatalf("error parsing manifest: %s", err)
+	}
+
+	want := Manifest{
+		"descriptor.ovf": {Algorithm: DigestSha1, Value: "685d5d524b01c50881913e1b2ea5bd1400344347"},
+		"disk 1.vmdk":    {Algorithm: DigestSha256, Value: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+	}
+	if !reflect.DeepEqual(manifest, want) {
+		t.Errorf("ParseManifest() = %v, want %v", manifest, want)
+	}
+
+	_, err = ParseManifest(strings.NewReader("MD5(descriptor.ovf)= 0123"))
+	if err == nil {
+		t.Errorf("ParseManifest() should fail with an unsupported algorithm")
+	}
+}
+
+func TestManifestVerify(t *testing.T) {
+	manifest := Manifest{
+		"hello.txt": {Algorithm: DigestSha256, Value: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+	}
+
+	if err := manifest.Verify("hello.txt", strings.NewReader("hello")); err != nil {
+		t.Errorf("Verify() returned error: %s", err)
+	}
+	if err := manifest.Verify("hello.txt", strings.NewReader("hello!")); err == nil {
+		t.Errorf("Verify() should fail with a different content")
+	}
+	if err := manifest.Verify("other.txt", strings.NewReader("hello")); err == nil {
+		t.Errorf("Verify() should fail with a file not listed in the manifest")
+	}
+}
+
+func TestManifestSetAndMarshal(t *testing.T) {
+	manifest := Manifest{
+		"descriptor.ovf": {Algorithm: DigestSha1, Value: "0000"},
+	}
+
+	err := manifest.Set("descriptor.ovf", []byte("hello"))
+	if err != nil {
+		t.Fatalf("error setting digest: %s", err)
+	}
+	err = manifest.Set("disk.vmdk", []byte("hello"))
+	if err != nil {
+		t.Fatalf("error setting digest: %s", err)
+	}
+
+	want := "SHA1(descriptor.ovf)= aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d\n" +
+		"SHA256(disk.vmdk)= 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824\n"
+	if got := string(manifest.Marshal()); got != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+
+	parsed, err := ParseManifest(strings.NewReader(want))
+	if err != nil || !reflect.DeepEqual(parsed, manifest) {
+		t.Errorf("manifest changed when parsed back: %v, %v", parsed, err)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package ovf
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ReadOva reads the OVF descriptor and the manifest of an OVA package without extracting it. The
+// manifest is nil when the package doesn't contain one. The name of the descriptor within the
+// package is returned too, as it is listed in the manifest.
+func ReadOva(path string) (*Envelope, Manifest, string, error) {
+	// #nosec G304 - the path of the package is provided by the caller
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	defer file.Close()
+
+	var envelope *Envelope
+	var manifest Manifest
+	var descriptorName string
+	reader := tar.NewReader(file)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("error reading OVA %s: %s", path, err)
+		}
+
+		switch filepath.Ext(header.Name) {
+		case ".ovf":
+			if envelope != nil {
+				return nil, nil, "", fmt.Errorf("OVA %s contains more than one OVF descriptor", path)
+			}
+			envelope, err = Parse(reader)
+			if err != nil {
+				return nil, nil, "", err
+			}
+			descriptorName = header.Name
+		case ".mf":
+			manifest, err = ParseManifest(reader)
+			if err != nil {
+				return nil, nil, "", err
+			}
+		}
+	}
+
+	if envelope == nil {
+		return nil, nil, "", fmt.Errorf("OVA %s does not contain an OVF descriptor", path)
+	}
+	return envelope, manifest, descriptorName, nil
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package ovf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Host resource prefixes of the virtual hardware items
+const (
+	hostResourceDisk = "ovf:/disk/"
+	hostResourceFile = "ovf:/file/"
+)
+
+// Validate checks the consistency of the descriptor: the package must contain at least one virtual
+// system, and the references between files, disks, networks and virtual hardware items must be
+// valid. All the problems found are reported in the returned error.
+func (envelope *Envelope) Validate() error {
+	var problems []string
+
+	fileIds := make(map[string]bool)
+	for _, file := range envelope.References {
+		if file.HREF == "" {
+			problems = append(problems, fmt.Sprintf("file '%s' has no href", file.ID))
+		}
+		if fileIds[file.ID] {
+			problems = append(problems, fmt.Sprintf("file ID '%s' is used more than once", file.ID))
+		}
+		fileIds[file.ID] = true
+	}
+
+	diskIds := make(map[string]bool)
+	if envelope.DiskSection != nil {
+		for _, disk := range envelope.DiskSection.Disks {
+			if diskIds[disk.DiskID] {
+				problems = append(problems, fmt.Sprintf("disk ID '%s' is used more than once", disk.DiskID))
+			}
+			diskIds[disk.DiskID] = true
+			if disk.FileRef != "" && !fileIds[disk.FileRef] {
+				problems = append(problems, fmt.Sprintf("disk '%s' refers to unknown file '%s'", disk.DiskID, disk.FileRef))
+			}
+		}
+	}
+
+	networkNames := make(map[string]bool)
+	if envelope.NetworkSection != nil {
+		for _, network := range envelope.NetworkSection.Networks {
+			networkNames[network.Name] = true
+		}
+	}
+
+	virtualSystems := envelope.VirtualSystems()
+	if len(virtualSystems) == 0 {
+		problems = append(problems, "descriptor contains no virtual system")
+	}
+	for _, virtualSystem := range virtualSystems {
+		for _, hardwareSection := range virtualSystem.VirtualHardwareSections {
+			for _, item := range hardwareSection.allItems() {
+				for _, hostResource := range item.HostResources {
+					switch {
+					case strings.HasPrefix(hostResource, hostResourceDisk):
+						if !diskIds[strings.TrimPrefix(hostResource, hostResourceDisk)] {
+							problems = append(problems, fmt.Sprintf("item '%s' of virtual system '%s' refers to unknown disk '%s'",
+								item.ElementName, virtualSystem.ID, hostResource))
+						}
+					case strings.HasPrefix(hostResource, hostResourceFile):
+						if !fileIds[strings.TrimPrefix(hostResource, hostResourceFile)] {
+							problems = append(problems, fmt.Sprintf("item '%s' of virtual system '%s' refers to unknown file '%s'",
+								item.ElementName, virtualSystem.ID, hostResource))
+						}
+					}
+				}
+				for _, connection := range item.Connections {
+					connection = strings.TrimSpace(connection)
+					if connection != "" && !networkNames[connection] {
+						problems = append(problems, fmt.Sprintf("item '%s' of virtual system '%s' refers to unknown network '%s'",
+							item.ElementName, virtualSystem.ID, connection))
+					}
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid OVF descriptor: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ValidateFiles checks the files referenced by the descriptor, which must be in dir. Each file, or
+// each of its chunks, must exist and have the size declared in the descriptor. When manifest is
+// not nil, every file must be listed in it with a matching digest, and the other files listed in
+// the manifest which are present in dir (such as the descriptor itself) are verified too.
+// File names which are absolute or refer to files outside of dir are rejected.
+func (envelope *Envelope) ValidateFiles(dir string, manifest Manifest) error {
+	checked := make(map[string]bool)
+	for _, file := range envelope.References {
+		fileNames := []string{file.HREF}
+		if file.ChunkSize > 0 {
+			fileNames = ChunkNames(file.HREF, file.Size, file.ChunkSize)
+		}
+
+		var totalSize int64
+		for _, fileName := range fileNames {
+			filePath, err := packageFilePath(dir, fileName)
+			if err != nil {
+				return fmt.Errorf("file '%s' described in the OVF descriptor: %s", fileName, err)
+			}
+			fileInfo, err := os.Stat(filePath)
+			if err != nil {
+				return fmt.Errorf("file '%s' described in the OVF descriptor was not found: %s", fileName, err)
+			}
+			totalSize += fileInfo.Size()
+
+			if manifest != nil {
+				err = verifyFile(manifest, dir, fileName)
+				if err != nil {
+					return err
+				}
+				checked[fileName] = true
+			}
+		}
+
+		if file.Size > 0 && totalSize != file.Size {
+			return fmt.Errorf("size of file '%s' is %d instead of %d as described in the OVF descriptor",
+				file.HREF, totalSize, file.Size)
+		}
+	}
+
+	for fileName := range manifest {
+		if checked[fileName] {
+			continue
+		}
+		filePath, err := packageFilePath(dir, fileName)
+		if err != nil {
+			return fmt.Errorf("file '%s' listed in the manifest: %s", fileName, err)
+		}
+		_, err = os.Stat(filePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		err = verifyFile(manifest, dir, fileName)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChunkNames returns the names of the chunks of a file of size bytes split in chunks of chunkSize
+// bytes, such as disk.vmdk.000000000, disk.vmdk.000000001 and so on
+func ChunkNames(href string, size, chunkSize int64) []string {
+	var names []string
+	for offset, index := int64(0), 0; offset < size; offset, index = offset+chunkSize, index+1 {
+		names = append(names, fmt.Sprintf("%s.%09d", href, index))
+	}
+	return names
+}
+
+// packageFilePath returns the path of the file fileName of the package extracted in dir. The names
+// come from the descriptor or the manifest, so they are only accepted when they are relative
+// and stay inside dir.
+func packageFilePath(dir, fileName string) (string, error) {
+	if fileName == "" || filepath.IsAbs(fileName) || strings.HasPrefix(fileName, "/") || strings.HasPrefix(fileName, "\\") {
+		return "", fmt.Errorf("file name must be relative to the package")
+	}
+	for _, element := range strings.FieldsFunc(fileName, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if element == ".." {
+			return "", fmt.Errorf("file name must not refer to a parent directory")
+		}
+	}
+
+	filePath := filepath.Join(dir, fileName)
+	relativePath, err := filepath.Rel(dir, filePath)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("file is outside of the package directory")
+	}
+	return filePath, nil
+}
+
+// verifyFile checks the digest of the file fileName of dir
+func verifyFile(manifest Manifest, dir, fileName string) error {
+	filePath, err := packageFilePath(dir, fileName)
+	if err != nil {
+		return fmt.Errorf("file '%s': %s", fileName, err)
+	}
+	// #nosec G304 - the path is checked to be inside of the package directory
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return manifest.Verify(fileName, file)
+}
+
+// allItems returns all the virtual hardware items of the section
+func (section *VirtualHardwareSection) allItems() []Item {
+	var items []Item
+	items = append(items, section.Items...)
+	items = append(items, section.EthernetPortItems...)
+	items = append(items, section.StorageItems...)
+	return items
+}
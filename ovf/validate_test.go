@@ -0,0 +1,250 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package ovf
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// extractOva extracts an OVA from the test resources into a temporary directory
+func extractOva(t *testing.T, ova string) string {
+	dir, err := ioutil.TempDir("", "ovf-test")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %s", err)
+	}
+
+	file, err := os.Open(testResources + ova)
+	if err != nil {
+		t.Fatalf("error opening OVA: %s", err)
+	}
+	defer file.Close()
+
+	reader := tar.NewReader(file)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("error reading OVA: %s", err)
+		}
+		content, err := ioutil.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("error reading %s: %s", header.Name, err)
+		}
+		err = ioutil.WriteFile(filepath.Join(dir, filepath.Base(header.Name)), content, 0600)
+		if err != nil {
+			t.Fatalf("error writing %s: %s", header.Name, err)
+		}
+	}
+	return dir
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    func(string) string
+		problem string
+	}{
+		{
+			name: "UnknownFile",
+			edit: func(descriptor string) string {
+				return strings.Replace(descriptor, `ovf:fileRef="file1"`, `ovf:fileRef="file2"`, 1)
+			},
+			problem: "unknown file 'file2'",
+		},
+		{
+			name: "UnknownDisk",
+			edit: func(descriptor string) string {
+				return strings.Replace(descriptor, "<sasd:HostResource>ovf:/disk/vmdisk1", "<sasd:HostResource>ovf:/disk/vmdisk2", 1)
+			},
+			problem: "unknown disk 'ovf:/disk/vmdisk2'",
+		},
+		{
+			name: "UnknownNetwork",
+			edit: func(descriptor string) string {
+				return strings.Replace(descriptor, "<epasd:Connection>VM Network", "<epasd:Connection>Other", 1)
+			},
+			problem: "unknown network 'Other'",
+		},
+		{
+			name: "NoVirtualSystem",
+			edit: func(descriptor string) string {
+				return descriptor[:strings.Index(descriptor, "  <VirtualSystem ")] + "</Envelope>"
+			},
+			problem: "no virtual system",
+		},
+	}
+
+	envelope, err := Parse(strings.NewReader(ovf2Descriptor))
+	if err != nil {
+		t.Fatalf("error parsing descriptor: %s", err)
+	}
+	if err = envelope.Validate(); err != nil {
+		t.Errorf("descriptor should be valid: %s", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, err := Parse(strings.NewReader(tt.edit(ovf2Descriptor)))
+			if err != nil {
+				t.Fatalf("error parsing descriptor: %s", err)
+			}
+			err = envelope.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.problem) {
+				t.Errorf("Validate() = %v, want error containing %s", err, tt.problem)
+			}
+		})
+	}
+}
+
+func TestValidateFiles(t *testing.T) {
+	tests := []struct {
+		ova string
+	}{
+		{ova: "test_vapp_template.ova"},
+		{ova: "vapp_with_3_vms.ova"},
+		{ova: "template_with_custom_chunk_size.ova"},
+		{ova: "template_without_vmdk_size.ova"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ova, func(t *testing.T) {
+			dir := extractOva(t, tt.ova)
+			defer os.RemoveAll(dir)
+
+			envelope, manifest, _, err := ReadOva(testResources + tt.ova)
+			if err != nil {
+				t.Fatalf("error reading OVA: %s", err)
+			}
+			if err = envelope.ValidateFiles(dir, manifest); err != nil {
+				t.Errorf("ValidateFiles() returned error: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateFilesInvalid(t *testing.T) {
+	dir := extractOva(t, "vapp_with_3_vms.ova")
+	defer os.RemoveAll(dir)
+
+	envelope, manifest, _, err := ReadOva(testResources + "vapp_with_3_vms.ova")
+	if err != nil {
+		t.Fatalf("error reading OVA: %s", err)
+	}
+	diskName := envelope.References[0].HREF
+	diskPath := filepath.Join(dir, diskName)
+	content, err := ioutil.ReadFile(diskPath)
+	if err != nil {
+		t.Fatalf("error reading disk: %s", err)
+	}
+
+	// Same size, different content
+	corrupted := append([]byte{}, content...)
+	corrupted[len(corrupted)-1] ^= 0xff
+	err = ioutil.WriteFile(diskPath, corrupted, 0600)
+	if err != nil {
+		t.Fatalf("error writing disk: %s", err)
+	}
+	err = envelope.ValidateFiles(dir, manifest)
+	if err == nil || !strings.Contains(err.Error(), "does not match the manifest") {
+		t.Errorf("ValidateFiles() = %v, want digest mismatch", err)
+	}
+	// Without manifest, only the size is checked
+	if err = envelope.ValidateFiles(dir, nil); err != nil {
+		t.Errorf("ValidateFiles() without manifest returned error: %s", err)
+	}
+
+	err = ioutil.WriteFile(diskPath, content[:len(content)-1], 0600)
+	if err != nil {
+		t.Fatalf("error writing disk: %s", err)
+	}
+	err = envelope.ValidateFiles(dir, nil)
+	if err == nil || !strings.Contains(err.Error(), "as described in the OVF descriptor") {
+		t.Errorf("ValidateFiles() = %v, want size mismatch", err)
+	}
+
+	err = os.Remove(diskPath)
+	if err != nil {
+		t.Fatalf("error removing disk: %s", err)
+	}
+	err = envelope.ValidateFiles(dir, nil)
+	if err == nil || !strings.Contains(err.Error(), "was not found") {
+		t.Errorf("ValidateFiles() = %v, want missing file", err)
+	}
+}
+
+func TestChunkNames(t *testing.T) {
+	want := []string{"disk.vmdk.000000000", "disk.vmdk.000000001"}
+	if got := ChunkNames("disk.vmdk", 68096, 40960); !reflect.DeepEqual(got, want) {
+		t.Errorf("ChunkNames() = %v, want %v", got, want)
+	}
+	if got := ChunkNames("disk.vmdk", 40960, 40960); len(got) != 1 {
+		t.Errorf("ChunkNames() = %v, want one chunk", got)
+	}
+}
+
+func TestValidateFilesOutsideDir(t *testing.T) {
+	dir := extractOva(t, "vapp_with_3_vms.ova")
+	defer os.RemoveAll(dir)
+
+	// A file next to the package directory, which a malicious descriptor or manifest could refer to
+	outside := filepath.Join(filepath.Dir(dir), filepath.Base(dir)+"-outside.vmdk")
+	err := ioutil.WriteFile(outside, []byte("outside"), 0600)
+	if err != nil {
+		t.Fatalf("error writing file: %s", err)
+	}
+	defer os.Remove(outside)
+	outsideName := "../" + filepath.Base(outside)
+
+	tests := []struct {
+		name string
+		edit func(*Envelope, Manifest)
+		want string
+	}{
+		{
+			name: "DescriptorParentDirectory",
+			edit: func(envelope *Envelope, manifest Manifest) {
+				envelope.References[0].HREF = outsideName
+				envelope.References[0].Size = 0
+			},
+			want: "must not refer to a parent directory",
+		},
+		{
+			name: "DescriptorAbsolutePath",
+			edit: func(envelope *Envelope, manifest Manifest) {
+				envelope.References[0].HREF = outside
+				envelope.References[0].Size = 0
+			},
+			want: "must be relative to the package",
+		},
+		{
+			name: "ManifestParentDirectory",
+			edit: func(envelope *Envelope, manifest Manifest) {
+				_ = manifest.Set(outsideName, []byte("outside"))
+			},
+			want: "must not refer to a parent directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, manifest, _, err := ReadOva(testResources + "vapp_with_3_vms.ova")
+			if err != nil {
+				t.Fatalf("error reading OVA: %s", err)
+			}
+			tt.edit(envelope, manifest)
+			err = envelope.ValidateFiles(dir, manifest)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ValidateFiles() = %v, want error containing %s", err, tt.want)
+			}
+		})
+	}
+}
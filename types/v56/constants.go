@@ -77,6 +77,10 @@ const (
 	MimeMediaInsertOrEjectParams = "application/vnd.vmware.vcloud.mediaInsertOrEjectParams+xml"
 	// Mime for catalog
 	MimeAdminCatalog = "application/vnd.vmware.admin.catalog+xml"
+	// Mime for publishing a catalog to external organizations
+	MimePublishExternalCatalogParams = "application/vnd.vmware.admin.publishExternalCatalogParams+xml"
+	// Mime for subscribing a catalog to an external catalog
+	MimeExternalCatalogSubscriptionParams = "application/vnd.vmware.admin.externalCatalogSubscriptionParams+xml"
 	// Mime for virtual hardware section
 	MimeVirtualHardwareSection = "application/vnd.vmware.vcloud.virtualHardwareSection+xml"
 	// Mime for networkConnectionSection
@@ -87,6 +91,8 @@ const (
 	MimeGuestCustomizationSection = "application/vnd.vmware.vcloud.guestCustomizationSection+xml"
 	// Mime for guest customization status
 	MimeGuestCustomizationStatus = "application/vnd.vmware.vcloud.guestcustomizationstatussection"
+	// Mime for lease settings section
+	MimeLeaseSettingSection = "application/vnd.vmware.vcloud.leaseSettingsSection+xml"
 	// Mime for network config section
 	MimeNetworkConfigSection = "application/vnd.vmware.vcloud.networkconfigsection+xml"
 	// Mime for recompose vApp params
@@ -99,6 +105,18 @@ const (
 	MimeDeployVappParams = "application/vnd.vmware.vcloud.deployVAppParams+xml"
 	// Mime for VM
 	MimeVM = "application/vnd.vmware.vcloud.vm+xml"
+	// Mime for VM MKS ticket
+	MimeMksTicket = "application/vnd.vmware.vcloud.mksTicket+xml"
+	// Mime for VM screen ticket
+	MimeScreenTicket = "application/vnd.vmware.vcloud.screenTicket+xml"
+	// Mime for capture vApp params
+	MimeCaptureVappParams = "application/vnd.vmware.vcloud.captureVAppParams+xml"
+	// Mime for copy or move catalog item params
+	MimeCopyOrMoveCatalogItemParams = "application/vnd.vmware.vcloud.copyOrMoveCatalogItemParams+xml"
+	// Mime for clone vApp params
+	MimeCloneVAppParams = "application/vnd.vmware.vcloud.cloneVAppParams+xml"
+	// Mime for move vApp params
+	MimeMoveVAppParams = "application/vnd.vmware.vcloud.MoveVAppParams+xml"
 	// Mime for instantiate vApp template params
 	MimeInstantiateVappTemplateParams = "application/vnd.vmware.vcloud.instantiateVAppTemplateParams+xml"
 	// Mime for product section
@@ -117,6 +135,8 @@ const (
 	MimeAdminGroup = "application/vnd.vmware.admin.group+xml"
 	// MimeOrgLdapSettings
 	MimeOrgLdapSettings = "application/vnd.vmware.admin.organizationldapsettings+xml"
+	// MimeOrgFederationSettings specifies the SAML federation settings of an organization
+	MimeOrgFederationSettings = "application/vnd.vmware.admin.organizationFederationSettings+xml"
 	// Mime of vApp network
 	MimeVappNetwork = "application/vnd.vmware.vcloud.vAppNetwork+xml"
 	// Mime of access control
@@ -172,6 +192,18 @@ const (
 	NoneNetwork = "none"
 )
 
+// Network adapter types which can be set for a VM NIC
+const (
+	NetworkAdapterTypeVmxnet3  = "VMXNET3"
+	NetworkAdapterTypeVmxnet2  = "VMXNET2"
+	NetworkAdapterTypeVmxnet   = "VMXNET"
+	NetworkAdapterTypeE1000    = "E1000"
+	NetworkAdapterTypeE1000E   = "E1000E"
+	NetworkAdapterTypePcnet32  = "PCNet32"
+	NetworkAdapterTypeSriov    = "SRIOVETHERNETCARD"
+	NetworkAdapterTypeFlexible = "FLEXIBLE"
+)
+
 const (
 	XMLNamespaceVCloud    = "http://www.vmware.com/vcloud/v1.5"
 	XMLNamespaceOVF       = "http://schemas.dmtf.org/ovf/envelope/1"
@@ -192,6 +224,13 @@ const (
 	EdgeVdcVnicConfig      = "/vdcNetworks"
 	EdgeDhcpRelayPath      = "/dhcp/config/relay"
 	EdgeDhcpLeasePath      = "/dhcp/leaseInfo"
+	EdgeDhcpConfigPath     = "/dhcp/config"
+	EdgeDhcpBindingsPath   = "/dhcp/config/bindings"
+	EdgeDnsConfigPath      = "/dns/config"
+	EdgeRoutingPath        = "/routing/config"
+	EdgeStaticRoutingPath  = "/routing/config/static"
+	EdgeOspfRoutingPath    = "/routing/config/ospf"
+	EdgeBgpRoutingPath     = "/routing/config/bgp"
 	LbConfigPath           = "/loadbalancer/config/"
 	LbMonitorPath          = "/loadbalancer/config/monitors/"
 	LbServerPoolPath       = "/loadbalancer/config/pools/"
@@ -200,6 +239,18 @@ const (
 	LbVirtualServerPath    = "/loadbalancer/config/virtualservers/"
 )
 
+// NSX-V Edge gateway VPN API endpoints
+const (
+	EdgeSslVpnPath                = "/sslvpn/config"
+	EdgeSslVpnServerPath          = "/sslvpn/config/server"
+	EdgeSslVpnIpPoolsPath         = "/sslvpn/config/client/networkextension/ippools"
+	EdgeSslVpnPrivateNetworksPath = "/sslvpn/config/client/networkextension/privatenetworks"
+	EdgeSslVpnInstallPackagesPath = "/sslvpn/config/client/networkextension/installpackages"
+	EdgeSslVpnUsersPath           = "/sslvpn/config/auth/localserver/users"
+	EdgeSslVpnAuthPath            = "/sslvpn/config/auth/settings"
+	EdgeL2VpnPath                 = "/l2vpn/config"
+)
+
 // NSX-V proxied services API endpoints
 const (
 	NsxvIpSetServicePath = "/ipset"
@@ -214,6 +265,22 @@ const (
 	GuestCustStatusRebootPending = "REBOOT_PENDING"
 )
 
+// Undeploy power actions. They define what happens to a VM or vApp when it is undeployed
+const (
+	UndeployPowerActionPowerOff = "powerOff"
+	UndeployPowerActionSuspend  = "suspend"
+	UndeployPowerActionShutdown = "shutdown"
+	UndeployPowerActionForce    = "force"
+	UndeployPowerActionDefault  = "default"
+)
+
+// VM power states which can be requested in VM.EnsurePowerState. They match values in VAppStatuses
+const (
+	VmPowerStatePoweredOn  = "POWERED_ON"
+	VmPowerStatePoweredOff = "POWERED_OFF"
+	VmPowerStateSuspended  = "SUSPENDED"
+)
+
 // Edge gateway vNic types
 const (
 	EdgeGatewayVnicTypeUplink       = "uplink"
@@ -331,6 +398,15 @@ const (
 const (
 	OpenApiPathVersion1_0_0                   = "1.0.0/"
 	OpenApiEndpointRoles                      = "roles/"
+	OpenApiEndpointRoleRights                 = "roles/%s/rights"
+	OpenApiEndpointRights                     = "rights/"
+	OpenApiEndpointRightsCategories           = "rightsCategories/"
+	OpenApiEndpointGlobalRoles                = "globalRoles/"
+	OpenApiEndpointGlobalRoleRights           = "globalRoles/%s/rights"
+	OpenApiEndpointGlobalRoleTenants          = "globalRoles/%s/tenants"
+	OpenApiEndpointRightsBundles              = "rightsBundles/"
+	OpenApiEndpointRightsBundleRights         = "rightsBundles/%s/rights"
+	OpenApiEndpointRightsBundleTenants        = "rightsBundles/%s/tenants"
 	OpenApiEndpointAuditTrail                 = "auditTrail/"
 	OpenApiEndpointImportableTier0Routers     = "nsxTResources/importableTier0Routers"
 	OpenApiEndpointImportableSwitches         = "/network/orgvdcnetworks/importableswitches"
@@ -342,6 +418,26 @@ const (
 	OpenApiEndpointEdgeGateways               = "edgeGateways/"
 	OpenApiEndpointOrgVdcNetworks             = "orgVdcNetworks/"
 	OpenApiEndpointOrgVdcNetworksDhcp         = "orgVdcNetworks/%s/dhcp"
+
+	// NSX-T ALB related endpoints
+	OpenApiEndpointAlbController                    = "loadBalancer/controllers/"
+	OpenApiEndpointAlbImportableClouds              = "nsxAlbResources/importableClouds"
+	OpenApiEndpointAlbCloud                         = "loadBalancer/clouds/"
+	OpenApiEndpointAlbImportableServiceEngineGroups = "nsxAlbResources/importableServiceEngineGroups"
+	OpenApiEndpointAlbServiceEngineGroups           = "loadBalancer/serviceEngineGroups/"
+	OpenApiEndpointAlbServiceEngineGroupAssignments = "loadBalancer/serviceEngineGroupAssignments/"
+	OpenApiEndpointAlbEdgeGateway                   = "edgeGateways/%s/loadBalancer"
+	OpenApiEndpointAlbPools                         = "loadBalancer/pools/"
+	OpenApiEndpointAlbPoolSummaries                 = "edgeGateways/%s/loadBalancer/poolSummaries"
+	OpenApiEndpointAlbVirtualServices               = "loadBalancer/virtualServices/"
+	OpenApiEndpointAlbVirtualServiceSummaries       = "edgeGateways/%s/loadBalancer/virtualServiceSummaries"
+
+	// NSX-T Edge Gateway routing related endpoints
+	OpenApiEndpointEdgeGatewayStaticRoutes  = "edgeGateways/%s/routing/staticRoutes/"
+	OpenApiEndpointEdgeBgpConfig            = "edgeGateways/%s/routing/bgp"
+	OpenApiEndpointEdgeBgpNeighbor          = "edgeGateways/%s/routing/bgp/neighbors/"
+	OpenApiEndpointEdgeBgpConfigPrefixLists = "edgeGateways/%s/routing/bgp/prefixLists/"
+	OpenApiEndpointNsxtRouteAdvertisement   = "edgeGateways/%s/routing/advertisement"
 )
 
 // Header keys to run operations in tenant context
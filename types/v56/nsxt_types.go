@@ -229,3 +229,138 @@ type OpenApiOrgVdcNetworkDhcpPools struct {
 	// This applies for NSX-V Isolated network
 	DefaultLeaseTime *int `json:"defaultLeaseTime,omitempty"`
 }
+
+// NsxtEdgeGatewayStaticRoute defines a single NSX-T Edge Gateway static route
+type NsxtEdgeGatewayStaticRoute struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// NetworkCidr is the network prefix in CIDR format (e.g. 192.168.1.0/24) which the route applies to
+	NetworkCidr string `json:"networkCidr"`
+	// NextHops defines where traffic for NetworkCidr should be routed. At least one next hop is required.
+	NextHops []NsxtEdgeGatewayStaticRouteNextHops `json:"nextHops"`
+	// SystemOwned is a read-only field that defines if the route was created by the system
+	SystemOwned *bool `json:"systemOwned,omitempty"`
+	// Version of the static route which is used for optimistic locking
+	Version *NsxtEdgeGatewayStaticRouteVersion `json:"version,omitempty"`
+}
+
+// NsxtEdgeGatewayStaticRouteNextHops defines a single next hop of NSX-T Edge Gateway static route
+type NsxtEdgeGatewayStaticRouteNextHops struct {
+	// IPAddress of the next hop
+	IPAddress string `json:"ipAddress"`
+	// AdminDistance is the administrative distance of the next hop (1-255). Lower value has higher priority.
+	AdminDistance int `json:"adminDistance"`
+	// Scope is an optional reference to an entity (e.g. Org VDC network) where the next hop is reachable
+	Scope *NsxtEdgeGatewayStaticRouteNextHopScope `json:"scope,omitempty"`
+}
+
+// NsxtEdgeGatewayStaticRouteNextHopScope defines the scope of a static route next hop
+type NsxtEdgeGatewayStaticRouteNextHopScope struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	// ScopeType is one of NETWORK, SYSTEM_OWNED
+	ScopeType string `json:"scopeType,omitempty"`
+}
+
+// NsxtEdgeGatewayStaticRouteVersion holds the version of a static route
+type NsxtEdgeGatewayStaticRouteVersion struct {
+	Version int `json:"version"`
+}
+
+// EdgeBgpConfig defines BGP configuration on NSX-T Edge Gateways (Tier1 NSX-T Gateways)
+type EdgeBgpConfig struct {
+	// Enabled defines if BGP service is enabled on the Edge Gateway
+	Enabled bool `json:"enabled"`
+	// Ecmp defines if Equal-cost multi-path routing is enabled
+	Ecmp bool `json:"ecmp"`
+	// LocalASNumber is the autonomous system number of the Edge Gateway. Both ASPLAIN (e.g. "65000") and ASDOT (e.g.
+	// "1.10") formats are accepted.
+	LocalASNumber string `json:"localASNumber,omitempty"`
+	// GracefulRestart defines graceful restart configuration of BGP service
+	GracefulRestart *EdgeBgpGracefulRestartConfig `json:"gracefulRestart,omitempty"`
+	// Version is used for optimistic locking. It must be sent back when updating configuration.
+	Version EdgeBgpConfigVersion `json:"version"`
+}
+
+// EdgeBgpGracefulRestartConfig defines graceful restart configuration of NSX-T Edge Gateway BGP service
+type EdgeBgpGracefulRestartConfig struct {
+	// Mode is one of DISABLE, HELPER_ONLY, GRACEFUL_AND_HELPER
+	Mode string `json:"mode"`
+	// RestartTimer is the maximum time (in seconds) to wait for a peer to come back after it restarts
+	RestartTimer int `json:"restartTimer,omitempty"`
+	// StaleRouteTimer is the maximum time (in seconds) to keep stale routes of a restarting peer
+	StaleRouteTimer int `json:"staleRouteTimer,omitempty"`
+}
+
+// EdgeBgpConfigVersion holds the version of BGP configuration
+type EdgeBgpConfigVersion struct {
+	Version int `json:"version"`
+}
+
+// EdgeBgpNeighbor defines a single BGP neighbor (peer) of NSX-T Edge Gateway
+type EdgeBgpNeighbor struct {
+	ID string `json:"id,omitempty"`
+	// NeighborAddress is the IP address of the BGP neighbor. Both IPv4 and IPv6 are supported.
+	NeighborAddress string `json:"neighborAddress"`
+	// RemoteASNumber is the autonomous system number of the neighbor in ASPLAIN or ASDOT format
+	RemoteASNumber string `json:"remoteASNumber"`
+	// KeepAliveTimer is the time interval (in seconds) between sending keep alive messages to a peer
+	KeepAliveTimer int `json:"keepAliveTimer,omitempty"`
+	// HoldDownTimer is the time interval (in seconds) before declaring a peer dead
+	HoldDownTimer int `json:"holdDownTimer,omitempty"`
+	// NeighborPassword for BGP neighbor authentication. It is write-only.
+	NeighborPassword string `json:"neighborPassword,omitempty"`
+	// AllowASIn defines if routes with the same AS number as the Edge Gateway are accepted
+	AllowASIn bool `json:"allowASIn,omitempty"`
+	// GracefulRestartMode overrides graceful restart mode for this neighbor. One of DISABLE, HELPER_ONLY,
+	// GRACEFUL_AND_HELPER
+	GracefulRestartMode string `json:"gracefulRestartMode,omitempty"`
+	// IpAddressTypeFiltering is one of IPV4, IPV6, DISABLED
+	IpAddressTypeFiltering string `json:"ipAddressTypeFiltering,omitempty"`
+	// InRoutesFilterRef is an optional reference to an IP Prefix List (EdgeBgpIpPrefixList) which filters routes
+	// received from the neighbor
+	InRoutesFilterRef *OpenApiReference `json:"inRoutesFilterRef,omitempty"`
+	// OutRoutesFilterRef is an optional reference to an IP Prefix List (EdgeBgpIpPrefixList) which filters routes
+	// advertised to the neighbor
+	OutRoutesFilterRef *OpenApiReference `json:"outRoutesFilterRef,omitempty"`
+	// Bfd defines Bidirectional Forwarding Detection configuration for the neighbor
+	Bfd *EdgeBgpNeighborBfd `json:"bfd,omitempty"`
+}
+
+// EdgeBgpNeighborBfd defines Bidirectional Forwarding Detection configuration of a BGP neighbor
+type EdgeBgpNeighborBfd struct {
+	Enabled bool `json:"enabled"`
+	// BfdInterval is the time interval (in milliseconds) between heartbeat packets
+	BfdInterval int `json:"bfdInterval,omitempty"`
+	// DeclareDeadMultiple is the number of missed heartbeat packets before declaring the session down
+	DeclareDeadMultiple int `json:"declareDeadMultiple,omitempty"`
+}
+
+// EdgeBgpIpPrefixList defines an IP Prefix List which can be used as BGP neighbor route filter
+type EdgeBgpIpPrefixList struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Prefixes is an ordered list of prefixes which are evaluated top to bottom
+	Prefixes []EdgeBgpConfigPrefixListPrefixes `json:"prefixes,omitempty"`
+}
+
+// EdgeBgpConfigPrefixListPrefixes defines a single prefix of EdgeBgpIpPrefixList
+type EdgeBgpConfigPrefixListPrefixes struct {
+	// Network in CIDR format (e.g. 192.168.1.0/24) or the keyword "ANY"
+	Network string `json:"network"`
+	// Action is one of PERMIT, DENY
+	Action string `json:"action"`
+	// GreaterThan and LessThan optionally define the range of prefix lengths to match
+	GreaterThan *int `json:"greaterThan,omitempty"`
+	LessThan    *int `json:"lessThan,omitempty"`
+}
+
+// RouteAdvertisement lists the subnets of Org VDC networks which are advertised by NSX-T Edge Gateway
+type RouteAdvertisement struct {
+	// Enable defines if route advertisement is active
+	Enable bool `json:"enable"`
+	// Subnets is a list of subnets (in CIDR format) that are advertised to the Tier-0 Gateway
+	Subnets []string `json:"subnets"`
+}
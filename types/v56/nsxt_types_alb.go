@@ -0,0 +1,374 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package types
+
+// NsxtAlbController helps to integrate VMware Cloud Director with NSX-T Advanced Load Balancer deployment.
+// Controller instances are registered with VMware Cloud Director instance. Controller instances serve as a central
+// control plane for the load-balancing services provided by NSX-T Advanced Load Balancer.
+// To configure an NSX-T ALB one needs to supply AVI Controller endpoint, credentials and license to be used.
+type NsxtAlbController struct {
+	// ID holds URN for load balancer controller (e.g. urn:vcloud:loadBalancerController:aa23ef66-ba32-48b2-892f-7acdffe4587e)
+	ID string `json:"id,omitempty"`
+	// Name as shown in VCD
+	Name string `json:"name"`
+	// Description as shown in VCD
+	Description string `json:"description,omitempty"`
+	// Url of ALB controller
+	Url string `json:"url"`
+	// Username of user
+	Username string `json:"username"`
+	// Password (will not be returned on read)
+	Password string `json:"password,omitempty"`
+	// LicenseType By enabling this feature, the provider acknowledges that they have independently licensed the
+	// enterprise version of the NSX AVI LB.
+	// Possible options: 'BASIC', 'ENTERPRISE'
+	LicenseType string `json:"licenseType,omitempty"`
+	// Version of ALB (e.g. 20.1.3). Read-only
+	Version string `json:"version,omitempty"`
+}
+
+// NsxtAlbImportableCloud allows user to list importable NSX-T ALB Clouds. Each importable cloud can only be imported
+// once by using NsxtAlbCloud construct. It has a flag AlreadyImported which hints if it is already consumed or not.
+type NsxtAlbImportableCloud struct {
+	// ID (e.g. 'cloud-43726181-f73e-41f2-bf1d-8a9466a6ff9e')
+	ID string `json:"id"`
+	// DisplayName is the name of importable NSX-T ALB Cloud
+	DisplayName string `json:"displayName"`
+	// AlreadyImported shows if this ALB Cloud is already imported
+	AlreadyImported bool `json:"alreadyImported"`
+	// NetworkPoolRef contains a reference to NSX-T network pool
+	NetworkPoolRef *OpenApiReference `json:"networkPoolRef,omitempty"`
+	// TransportZoneName contains transport zone name
+	TransportZoneName string `json:"transportZoneName,omitempty"`
+}
+
+// NsxtAlbCloud helps to use the virtual infrastructure provided by NSX Advanced Load Balancer, register NSX-T Cloud
+// instances with VMware Cloud Director by consuming NsxtAlbImportableCloud.
+type NsxtAlbCloud struct {
+	// ID (e.g. 'urn:vcloud:loadBalancerCloud:947ea2ba-e448-4249-91f7-1432b3d2fcbf')
+	ID string `json:"id,omitempty"`
+	// Name of NSX-T ALB Cloud
+	Name string `json:"name"`
+	// Description of NSX-T ALB Cloud
+	Description string `json:"description,omitempty"`
+	// LoadBalancerCloudBacking uniquely identifies a Load Balancer Cloud configured within a Load Balancer Controller. At
+	// the present, VCD only supports NSX-T Clouds configured within an NSX-ALB Controller deployment.
+	LoadBalancerCloudBacking NsxtAlbCloudBacking `json:"loadBalancerCloudBacking"`
+	// NetworkPoolRef for the Network Pool associated with this Cloud
+	NetworkPoolRef *OpenApiReference `json:"networkPoolRef"`
+	// HealthStatus contains status of the Load Balancer Cloud. Possible values are:
+	// UP - The cloud is healthy and ready to enable Load Balancer for an Edge Gateway.
+	// DOWN - The cloud is in a failure state. Enabling Load balancer on an Edge Gateway may not be possible.
+	// RUNNING - The cloud is currently processing. An example is if it's enabling a Load Balancer for an Edge Gateway.
+	// UNAVAILABLE - The cloud is unavailable.
+	// UNKNOWN - The cloud state is unknown.
+	HealthStatus string `json:"healthStatus,omitempty"`
+	// DetailedHealthMessage contains detailed message on the health of the Cloud.
+	DetailedHealthMessage string `json:"detailedHealthMessage,omitempty"`
+}
+
+// NsxtAlbCloudBacking is embedded into NsxtAlbCloud
+type NsxtAlbCloudBacking struct {
+	// BackingId is the ID of NsxtAlbImportableCloud
+	BackingId string `json:"backingId"`
+	// BackingType contains type of ALB (The only supported now is 'NSXALB_NSXT')
+	BackingType string `json:"backingType,omitempty"`
+	// LoadBalancerControllerRef contains reference to NSX-T ALB Controller
+	LoadBalancerControllerRef OpenApiReference `json:"loadBalancerControllerRef"`
+}
+
+// NsxtAlbImportableServiceEngineGroups provides capability to list all Importable Service Engine Groups available in
+// ALB Controller so that they can be consumed by NsxtAlbServiceEngineGroup
+type NsxtAlbImportableServiceEngineGroups struct {
+	// ID (e.g. 'serviceenginegroup-b633f16f-2733-4bf5-b552-3a6c4949caa4')
+	ID string `json:"id"`
+	// DisplayName is the name of importable Service Engine Group
+	DisplayName string `json:"displayName"`
+	// HaMode (e.g. 'ELASTIC_N_PLUS_M_BUFFER')
+	HaMode string `json:"haMode"`
+}
+
+// NsxtAlbServiceEngineGroup provides virtual service management capabilities for tenants. This entity can be created
+// by referencing a backing importable service engine group - NsxtAlbImportableServiceEngineGroups.
+//
+// A ReservationType can be 'DEDICATED' or 'SHARED'. DEDICATED service engine group is assigned to a single edge
+// gateway, while SHARED one can be assigned to multiple edge gateways.
+type NsxtAlbServiceEngineGroup struct {
+	// ID of the Service Engine Group
+	ID string `json:"id,omitempty"`
+	// Name of the Service Engine Group
+	Name string `json:"name"`
+	// Description of the Service Engine Group
+	Description string `json:"description,omitempty"`
+	// ServiceEngineGroupBacking holds backing details that uniquely identifies a Load Balancer Service Engine Group
+	// configured within a load balancer cloud.
+	ServiceEngineGroupBacking NsxtAlbServiceEngineGroupBacking `json:"serviceEngineGroupBacking"`
+	// HaMode defines High Availability Mode for Service Engine Group. Read-only
+	HaMode string `json:"haMode,omitempty"`
+	// ReservationType can be 'DEDICATED' or 'SHARED'
+	ReservationType string `json:"reservationType,omitempty"`
+	// MaxVirtualServices holds maximum number of virtual services supported on the Load Balancer Service Engine Group
+	MaxVirtualServices *int `json:"maxVirtualServices,omitempty"`
+	// NumDeployedVirtualServices shows number of virtual services currently deployed on the Load Balancer Service Engine
+	// Group
+	NumDeployedVirtualServices *int `json:"numDeployedVirtualServices,omitempty"`
+	// ReservedVirtualServices holds number of virtual services already reserved on the Load Balancer Service Engine
+	// Group. This value is the sum of the guaranteed virtual services given to Edge Gateways assigned to the Load
+	// Balancer Service Engine Group.
+	ReservedVirtualServices *int `json:"reservedVirtualServices,omitempty"`
+	// OverAllocated indicates whether the maximum number of virtual services supported on the Load Balancer Service
+	// Engine Group has been surpassed by the current number of reserved virtual services.
+	OverAllocated *bool `json:"overAllocated,omitempty"`
+}
+
+// NsxtAlbServiceEngineGroupBacking is embedded into NsxtAlbServiceEngineGroup
+type NsxtAlbServiceEngineGroupBacking struct {
+	// BackingId is the ID of NsxtAlbImportableServiceEngineGroups
+	BackingId string `json:"backingId"`
+	// LoadBalancerCloudRef contains reference to NsxtAlbCloud
+	LoadBalancerCloudRef *OpenApiReference `json:"loadBalancerCloudRef"`
+}
+
+// NsxtAlbServiceEngineGroupAssignment configures Service Engine Group assignments to Edge Gateway. The only mandatory
+// fields are `GatewayRef` and `ServiceEngineGroupRef`. `MinVirtualServices` and `MaxVirtualServices` are only available
+// for SHARED Service Engine Groups.
+type NsxtAlbServiceEngineGroupAssignment struct {
+	ID string `json:"id,omitempty"`
+	// ServiceEngineGroupRef is the reference to the Load Balancer Service Engine Group this assignment belongs to
+	ServiceEngineGroupRef *OpenApiReference `json:"serviceEngineGroupRef"`
+	// GatewayRef is the reference to the Edge Gateway this assignment belongs to
+	GatewayRef *OpenApiReference `json:"gatewayRef"`
+	// GatewayOrgRef optional Org reference for gateway
+	GatewayOrgRef *OpenApiReference `json:"gatewayOrgRef,omitempty"`
+	// GatewayOwnerRef can be a VDC or VDC group
+	GatewayOwnerRef *OpenApiReference `json:"gatewayOwnerRef,omitempty"`
+	// MaxVirtualServices is the maximum number of virtual services the Edge Gateway is allowed to use. This is required
+	// if the Load Balancer Service Engine Group has reservation type 'SHARED'. This must be unset if the Load Balancer
+	// Service Engine Group has reservation type 'DEDICATED'.
+	MaxVirtualServices *int `json:"maxVirtualServices,omitempty"`
+	// MinVirtualServices is the number of guaranteed virtual services available to the Edge Gateway. This is required if
+	// the Load Balancer Service Engine Group has reservation type 'SHARED'. This must be unset if the Load Balancer
+	// Service Engine Group has reservation type 'DEDICATED'.
+	MinVirtualServices *int `json:"minVirtualServices,omitempty"`
+	// NumDeployedVirtualServices is the current number of load balancer virtual services associated with this
+	// assignment. Read-only
+	NumDeployedVirtualServices int `json:"numDeployedVirtualServices,omitempty"`
+}
+
+// NsxtAlbConfig describes Load Balancer Service configuration on an NSX-T Edge Gateway
+type NsxtAlbConfig struct {
+	// Enabled is a mandatory flag indicating whether Load Balancer Service is enabled or not
+	Enabled bool `json:"enabled"`
+	// LicenseType of the backing Load Balancer Cloud.
+	// * BASIC - Basic edition of the NSX Advanced Load Balancer.
+	// * ENTERPRISE - Full featured edition of the NSX Advanced Load Balancer.
+	LicenseType string `json:"licenseType,omitempty"`
+	// LoadBalancerCloudRef is a read-only reference to the Load Balancer Cloud. Only one Cloud can be used per gateway
+	LoadBalancerCloudRef *OpenApiReference `json:"loadBalancerCloudRef,omitempty"`
+	// ServiceNetworkDefinition in Gateway CIDR format which will be used by Load Balancer service. All the load balancer
+	// service engines associated with the Service Engine Group will be attached to this network. The subnet prefix
+	// length must be 25. If nothing is set, the default is 192.168.255.1/25. Default CIDR can be configured. This field
+	// cannot be updated.
+	ServiceNetworkDefinition string `json:"serviceNetworkDefinition,omitempty"`
+}
+
+// NsxtAlbPool defines configuration of a single NSX-T ALB Pool. Pools contain reference to member IPs which will be
+// serving traffic for a virtual service (NsxtAlbVirtualService). Additionally it can define health monitoring,
+// persistence and CA certificates for member verification.
+type NsxtAlbPool struct {
+	ID string `json:"id,omitempty"`
+	// Name is mandatory
+	Name string `json:"name"`
+	// Description is optional
+	Description string `json:"description,omitempty"`
+	// GatewayRef is mandatory and associates NSX-T Edge Gateway with this Load Balancer Pool.
+	GatewayRef OpenApiReference `json:"gatewayRef"`
+	// Enabled defines if the Pool is enabled
+	Enabled *bool `json:"enabled,omitempty"`
+	// Algorithm for choosing a member within the pools list of available members for each new connection.
+	// Default value is 'LEAST_CONNECTIONS'
+	// Supported algorithms are:
+	// * LEAST_CONNECTIONS
+	// * ROUND_ROBIN
+	// * CONSISTENT_HASH (uses Source IP Address hash)
+	// * FASTEST_RESPONSE
+	// * LEAST_LOAD
+	// * FEWEST_SERVERS
+	// * RANDOM
+	// * FEWEST_TASKS
+	// * CORE_AFFINITY
+	Algorithm string `json:"algorithm,omitempty"`
+	// DefaultPort defines destination server port used by the traffic sent to the member.
+	DefaultPort *int `json:"defaultPort,omitempty"`
+	// GracefulTimeoutPeriod sets maximum time (in minutes) to gracefully disable a member. Virtual service waits for the
+	// specified time before terminating the existing connections to the members that are disabled.
+	//
+	// Special values: 0 represents 'Immediate', -1 represents 'Infinite'. Default value is 1.
+	GracefulTimeoutPeriod *int `json:"gracefulTimeoutPeriod,omitempty"`
+	// PassiveMonitoringEnabled sets if client traffic should be used to check if pool member is up or down.
+	PassiveMonitoringEnabled *bool `json:"passiveMonitoringEnabled,omitempty"`
+	// HealthMonitors check member servers health. It can be monitored by using one or more health monitors. Active
+	// monitors generate synthetic traffic and mark a server up or down based on the response.
+	HealthMonitors []NsxtAlbPoolHealthMonitor `json:"healthMonitors,omitempty"`
+	// Members field defines list of destination servers which are used by the Load Balancer Pool to direct load balanced
+	// traffic.
+	Members []NsxtAlbPoolMember `json:"members,omitempty"`
+	// CaCertificateRefs point to root certificates to use when validating certificates presented by the pool members.
+	CaCertificateRefs []OpenApiReference `json:"caCertificateRefs,omitempty"`
+	// CommonNameCheckEnabled specifies whether to check the common name of the certificate presented by the pool member.
+	// This cannot be enabled if no caCertificateRefs are specified.
+	CommonNameCheckEnabled *bool `json:"commonNameCheckEnabled,omitempty"`
+	// DomainNames holds a list of domain names which will be used to verify the common names or subject alternative
+	// names presented by the pool member certificates. It is performed only when common name check
+	// (CommonNameCheckEnabled) is enabled. If common name check is enabled, but domain names are not specified then the
+	// incoming host header will be used to check the certificate.
+	DomainNames []string `json:"domainNames,omitempty"`
+	// PersistenceProfile of a Load Balancer Pool. Persistence profile will ensure that the same user sticks to the same
+	// server for a desired duration of time. If the persistence profile is unmanaged by Cloud Director, updates that
+	// leave the values unchanged will continue to use the same unmanaged profile. Any changes made to the persistence
+	// profile will cause Cloud Director to switch the pool to a profile managed by Cloud Director.
+	PersistenceProfile *NsxtAlbPoolPersistenceProfile `json:"persistenceProfile,omitempty"`
+	// MemberCount is a read-only value that reports number of members added
+	MemberCount int `json:"memberCount,omitempty"`
+	// EnabledMemberCount is a read-only value that reports number of enabled members
+	EnabledMemberCount int `json:"enabledMemberCount,omitempty"`
+	// UpMemberCount is a read-only value that reports number of members that are serving traffic
+	UpMemberCount int `json:"upMemberCount,omitempty"`
+	// HealthMessage shows a pool health status (e.g. "The pool is unassigned.")
+	HealthMessage string `json:"healthMessage,omitempty"`
+	// VirtualServiceRefs holds list of Load Balancer Virtual Services associated with this Load balancer Pool.
+	VirtualServiceRefs []OpenApiReference `json:"virtualServiceRefs,omitempty"`
+}
+
+// NsxtAlbPoolHealthMonitor checks member servers health. Active monitor generates synthetic traffic and mark a server
+// up or down based on the response.
+type NsxtAlbPoolHealthMonitor struct {
+	Name string `json:"name,omitempty"`
+	// SystemDefined is true for health monitors provided by the system
+	SystemDefined bool `json:"systemDefined,omitempty"`
+	// Type
+	// * HTTP - HTTP request/response is used to validate health.
+	// * HTTPS - Used against HTTPS encrypted web servers to validate health.
+	// * TCP - TCP connection is used to validate health.
+	// * UDP - A UDP datagram is used to validate health.
+	// * PING - An ICMP ping is used to validate health.
+	Type string `json:"type"`
+}
+
+// NsxtAlbPoolMember defines a single destination server which is used by the Load Balancer Pool to direct load balanced
+// traffic.
+type NsxtAlbPoolMember struct {
+	// Enabled defines if member is enabled (will receive incoming requests) or not
+	Enabled bool `json:"enabled"`
+	// IpAddress of the Load Balancer Pool member.
+	IpAddress string `json:"ipAddress"`
+	// Port number of the Load Balancer Pool member. If unset, the port that the client used to connect will be used.
+	Port int `json:"port,omitempty"`
+	// Ratio of selecting eligible servers in the pool.
+	Ratio *int `json:"ratio,omitempty"`
+	// MarkedDownBy gives the names of the health monitors that marked the member as down when it is DOWN. If a monitor
+	// cannot be determined, the value will be UNKNOWN.
+	MarkedDownBy []string `json:"markedDownBy,omitempty"`
+	// HealthStatus of the pool member. Possible values are:
+	// * UP - The member is operational
+	// * DOWN - The member is down
+	// * DISABLED - The member is disabled
+	// * UNKNOWN - The state is unknown
+	HealthStatus string `json:"healthStatus,omitempty"`
+	// DetailedHealthMessage contains non-localized detailed message on the health of the pool member.
+	DetailedHealthMessage string `json:"detailedHealthMessage,omitempty"`
+}
+
+// NsxtAlbPoolPersistenceProfile holds Persistence Profile of a Load Balancer Pool. Persistence profile will ensure that
+// the same user sticks to the same server for a desired duration of time.
+type NsxtAlbPoolPersistenceProfile struct {
+	// Name field is tricky. It remains empty in some case, but if it is sent it can become computed.
+	// (e.g. setting 'CUSTOM_HTTP_HEADER' results in value being
+	// 'VCD-LoadBalancer-3510eae9-53bb-49f1-b7aa-7aedf5ce3a77-CUSTOM_HTTP_HEADER')
+	Name string `json:"name,omitempty"`
+	// Type of persistence strategy to use. Supported values are:
+	// * CLIENT_IP - The client’s IP is used as the identifier and mapped to the server
+	// * HTTP_COOKIE - Load Balancer inserts a cookie into HTTP responses. Cookie name must be provided as value
+	// * CUSTOM_HTTP_HEADER - Custom, static mappings of header values to specific servers are used. Header name must be
+	// provided as value
+	// * APP_COOKIE - Load Balancer reads existing server cookies or URI embedded data such as JSessionID. Cookie name
+	// must be provided as value
+	// * TLS - Information is embedded in the client’s SSL/TLS ticket ID. This will use default system profile
+	// System-Persistence-TLS
+	Type string `json:"type,omitempty"`
+	// Value of attribute based on selected persistence type.
+	// This is required for HTTP_COOKIE, CUSTOM_HTTP_HEADER and APP_COOKIE persistence types.
+	Value string `json:"value,omitempty"`
+}
+
+// NsxtAlbVirtualService combines Load Balancer Pools with Service Engine Groups and exposes a virtual service on
+// defined VIP (virtual IP address) while optionally allowing to use encrypted traffic
+type NsxtAlbVirtualService struct {
+	ID string `json:"id,omitempty"`
+	// Name of the Virtual Service
+	Name string `json:"name"`
+	// Description of the Virtual Service
+	Description string `json:"description,omitempty"`
+	// Enabled defines if the virtual service is enabled to accept traffic
+	Enabled *bool `json:"enabled"`
+	// ApplicationProfile sets protocol for load balancing by using NsxtAlbVirtualServiceApplicationProfile
+	ApplicationProfile NsxtAlbVirtualServiceApplicationProfile `json:"applicationProfile"`
+	// GatewayRef contains NSX-T Edge Gateway reference
+	GatewayRef OpenApiReference `json:"gatewayRef"`
+	// LoadBalancerPoolRef contains Pool reference
+	LoadBalancerPoolRef OpenApiReference `json:"loadBalancerPoolRef"`
+	// ServiceEngineGroupRef points to service engine group (which must be assigned to NSX-T Edge Gateway)
+	ServiceEngineGroupRef OpenApiReference `json:"serviceEngineGroupRef"`
+	// CertificateRef contains certificate reference if serving encrypted traffic
+	CertificateRef *OpenApiReference `json:"certificateRef,omitempty"`
+	// ServicePorts define one or more ports (or port ranges) of the virtual service
+	ServicePorts []NsxtAlbVirtualServicePort `json:"servicePorts"`
+	// VirtualIpAddress to be used for exposing this virtual service
+	VirtualIpAddress string `json:"virtualIpAddress"`
+	// HealthStatus contains status of the Virtual Service. Possible values are: UP, DOWN, RUNNING, UNAVAILABLE,
+	// UNKNOWN. Read-only
+	HealthStatus string `json:"healthStatus,omitempty"`
+	// HealthMessage shows a Virtual Service health status. Read-only
+	HealthMessage string `json:"healthMessage,omitempty"`
+	// DetailedHealthMessage contains a more in depth health message. Read-only
+	DetailedHealthMessage string `json:"detailedHealthMessage,omitempty"`
+}
+
+// NsxtAlbVirtualServicePort port (or port range) definition
+type NsxtAlbVirtualServicePort struct {
+	// PortStart is always required
+	PortStart *int `json:"portStart"`
+	// PortEnd is only required if a port range is specified. For single port cases PortStart is sufficient
+	PortEnd *int `json:"portEnd,omitempty"`
+	// SslEnabled defines if traffic is served as secure. CertificateRef must be specified in NsxtAlbVirtualService
+	// when true
+	SslEnabled *bool `json:"sslEnabled,omitempty"`
+	// TcpUdpProfile defines the network protocol settings used by this port
+	TcpUdpProfile *NsxtAlbVirtualServicePortTcpUdpProfile `json:"tcpUdpProfile,omitempty"`
+}
+
+// NsxtAlbVirtualServicePortTcpUdpProfile profile determines the type and settings of the network protocol that a
+// subscribing virtual service will use. It sets a number of parameters, such as whether the virtual service is a TCP
+// proxy versus a pass-through via fast path. A virtual service can have both TCP and UDP enabled, which is useful for
+// protocols such as DNS or Syslog.
+type NsxtAlbVirtualServicePortTcpUdpProfile struct {
+	SystemDefined bool `json:"systemDefined"`
+	// Type defines L4 or L4_TLS profiles:
+	// * TCP_PROXY (the only one supported in L4_TLS)
+	// * TCP_FAST_PATH
+	// * UDP_FAST_PATH
+	Type string `json:"type"`
+}
+
+// NsxtAlbVirtualServiceApplicationProfile sets protocol for load balancing. Type field defines possible options.
+type NsxtAlbVirtualServiceApplicationProfile struct {
+	SystemDefined bool `json:"systemDefined,omitempty"`
+	// Type defines Traffic type:
+	// * HTTP
+	// * HTTPS (certificate reference is mandatory)
+	// * L4
+	// * L4 TLS (certificate reference is mandatory)
+	Type string `json:"type"`
+}
@@ -448,3 +448,622 @@ type EdgeDhcpLeaseInfo struct {
 	// HardwareType holds type of hardware, usually "ethernet"
 	HardwareType string `xml:"hardwareType"`
 }
+
+// EdgeRouting holds complete routing configuration of NSX-V edge gateway (global settings, static
+// routing, OSPF and BGP) as returned by proxied NSX-V API
+// https://code.vmware.com/docs/6900/vcloud-director-api-for-nsx-programming-guide
+type EdgeRouting struct {
+	XMLName             xml.Name                 `xml:"routing"`
+	Version             string                   `xml:"version,omitempty"`
+	Enabled             bool                     `xml:"enabled"`
+	RoutingGlobalConfig *EdgeRoutingGlobalConfig `xml:"routingGlobalConfig,omitempty"`
+	StaticRouting       *EdgeStaticRouting       `xml:"staticRouting,omitempty"`
+	Ospf                *EdgeOspfRouting         `xml:"ospf,omitempty"`
+	Bgp                 *EdgeBgpRouting          `xml:"bgp,omitempty"`
+}
+
+// EdgeRoutingGlobalConfig holds global routing settings of NSX-V edge gateway
+type EdgeRoutingGlobalConfig struct {
+	// RouterId is the IP address of an uplink interface which identifies the router for dynamic
+	// routing protocols
+	RouterId string `xml:"routerId,omitempty"`
+	// Ecmp enables Equal-cost multi-path routing
+	Ecmp    bool                `xml:"ecmp"`
+	Logging *EdgeRoutingLogging `xml:"logging,omitempty"`
+}
+
+// EdgeRoutingLogging holds routing log settings
+type EdgeRoutingLogging struct {
+	Enable bool `xml:"enable"`
+	// LogLevel is one of emergency, alert, critical, error, warning, notice, info, debug
+	LogLevel string `xml:"logLevel,omitempty"`
+}
+
+// EdgeStaticRouting holds default route and static routes of NSX-V edge gateway
+type EdgeStaticRouting struct {
+	XMLName      xml.Name              `xml:"staticRouting"`
+	DefaultRoute *EdgeStaticRoute      `xml:"defaultRoute,omitempty"`
+	StaticRoutes *EdgeStaticRoutesList `xml:"staticRoutes,omitempty"`
+}
+
+// EdgeStaticRoutesList holds a slice of EdgeStaticRoute
+type EdgeStaticRoutesList struct {
+	Routes []*EdgeStaticRoute `xml:"route"`
+}
+
+// EdgeStaticRoute defines a single static route (or default route) on NSX-V edge gateway
+type EdgeStaticRoute struct {
+	Description string `xml:"description,omitempty"`
+	// Vnic is the index of edge gateway interface through which next hop is reachable
+	Vnic *int `xml:"vnic,omitempty"`
+	// Network in CIDR format (e.g. 192.168.1.0/24). It is not used for default route.
+	Network string `xml:"network,omitempty"`
+	// NextHop is the IP address of next hop. For default route the field is GatewayAddress.
+	NextHop        string `xml:"nextHop,omitempty"`
+	GatewayAddress string `xml:"gatewayAddress,omitempty"`
+	Mtu            int    `xml:"mtu,omitempty"`
+	// AdminDistance (1-255) is used to choose which route to use when there are multiple routes
+	// for a given network
+	AdminDistance int `xml:"adminDistance,omitempty"`
+}
+
+// EdgeOspfRouting holds OSPF configuration of NSX-V edge gateway
+type EdgeOspfRouting struct {
+	XMLName           xml.Name                   `xml:"ospf"`
+	Enabled           bool                       `xml:"enabled"`
+	ProtocolAddress   string                     `xml:"protocolAddress,omitempty"`
+	ForwardingAddress string                     `xml:"forwardingAddress,omitempty"`
+	OspfAreas         *EdgeOspfAreas             `xml:"ospfAreas,omitempty"`
+	OspfInterfaces    *EdgeOspfInterfaces        `xml:"ospfInterfaces,omitempty"`
+	Redistribution    *EdgeRoutingRedistribution `xml:"redistribution,omitempty"`
+	GracefulRestart   bool                       `xml:"gracefulRestart"`
+	DefaultOriginate  bool                       `xml:"defaultOriginate"`
+}
+
+// EdgeOspfAreas holds a slice of EdgeOspfArea
+type EdgeOspfAreas struct {
+	OspfArea []EdgeOspfArea `xml:"ospfArea"`
+}
+
+// EdgeOspfArea defines a single OSPF area
+type EdgeOspfArea struct {
+	AreaId int `xml:"areaId"`
+	// Type is one of normal, nssa
+	Type           string                  `xml:"type,omitempty"`
+	Authentication *EdgeOspfAuthentication `xml:"authentication,omitempty"`
+}
+
+// EdgeOspfAuthentication defines OSPF area authentication
+type EdgeOspfAuthentication struct {
+	// Type is one of none, password, md5
+	Type  string `xml:"type"`
+	Value string `xml:"value,omitempty"`
+}
+
+// EdgeOspfInterfaces holds a slice of EdgeOspfInterface
+type EdgeOspfInterfaces struct {
+	OspfInterface []EdgeOspfInterface `xml:"ospfInterface"`
+}
+
+// EdgeOspfInterface maps edge gateway interface (vNic) to OSPF area
+type EdgeOspfInterface struct {
+	Vnic          *int `xml:"vnic"`
+	AreaId        int  `xml:"areaId"`
+	HelloInterval int  `xml:"helloInterval,omitempty"`
+	DeadInterval  int  `xml:"deadInterval,omitempty"`
+	Priority      int  `xml:"priority,omitempty"`
+	Cost          int  `xml:"cost,omitempty"`
+	MtuIgnore     bool `xml:"mtuIgnore"`
+}
+
+// EdgeBgpRouting holds BGP configuration of NSX-V edge gateway
+type EdgeBgpRouting struct {
+	XMLName xml.Name `xml:"bgp"`
+	Enabled bool     `xml:"enabled"`
+	// LocalAS is the autonomous system number of the edge gateway
+	LocalAS          string                     `xml:"localAS,omitempty"`
+	BgpNeighbours    *EdgeBgpNeighbours         `xml:"bgpNeighbours,omitempty"`
+	Redistribution   *EdgeRoutingRedistribution `xml:"redistribution,omitempty"`
+	GracefulRestart  bool                       `xml:"gracefulRestart"`
+	DefaultOriginate bool                       `xml:"defaultOriginate"`
+}
+
+// EdgeBgpNeighbours holds a slice of EdgeBgpNeighbour
+type EdgeBgpNeighbours struct {
+	BgpNeighbour []EdgeBgpNeighbour `xml:"bgpNeighbour"`
+}
+
+// EdgeBgpNeighbour defines a single BGP neighbour of NSX-V edge gateway
+type EdgeBgpNeighbour struct {
+	IpAddress      string          `xml:"ipAddress"`
+	RemoteAS       string          `xml:"remoteAS"`
+	Weight         int             `xml:"weight,omitempty"`
+	HoldDownTimer  int             `xml:"holdDownTimer,omitempty"`
+	KeepAliveTimer int             `xml:"keepAliveTimer,omitempty"`
+	Password       string          `xml:"password,omitempty"`
+	BgpFilters     *EdgeBgpFilters `xml:"bgpFilters,omitempty"`
+}
+
+// EdgeBgpFilters holds a slice of EdgeBgpFilter
+type EdgeBgpFilters struct {
+	BgpFilter []EdgeBgpFilter `xml:"bgpFilter"`
+}
+
+// EdgeBgpFilter filters routes exchanged with a BGP neighbour
+type EdgeBgpFilter struct {
+	// Direction is one of in, out
+	Direction string `xml:"direction"`
+	// Action is one of permit, deny
+	Action string `xml:"action"`
+	// Network in CIDR format
+	Network    string `xml:"network"`
+	IpPrefixGe int    `xml:"ipPrefixGe,omitempty"`
+	IpPrefixLe int    `xml:"ipPrefixLe,omitempty"`
+}
+
+// EdgeRoutingRedistribution defines which routes are redistributed into OSPF or BGP
+type EdgeRoutingRedistribution struct {
+	Enabled bool                            `xml:"enabled"`
+	Rules   *EdgeRoutingRedistributionRules `xml:"rules,omitempty"`
+}
+
+// EdgeRoutingRedistributionRules holds a slice of EdgeRoutingRedistributionRule
+type EdgeRoutingRedistributionRules struct {
+	Rule []EdgeRoutingRedistributionRule `xml:"rule"`
+}
+
+// EdgeRoutingRedistributionRule defines a single route redistribution rule
+type EdgeRoutingRedistributionRule struct {
+	Id         string                            `xml:"id,omitempty"`
+	PrefixName string                            `xml:"prefixName,omitempty"`
+	From       EdgeRoutingRedistributionRuleFrom `xml:"from"`
+	// Action is one of permit, deny
+	Action string `xml:"action"`
+}
+
+// EdgeRoutingRedistributionRuleFrom defines learner sources of redistribution rule
+type EdgeRoutingRedistributionRuleFrom struct {
+	Ospf      bool `xml:"ospf"`
+	Bgp       bool `xml:"bgp"`
+	Static    bool `xml:"static"`
+	Connected bool `xml:"connected"`
+}
+
+// EdgeSslVpnConfig holds complete SSL VPN-Plus configuration of NSX-V edge gateway. SSL VPN-Plus
+// allows remote users to connect securely to private networks behind an edge gateway.
+// https://code.vmware.com/docs/6900/vcloud-director-api-for-nsx-programming-guide
+type EdgeSslVpnConfig struct {
+	XMLName               xml.Name                   `xml:"sslvpnConfig"`
+	Version               string                     `xml:"version,omitempty"`
+	Enabled               bool                       `xml:"enabled"`
+	Logging               *EdgeSslVpnLogging         `xml:"logging,omitempty"`
+	AdvancedConfig        *EdgeSslVpnAdvancedConfig  `xml:"advancedConfig,omitempty"`
+	ClientConfiguration   *EdgeSslVpnClientConfig    `xml:"clientConfiguration,omitempty"`
+	AuthenticationConfig  *EdgeSslVpnAuthConfig      `xml:"authenticationConfig,omitempty"`
+	ServerSettings        *EdgeSslVpnServerSettings  `xml:"serverSettings,omitempty"`
+	IpAddressPools        *EdgeSslVpnIpAddressPools  `xml:"ipAddressPools,omitempty"`
+	PrivateNetworks       *EdgeSslVpnPrivateNetworks `xml:"privateNetworks,omitempty"`
+	Users                 *EdgeSslVpnUsers           `xml:"users,omitempty"`
+	ClientInstallPackages *EdgeSslVpnInstallPackages `xml:"clientInstallPackages,omitempty"`
+}
+
+// EdgeSslVpnLogging holds SSL VPN-Plus logging settings
+type EdgeSslVpnLogging struct {
+	Enable bool `xml:"enable"`
+	// LogLevel is one of emergency, alert, critical, error, warning, notice, info, debug
+	LogLevel string `xml:"logLevel,omitempty"`
+}
+
+// EdgeSslVpnAdvancedConfig holds advanced SSL VPN-Plus settings
+type EdgeSslVpnAdvancedConfig struct {
+	EnableCompression     bool               `xml:"enableCompression"`
+	ForceVirtualKeyboard  bool               `xml:"forceVirtualKeyboard"`
+	RandomizeVirtualkeys  bool               `xml:"randomizeVirtualkeys"`
+	PreventMultipleLogon  bool               `xml:"preventMultipleLogon"`
+	ClientNotification    string             `xml:"clientNotification,omitempty"`
+	EnablePublicUrlAccess bool               `xml:"enablePublicUrlAccess"`
+	Timeout               *EdgeSslVpnTimeout `xml:"timeout,omitempty"`
+}
+
+// EdgeSslVpnTimeout holds SSL VPN-Plus session timeouts in minutes
+type EdgeSslVpnTimeout struct {
+	ForcedTimeout      int `xml:"forcedTimeout,omitempty"`
+	SessionIdleTimeout int `xml:"sessionIdleTimeout,omitempty"`
+}
+
+// EdgeSslVpnClientConfig holds SSL VPN-Plus client behaviour settings
+type EdgeSslVpnClientConfig struct {
+	XMLName             xml.Name `xml:"clientConfiguration"`
+	AutoReconnect       bool     `xml:"autoReconnect"`
+	UpgradeNotification bool     `xml:"upgradeNotification"`
+}
+
+// EdgeSslVpnServerSettings holds SSL VPN-Plus server listener settings
+type EdgeSslVpnServerSettings struct {
+	XMLName xml.Name `xml:"serverSettings"`
+	// ServerAddresses holds IP addresses of edge gateway interfaces the server listens on
+	ServerAddresses *EdgeSslVpnServerAddresses `xml:"serverAddresses,omitempty"`
+	Port            int                        `xml:"port"`
+	// CertificateId is the ID of server certificate. Default self-signed certificate is used when
+	// it is empty.
+	CertificateId string                `xml:"certificateId,omitempty"`
+	CipherList    *EdgeSslVpnCipherList `xml:"cipherList,omitempty"`
+}
+
+// EdgeSslVpnServerAddresses holds a list of IP addresses
+type EdgeSslVpnServerAddresses struct {
+	IpAddress []string `xml:"ipAddress"`
+}
+
+// EdgeSslVpnCipherList holds a list of ciphers (e.g. AES128-SHA, AES256-SHA)
+type EdgeSslVpnCipherList struct {
+	Cipher []string `xml:"cipher"`
+}
+
+// EdgeSslVpnIpAddressPools holds a slice of EdgeSslVpnIpAddressPool
+type EdgeSslVpnIpAddressPools struct {
+	XMLName       xml.Name                  `xml:"ipAddressPools"`
+	IpAddressPool []EdgeSslVpnIpAddressPool `xml:"ipAddressPool"`
+}
+
+// EdgeSslVpnIpAddressPool defines a range of IP addresses assigned to remote users
+type EdgeSslVpnIpAddressPool struct {
+	ObjectId string `xml:"objectId,omitempty"`
+	// IpRange is specified as "10.1.1.10-10.1.1.50"
+	IpRange      string `xml:"ipRange"`
+	Netmask      string `xml:"netmask"`
+	Gateway      string `xml:"gateway"`
+	Description  string `xml:"description,omitempty"`
+	PrimaryDns   string `xml:"primaryDns,omitempty"`
+	SecondaryDns string `xml:"secondaryDns,omitempty"`
+	DnsSuffix    string `xml:"dnsSuffix,omitempty"`
+	WinsServer   string `xml:"winsServer,omitempty"`
+	Enabled      bool   `xml:"enabled"`
+}
+
+// EdgeSslVpnPrivateNetworks holds a slice of EdgeSslVpnPrivateNetwork
+type EdgeSslVpnPrivateNetworks struct {
+	XMLName        xml.Name                   `xml:"privateNetworks"`
+	PrivateNetwork []EdgeSslVpnPrivateNetwork `xml:"privateNetwork"`
+}
+
+// EdgeSslVpnPrivateNetwork defines a private network which remote users can access
+type EdgeSslVpnPrivateNetwork struct {
+	ObjectId    string `xml:"objectId,omitempty"`
+	Description string `xml:"description,omitempty"`
+	// Network in CIDR format
+	Network string `xml:"network"`
+	// SendOverTunnel defines that traffic is sent over SSL VPN tunnel. When it is nil, traffic
+	// bypasses the tunnel.
+	SendOverTunnel *EdgeSslVpnSendOverTunnel `xml:"sendOverTunnel,omitempty"`
+	Enabled        bool                      `xml:"enabled"`
+}
+
+// EdgeSslVpnSendOverTunnel defines tunnel settings for private network traffic
+type EdgeSslVpnSendOverTunnel struct {
+	// Ports is an optional list or range of ports (e.g. "20-40")
+	Ports    string `xml:"ports,omitempty"`
+	Optimize bool   `xml:"optimize"`
+}
+
+// EdgeSslVpnUsers holds a slice of EdgeSslVpnUser
+type EdgeSslVpnUsers struct {
+	XMLName xml.Name         `xml:"users"`
+	User    []EdgeSslVpnUser `xml:"user"`
+}
+
+// EdgeSslVpnUser defines a local SSL VPN-Plus user
+type EdgeSslVpnUser struct {
+	ObjectId             string                         `xml:"objectId,omitempty"`
+	UserId               string                         `xml:"userId"`
+	Password             string                         `xml:"password,omitempty"`
+	FirstName            string                         `xml:"firstName,omitempty"`
+	LastName             string                         `xml:"lastName,omitempty"`
+	Description          string                         `xml:"description,omitempty"`
+	DisableUserAccount   bool                           `xml:"disableUserAccount"`
+	PasswordNeverExpires bool                           `xml:"passwordNeverExpires"`
+	AllowChangePassword  *EdgeSslVpnAllowChangePassword `xml:"allowChangePassword,omitempty"`
+}
+
+// EdgeSslVpnAllowChangePassword defines if a user may or must change password
+type EdgeSslVpnAllowChangePassword struct {
+	ChangePasswordOnNextLogin bool `xml:"changePasswordOnNextLogin"`
+}
+
+// EdgeSslVpnInstallPackages holds a slice of EdgeSslVpnInstallPackage
+type EdgeSslVpnInstallPackages struct {
+	XMLName              xml.Name                   `xml:"clientInstallPackages"`
+	ClientInstallPackage []EdgeSslVpnInstallPackage `xml:"clientInstallPackage"`
+}
+
+// EdgeSslVpnInstallPackage defines SSL VPN-Plus client installation package for remote users
+type EdgeSslVpnInstallPackage struct {
+	ObjectId                            string                 `xml:"objectId,omitempty"`
+	ProfileName                         string                 `xml:"profileName"`
+	GatewayList                         *EdgeSslVpnGatewayList `xml:"gatewayList,omitempty"`
+	StartClientOnLogon                  bool                   `xml:"startClientOnLogon"`
+	HideSystrayIcon                     bool                   `xml:"hideSystrayIcon"`
+	RememberPassword                    bool                   `xml:"rememberPassword"`
+	SilentModeOperation                 bool                   `xml:"silentModeOperation"`
+	SilentModeInstallation              bool                   `xml:"silentModeInstallation"`
+	HideNetworkAdaptor                  bool                   `xml:"hideNetworkAdaptor"`
+	CreateDesktopIcon                   bool                   `xml:"createDesktopIcon"`
+	EnforceServerSecurityCertValidation bool                   `xml:"enforceServerSecurityCertValidation"`
+	CreateLinuxClient                   bool                   `xml:"createLinuxClient"`
+	CreateMacClient                     bool                   `xml:"createMacClient"`
+	Description                         string                 `xml:"description,omitempty"`
+	Enabled                             bool                   `xml:"enabled"`
+}
+
+// EdgeSslVpnGatewayList holds a slice of EdgeSslVpnGateway
+type EdgeSslVpnGatewayList struct {
+	Gateway []EdgeSslVpnGateway `xml:"gateway"`
+}
+
+// EdgeSslVpnGateway defines a gateway which SSL VPN-Plus client connects to
+type EdgeSslVpnGateway struct {
+	HostName string `xml:"hostName"`
+	Port     int    `xml:"port,omitempty"`
+}
+
+// EdgeSslVpnAuthConfig holds SSL VPN-Plus authentication configuration
+type EdgeSslVpnAuthConfig struct {
+	XMLName                xml.Name                          `xml:"authenticationConfig"`
+	PasswordAuthentication *EdgeSslVpnPasswordAuthentication `xml:"passwordAuthentication,omitempty"`
+}
+
+// EdgeSslVpnPasswordAuthentication holds primary and secondary authentication servers
+type EdgeSslVpnPasswordAuthentication struct {
+	// AuthenticationTimeout in minutes
+	AuthenticationTimeout int                    `xml:"authenticationTimeout,omitempty"`
+	PrimaryAuthServers    *EdgeSslVpnAuthServers `xml:"primaryAuthServers,omitempty"`
+	SecondaryAuthServer   *EdgeSslVpnAuthServers `xml:"secondaryAuthServer,omitempty"`
+}
+
+// EdgeSslVpnAuthServers holds authentication servers of different kinds. Each server kind is
+// represented by its own XML element.
+type EdgeSslVpnAuthServers struct {
+	LocalAuthServer   *EdgeSslVpnLocalAuthServer   `xml:"com.vmware.vshield.edge.sslvpn.dto.LocalAuthServerDto,omitempty"`
+	LdapAuthServers   []EdgeSslVpnLdapAuthServer   `xml:"com.vmware.vshield.edge.sslvpn.dto.LdapAuthServerDto,omitempty"`
+	AdAuthServers     []EdgeSslVpnLdapAuthServer   `xml:"com.vmware.vshield.edge.sslvpn.dto.AdAuthServerDto,omitempty"`
+	RadiusAuthServers []EdgeSslVpnRadiusAuthServer `xml:"com.vmware.vshield.edge.sslvpn.dto.RadiusAuthServerDto,omitempty"`
+	RsaAuthServer     *EdgeSslVpnRsaAuthServer     `xml:"com.vmware.vshield.edge.sslvpn.dto.RsaAuthServerDto,omitempty"`
+}
+
+// EdgeSslVpnLocalAuthServer defines local authentication server with password and lockout policies
+type EdgeSslVpnLocalAuthServer struct {
+	Enabled              bool                            `xml:"enabled"`
+	PasswordPolicy       *EdgeSslVpnPasswordPolicy       `xml:"passwordPolicy,omitempty"`
+	AccountLockoutPolicy *EdgeSslVpnAccountLockoutPolicy `xml:"accountLockoutPolicy,omitempty"`
+}
+
+// EdgeSslVpnPasswordPolicy defines password requirements for local users
+type EdgeSslVpnPasswordPolicy struct {
+	MinLength                 int  `xml:"minLength,omitempty"`
+	MaxLength                 int  `xml:"maxLength,omitempty"`
+	MinAlphabets              int  `xml:"minAlphabets,omitempty"`
+	MinDigits                 int  `xml:"minDigits,omitempty"`
+	MinSpecialChar            int  `xml:"minSpecialChar,omitempty"`
+	AllowUserIdWithinPassword bool `xml:"allowUserIdWithinPassword"`
+	// PasswordLifeTime in days
+	PasswordLifeTime int `xml:"passwordLifeTime,omitempty"`
+	// ExpiryNotification in days before password expires
+	ExpiryNotification int `xml:"expiryNotification,omitempty"`
+}
+
+// EdgeSslVpnAccountLockoutPolicy defines when local user accounts are locked
+type EdgeSslVpnAccountLockoutPolicy struct {
+	RetryCount int `xml:"retryCount,omitempty"`
+	// RetryDuration and LockoutDuration in minutes
+	RetryDuration   int `xml:"retryDuration,omitempty"`
+	LockoutDuration int `xml:"lockoutDuration,omitempty"`
+}
+
+// EdgeSslVpnLdapAuthServer defines LDAP or Active Directory authentication server
+type EdgeSslVpnLdapAuthServer struct {
+	Ip                 string `xml:"ip"`
+	Port               int    `xml:"port,omitempty"`
+	TimeOut            int    `xml:"timeOut,omitempty"`
+	EnableSsl          bool   `xml:"enableSsl"`
+	SearchBase         string `xml:"searchBase,omitempty"`
+	BindDomainName     string `xml:"bindDomainName,omitempty"`
+	BindPassword       string `xml:"bindPassword,omitempty"`
+	LoginAttributeName string `xml:"loginAttributeName,omitempty"`
+	SearchFilter       string `xml:"searchFilter,omitempty"`
+	Enabled            bool   `xml:"enabled"`
+}
+
+// EdgeSslVpnRadiusAuthServer defines RADIUS authentication server
+type EdgeSslVpnRadiusAuthServer struct {
+	Ip         string `xml:"ip"`
+	Port       int    `xml:"port,omitempty"`
+	TimeOut    int    `xml:"timeOut,omitempty"`
+	Secret     string `xml:"secret,omitempty"`
+	NasIp      string `xml:"nasIp,omitempty"`
+	RetryCount int    `xml:"retryCount,omitempty"`
+	Enabled    bool   `xml:"enabled"`
+}
+
+// EdgeSslVpnRsaAuthServer defines RSA SecurID authentication server
+type EdgeSslVpnRsaAuthServer struct {
+	TimeOut  int    `xml:"timeOut,omitempty"`
+	SourceIp string `xml:"sourceIp,omitempty"`
+	Enabled  bool   `xml:"enabled"`
+}
+
+// EdgeL2Vpn holds L2 VPN configuration of NSX-V edge gateway. L2 VPN allows to stretch layer 2
+// networks across sites. An edge gateway acts either as L2 VPN server or as L2 VPN client.
+// https://code.vmware.com/docs/6900/vcloud-director-api-for-nsx-programming-guide
+type EdgeL2Vpn struct {
+	XMLName    xml.Name           `xml:"l2Vpn"`
+	Version    string             `xml:"version,omitempty"`
+	Enabled    bool               `xml:"enabled"`
+	Logging    *EdgeSslVpnLogging `xml:"logging,omitempty"`
+	L2VpnSites *EdgeL2VpnSites    `xml:"l2VpnSites,omitempty"`
+}
+
+// EdgeL2VpnSites holds a slice of EdgeL2VpnSite
+type EdgeL2VpnSites struct {
+	L2VpnSite []EdgeL2VpnSite `xml:"l2VpnSite"`
+}
+
+// EdgeL2VpnSite holds either server or client configuration
+type EdgeL2VpnSite struct {
+	Server *EdgeL2VpnServer `xml:"server,omitempty"`
+	Client *EdgeL2VpnClient `xml:"client,omitempty"`
+}
+
+// EdgeL2VpnServer defines L2 VPN server settings and peer sites connecting to it
+type EdgeL2VpnServer struct {
+	ListenerIp          string              `xml:"listenerIp"`
+	ListenerPort        int                 `xml:"listenerPort,omitempty"`
+	EncryptionAlgorithm string              `xml:"encryptionAlgorithm,omitempty"`
+	ServerCertificate   string              `xml:"serverCertificate,omitempty"`
+	PeerSites           *EdgeL2VpnPeerSites `xml:"peerSites,omitempty"`
+}
+
+// EdgeL2VpnPeerSites holds a slice of EdgeL2VpnPeerSite
+type EdgeL2VpnPeerSites struct {
+	PeerSite []EdgeL2VpnPeerSite `xml:"peerSite"`
+}
+
+// EdgeL2VpnPeerSite defines a single L2 VPN client site connecting to the server
+type EdgeL2VpnPeerSite struct {
+	Name        string         `xml:"name"`
+	Description string         `xml:"description,omitempty"`
+	L2VpnUser   *EdgeL2VpnUser `xml:"l2VpnUser,omitempty"`
+	// Vnics lists indexes of trunk interfaces (sub-interfaces) stretched to this site
+	Vnics              *EdgeL2VpnVnics              `xml:"vnics,omitempty"`
+	EgressOptimization *EdgeL2VpnEgressOptimization `xml:"egressOptimization,omitempty"`
+	Enabled            bool                         `xml:"enabled"`
+}
+
+// EdgeL2VpnVnics holds a list of vNic indexes
+type EdgeL2VpnVnics struct {
+	Index []int `xml:"index"`
+}
+
+// EdgeL2VpnEgressOptimization holds gateway IP addresses for local egress traffic
+type EdgeL2VpnEgressOptimization struct {
+	GatewayIpAddress []string `xml:"gatewayIpAddress"`
+}
+
+// EdgeL2VpnUser holds L2 VPN credentials
+type EdgeL2VpnUser struct {
+	UserId   string `xml:"userId"`
+	Password string `xml:"password,omitempty"`
+}
+
+// EdgeL2VpnClient defines L2 VPN client settings
+type EdgeL2VpnClient struct {
+	Configuration *EdgeL2VpnClientConfiguration `xml:"configuration,omitempty"`
+	ProxySetting  *EdgeL2VpnProxySetting        `xml:"proxySetting,omitempty"`
+	L2VpnUser     *EdgeL2VpnUser                `xml:"l2VpnUser,omitempty"`
+}
+
+// EdgeL2VpnClientConfiguration defines L2 VPN server which the client connects to
+type EdgeL2VpnClientConfiguration struct {
+	ServerAddress       string                       `xml:"serverAddress"`
+	ServerPort          int                          `xml:"serverPort,omitempty"`
+	Vnic                *int                         `xml:"vnic,omitempty"`
+	EncryptionAlgorithm string                       `xml:"encryptionAlgorithm,omitempty"`
+	CaCertificate       string                       `xml:"caCertificate,omitempty"`
+	EgressOptimization  *EdgeL2VpnEgressOptimization `xml:"egressOptimization,omitempty"`
+}
+
+// EdgeL2VpnProxySetting defines optional HTTPS proxy for L2 VPN client
+type EdgeL2VpnProxySetting struct {
+	Type     string `xml:"type,omitempty"`
+	Address  string `xml:"address,omitempty"`
+	Port     int    `xml:"port,omitempty"`
+	UserName string `xml:"userName,omitempty"`
+	Password string `xml:"password,omitempty"`
+}
+
+// EdgeDhcpConfig holds DHCP service configuration of NSX-V edge gateway (IP pools and static
+// bindings) as returned by proxied NSX-V API
+// https://code.vmware.com/docs/6900/vcloud-director-api-for-nsx-programming-guide
+type EdgeDhcpConfig struct {
+	XMLName        xml.Name                `xml:"dhcp"`
+	Version        string                  `xml:"version,omitempty"`
+	Enabled        bool                    `xml:"enabled"`
+	StaticBindings *EdgeDhcpStaticBindings `xml:"staticBindings,omitempty"`
+	IpPools        *EdgeDhcpIpPools        `xml:"ipPools,omitempty"`
+	Logging        *EdgeRoutingLogging     `xml:"logging,omitempty"`
+}
+
+// EdgeDhcpIpPools holds a slice of EdgeDhcpIpPool
+type EdgeDhcpIpPools struct {
+	IpPool []EdgeDhcpIpPool `xml:"ipPool"`
+}
+
+// EdgeDhcpIpPool defines a range of IP addresses leased by DHCP service together with options
+// sent to clients
+type EdgeDhcpIpPool struct {
+	PoolId string `xml:"poolId,omitempty"`
+	// IpRange is specified as "10.10.10.100-10.10.10.200"
+	IpRange             string `xml:"ipRange"`
+	DefaultGateway      string `xml:"defaultGateway,omitempty"`
+	SubnetMask          string `xml:"subnetMask,omitempty"`
+	DomainName          string `xml:"domainName,omitempty"`
+	PrimaryNameServer   string `xml:"primaryNameServer,omitempty"`
+	SecondaryNameServer string `xml:"secondaryNameServer,omitempty"`
+	// AutoConfigureDNS uses DNS settings of edge gateway instead of PrimaryNameServer and
+	// SecondaryNameServer
+	AutoConfigureDNS bool `xml:"autoConfigureDNS"`
+	// LeaseTime in seconds or "infinite"
+	LeaseTime      string `xml:"leaseTime,omitempty"`
+	AllowHugeRange bool   `xml:"allowHugeRange"`
+}
+
+// EdgeDhcpStaticBindings holds a slice of EdgeDhcpStaticBinding
+type EdgeDhcpStaticBindings struct {
+	StaticBinding []EdgeDhcpStaticBinding `xml:"staticBinding"`
+}
+
+// EdgeDhcpStaticBinding reserves an IP address for a particular MAC address or VM network adapter
+type EdgeDhcpStaticBinding struct {
+	XMLName   xml.Name `xml:"staticBinding"`
+	BindingId string   `xml:"bindingId,omitempty"`
+	// MacAddress identifies the client. Either MacAddress or VmId with VnicId must be set.
+	MacAddress string `xml:"macAddress,omitempty"`
+	// VmId is the NSX ID of VM (e.g. "vm-34") and VnicId is the index of its network adapter
+	VmId                string `xml:"vmId,omitempty"`
+	VnicId              *int   `xml:"vnicId,omitempty"`
+	Hostname            string `xml:"hostname,omitempty"`
+	IpAddress           string `xml:"ipAddress"`
+	DefaultGateway      string `xml:"defaultGateway,omitempty"`
+	SubnetMask          string `xml:"subnetMask,omitempty"`
+	DomainName          string `xml:"domainName,omitempty"`
+	PrimaryNameServer   string `xml:"primaryNameServer,omitempty"`
+	SecondaryNameServer string `xml:"secondaryNameServer,omitempty"`
+	AutoConfigureDNS    bool   `xml:"autoConfigureDNS"`
+	// LeaseTime in seconds or "infinite"
+	LeaseTime string `xml:"leaseTime,omitempty"`
+}
+
+// EdgeDnsConfig holds DNS forwarder (relay) configuration of NSX-V edge gateway. When enabled, the
+// edge gateway forwards DNS queries from clients to upstream DNS servers and caches responses.
+// https://code.vmware.com/docs/6900/vcloud-director-api-for-nsx-programming-guide
+type EdgeDnsConfig struct {
+	XMLName xml.Name `xml:"dns"`
+	Version string   `xml:"version,omitempty"`
+	Enabled bool     `xml:"enabled"`
+	// CacheSize in MB
+	CacheSize int `xml:"cacheSize,omitempty"`
+	// Listeners lists vNics on which DNS service listens ("any" or vNic indexes)
+	Listeners *EdgeDnsListeners `xml:"listeners,omitempty"`
+	// DnsServers are upstream DNS servers which queries are forwarded to
+	DnsServers *EdgeDnsServers     `xml:"dnsServers,omitempty"`
+	Logging    *EdgeRoutingLogging `xml:"logging,omitempty"`
+}
+
+// EdgeDnsListeners holds a list of vNics
+type EdgeDnsListeners struct {
+	Vnic []string `xml:"vnic"`
+}
+
+// EdgeDnsServers holds a list of upstream DNS server IP addresses
+type EdgeDnsServers struct {
+	IpAddress []string `xml:"ipAddress"`
+}
@@ -51,6 +51,57 @@ type Role struct {
 	ReadOnly    bool   `json:"readOnly"`
 }
 
+// Right defines a right which can be granted to roles, global roles and rights bundles
+type Right struct {
+	ID               string             `json:"id,omitempty"`
+	Name             string             `json:"name"`
+	Description      string             `json:"description,omitempty"`
+	BundleKey        string             `json:"bundleKey,omitempty"`
+	Category         string             `json:"category,omitempty"`         // ID of the RightsCategory
+	ServiceNamespace string             `json:"serviceNamespace,omitempty"` // Namespace of the service which defines the right
+	RightType        string             `json:"rightType,omitempty"`        // VIEW or MODIFY
+	ImpliedRights    []OpenApiReference `json:"impliedRights,omitempty"`    // Rights which are granted along with this one
+}
+
+// RightsCategory defines a category of rights (e.g. "vApp" or "Catalog")
+type RightsCategory struct {
+	ID            string   `json:"id,omitempty"`
+	Name          string   `json:"name"`
+	BundleKey     string   `json:"bundleKey,omitempty"`
+	Parent        string   `json:"parent,omitempty"` // ID of the parent category
+	SubCategories []string `json:"subCategories,omitempty"`
+	RightsCount   struct {
+		View   int `json:"view"`
+		Modify int `json:"modify"`
+	} `json:"rightsCount"`
+}
+
+// GlobalRole is a provider defined role which can be published to tenants
+type GlobalRole struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	BundleKey   string `json:"bundleKey"`
+	ReadOnly    bool   `json:"readOnly"`
+	PublishAll  *bool  `json:"publishAll,omitempty"` // True if the global role is published to all tenants
+}
+
+// RightsBundle is a set of rights which can be published to tenants. A tenant can only use the
+// rights included in the bundles published to it.
+type RightsBundle struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	BundleKey   string `json:"bundleKey"`
+	ReadOnly    bool   `json:"readOnly"`
+	PublishAll  *bool  `json:"publishAll,omitempty"` // True if the rights bundle is published to all tenants
+}
+
+// OpenApiItems is a list of references used as payload to add rights or to publish to tenants
+type OpenApiItems struct {
+	Values []OpenApiReference `json:"values"`
+}
+
 // NsxtTier0Router defines NSX-T Tier 0 router
 type NsxtTier0Router struct {
 	ID          string `json:"id,omitempty"`
@@ -19,6 +19,9 @@ type VcdSamlMetadata struct {
 	ID      string   `xml:"ID,attr"`
 	// EntityID is the configured vCD Entity ID which is used in ADFS authentication request
 	EntityID string `xml:"entityID,attr"`
+	// Raw holds the complete metadata document, as returned by vCD. It is only filled in by
+	// AdminOrg.GetSamlMetadata
+	Raw string `xml:"-"`
 }
 
 // AdfsAuthErrorEnvelope helps to parse ADFS authentication error with help of Error() method
@@ -88,6 +88,19 @@ type LeaseSettingsSection struct {
 	StorageLeaseInSeconds     int    `xml:"StorageLeaseInSeconds,omitempty"`
 }
 
+// UpdateLeaseSettingsSection is the payload used to update lease settings of a vApp or vApp template.
+// Lease values are pointers so that 0 (never expires) can be sent explicitly.
+type UpdateLeaseSettingsSection struct {
+	XMLName                  xml.Name `xml:"LeaseSettingsSection"`
+	XmlnsOvf                 string   `xml:"xmlns:ovf,attr,omitempty"`
+	Xmlns                    string   `xml:"xmlns,attr"`
+	OVFInfo                  string   `xml:"ovf:Info"`
+	HREF                     string   `xml:"href,attr,omitempty"`
+	Type                     string   `xml:"type,attr,omitempty"`
+	DeploymentLeaseInSeconds *int     `xml:"DeploymentLeaseInSeconds,omitempty"`
+	StorageLeaseInSeconds    *int     `xml:"StorageLeaseInSeconds,omitempty"`
+}
+
 // IPRange represents a range of IP addresses, start and end inclusive.
 // Type: IpRangeType
 // Namespace: http://www.vmware.com/vcloud/v1.5
@@ -343,7 +356,7 @@ type InstantiationParams struct {
 	LeaseSettingsSection         *LeaseSettingsSection         `xml:"LeaseSettingsSection,omitempty"`
 	NetworkConfigSection         *NetworkConfigSection         `xml:"NetworkConfigSection,omitempty"`
 	NetworkConnectionSection     *NetworkConnectionSection     `xml:"NetworkConnectionSection,omitempty"`
-	ProductSection               *ProductSection               `xml:"ProductSection,omitempty"`
+	ProductSection               *ProductSection               `xml:"http://schemas.dmtf.org/ovf/envelope/1 ProductSection,omitempty"`
 	// TODO: Not Implemented
 	// SnapshotSection              SnapshotSection              `xml:"SnapshotSection,omitempty"`
 }
@@ -799,12 +812,35 @@ type VAppLeaseSettings struct {
 	PowerOffOnRuntimeLeaseExpiration *bool `xml:"PowerOffOnRuntimeLeaseExpiration,omitempty"`
 }
 
+// OrgFederationSettings represents the SAML federation settings of an organization.
+// Type: OrgFederationSettingsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Note. Order of these fields matter and API will error if it is changed
 type OrgFederationSettings struct {
-	HREF string   `xml:"href,attr,omitempty"` // The URI of the entity.
-	Type string   `xml:"type,attr,omitempty"` // The MIME type of the entity.
-	Link LinkList `xml:"Link,omitempty"`      // A reference to an entity or operation associated with this object.
+	XMLName xml.Name `xml:"OrgFederationSettings"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	HREF    string   `xml:"href,attr,omitempty"` // The URI of the entity.
+	Type    string   `xml:"type,attr,omitempty"` // The MIME type of the entity.
+	Link    LinkList `xml:"Link,omitempty"`      // A reference to an entity or operation associated with this object.
 
-	Enabled bool `xml:"Enabled,omitempty"`
+	SAMLMetadata         string                `xml:"SAMLMetadata,omitempty"`         // Metadata XML document of the SAML identity provider
+	Enabled              bool                  `xml:"Enabled"`                        // True if SAML authentication is enabled for the organization
+	SamlAttributeMapping *SamlAttributeMapping `xml:"SamlAttributeMapping,omitempty"` // Names of the SAML attributes mapped to user properties
+	SamlSPEntityId       string                `xml:"SamlSPEntityId,omitempty"`       // Entity ID of the organization as service provider
+}
+
+// SamlAttributeMapping defines the names of the SAML assertion attributes used to fill in the
+// properties of imported users and groups.
+// Type: SamlAttributeMappingType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+type SamlAttributeMapping struct {
+	EmailAttributeName     string `xml:"EmailAttributeName,omitempty"`
+	UserNameAttributeName  string `xml:"UserNameAttributeName,omitempty"`
+	FirstNameAttributeName string `xml:"FirstNameAttributeName,omitempty"`
+	SurnameAttributeName   string `xml:"SurnameAttributeName,omitempty"`
+	FullNameAttributeName  string `xml:"FullNameAttributeName,omitempty"`
+	GroupAttributeName     string `xml:"GroupAttributeName,omitempty"`
+	RoleAttributeName      string `xml:"RoleAttributeName,omitempty"`
 }
 
 // OrgLdapSettingsType represents the ldap settings for a vCloud Director organization.
@@ -1003,11 +1039,13 @@ type AdminCatalog struct {
 // Description: Represents the configuration parameters of a catalog published externally.
 // Since: 5.5
 type PublishExternalCatalogParams struct {
-	IsCachedEnabled          bool   `xml:"IsCacheEnabled,omitempty"`
-	IsPublishedExternally    bool   `xml:"IsPublishedExternally,omitempty"`
-	Password                 string `xml:"Password,omitempty"`
-	PreserveIdentityInfoFlag bool   `xml:"PreserveIdentityInfoFlag,omitempty"`
-	CatalogPublishedUrl      string `xml:"catalogPublishedUrl,omitempty"`
+	XMLName                  xml.Name `xml:"PublishExternalCatalogParams"`
+	Xmlns                    string   `xml:"xmlns,attr,omitempty"`
+	IsPublishedExternally    bool     `xml:"IsPublishedExternally,omitempty"`
+	CatalogPublishedUrl      string   `xml:"catalogPublishedUrl,omitempty"`
+	Password                 string   `xml:"Password,omitempty"`
+	IsCachedEnabled          bool     `xml:"IsCacheEnabled,omitempty"`
+	PreserveIdentityInfoFlag bool     `xml:"PreserveIdentityInfoFlag,omitempty"`
 }
 
 // ExternalCatalogSubscription represents the configuration parameters for a catalog that has an external subscription
@@ -1016,11 +1054,13 @@ type PublishExternalCatalogParams struct {
 // Description: Represents the configuration parameters for a catalog that has an external subscription.
 // Since: 5.5
 type ExternalCatalogSubscription struct {
-	ExpectedSslThumbprint    bool   `xml:"ExpectedSslThumbprint,omitempty"`
-	LocalCopy                bool   `xml:"LocalCopy,omitempty"`
-	Password                 string `xml:"Password,omitempty"`
-	SubscribeToExternalFeeds bool   `xml:"SubscribeToExternalFeeds,omitempty"`
-	Location                 string `xml:"Location,omitempty"`
+	XMLName                  xml.Name `xml:"ExternalCatalogSubscriptionParams"`
+	Xmlns                    string   `xml:"xmlns,attr,omitempty"`
+	SubscribeToExternalFeeds bool     `xml:"SubscribeToExternalFeeds,omitempty"`
+	Location                 string   `xml:"Location,omitempty"`
+	Password                 string   `xml:"Password,omitempty"`
+	LocalCopy                bool     `xml:"LocalCopy,omitempty"`
+	ExpectedSslThumbprint    bool     `xml:"ExpectedSslThumbprint,omitempty"`
 }
 
 // CatalogStorageProfiles represents a container for storage profiles used by this catalog
@@ -1302,7 +1342,9 @@ type Property struct {
 	DefaultValue     string `xml:"http://schemas.dmtf.org/ovf/envelope/1 value,attr"`
 	Value            *Value `xml:"http://schemas.dmtf.org/ovf/envelope/1 Value,omitempty"`
 	Type             string `xml:"http://schemas.dmtf.org/ovf/envelope/1 type,attr,omitempty"`
+	Qualifiers       string `xml:"http://schemas.dmtf.org/ovf/envelope/1 qualifiers,attr,omitempty"` // Additional constraints such as MinLen(1), MaxLen(64) or ValueMap{"a","b"}
 	UserConfigurable bool   `xml:"http://schemas.dmtf.org/ovf/envelope/1 userConfigurable,attr"`
+	Password         bool   `xml:"http://schemas.dmtf.org/ovf/envelope/1 password,attr,omitempty"` // True if the value should be masked when displayed
 }
 
 type Value struct {
@@ -1610,6 +1652,31 @@ type GuestCustomizationStatusSection struct {
 	GuestCustStatus string `xml:"GuestCustStatus"`
 }
 
+// MksTicket contains the information needed to open a remote console connection to a VM
+// Type: MksTicketType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// https://code.vmware.com/apis/1046/vmware-cloud-director/doc/doc/types/MksTicketType.html
+type MksTicket struct {
+	XMLName xml.Name `xml:"MksTicket"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+
+	Host   string `xml:"Host"`   // The host to connect to
+	Vmx    string `xml:"Vmx"`    // The path to the VMX file of the VM
+	Ticket string `xml:"Ticket"` // The ticket to use for authentication
+	Port   int    `xml:"Port"`   // The port to connect to
+}
+
+// ScreenTicket contains a screen ticket in the form of an MKS URL
+// (mks://<host>/<vmx path>?ticket=<ticket>)
+// Type: ScreenTicketType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+type ScreenTicket struct {
+	XMLName xml.Name `xml:"ScreenTicket"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+
+	Value string `xml:",chardata"`
+}
+
 // GuestCustomizationSection represents guest customization settings
 // Type: GuestCustomizationSectionType
 // Namespace: http://www.vmware.com/vcloud/v1.5
@@ -1672,6 +1739,77 @@ type InstantiateVAppTemplateParams struct {
 	AllEULAsAccepted    bool                         `xml:"AllEULAsAccepted,omitempty"`    // True confirms acceptance of all EULAs in a vApp template. Instantiation fails if this element is missing, empty, or set to false and one or more EulaSection elements are present.
 }
 
+// CaptureVAppParams represents parameters for a request to capture a vApp into a catalog as a
+// vApp template
+// Type: CaptureVAppParamsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: Parameters for a captureVApp request.
+// Since: 0.9
+type CaptureVAppParams struct {
+	XMLName xml.Name `xml:"CaptureVAppParams"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Ovf     string   `xml:"xmlns:ovf,attr"`
+	// Attributes
+	Name string `xml:"name,attr"` // Name of the vApp template
+	// Elements
+	Description          string                `xml:"Description,omitempty"`          // Optional description
+	Source               *Reference            `xml:"Source"`                         // Reference to the vApp to capture
+	CustomizationSection *CustomizationSection `xml:"CustomizationSection,omitempty"` // Customization settings of the vApp template
+	TargetCatalogItem    *Reference            `xml:"TargetCatalogItem,omitempty"`    // Existing catalog item to overwrite. Since API 33.0
+}
+
+// CopyOrMoveCatalogItemParams represents parameters for a request to copy or move an item into a
+// catalog
+// Type: CopyOrMoveCatalogItemParamsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: Parameters for a copy or move catalog item request.
+// Since: 5.5
+type CopyOrMoveCatalogItemParams struct {
+	XMLName xml.Name `xml:"CopyOrMoveCatalogItemParams"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	// Attributes
+	Name string `xml:"name,attr"` // Name of the catalog item in the destination catalog
+	// Elements
+	Description string     `xml:"Description,omitempty"` // Optional description
+	Source      *Reference `xml:"Source"`                // Reference to the catalog item to copy or move
+}
+
+// CloneVAppParams represents parameters for copying a vApp and optionally deleting the source
+// Type: CloneVAppParamsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: Parameters for a cloneVApp request.
+// Since: 0.9
+type CloneVAppParams struct {
+	XMLName xml.Name `xml:"CloneVAppParams"`
+	Ovf     string   `xml:"xmlns:ovf,attr"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	// Attributes
+	Name        string `xml:"name,attr,omitempty"`        // Name of the new vApp
+	Deploy      bool   `xml:"deploy,attr"`                // True if the vApp should be deployed after cloning
+	PowerOn     bool   `xml:"powerOn,attr"`               // True if the vApp should be powered-on after cloning
+	LinkedClone bool   `xml:"linkedClone,attr,omitempty"` // True if VMs should be created as linked clones
+	// Elements
+	Description         string               `xml:"Description,omitempty"`         // Optional description
+	InstantiationParams *InstantiationParams `xml:"InstantiationParams,omitempty"` // Instantiation parameters for the new vApp
+	Source              *Reference           `xml:"Source"`                        // Reference to the source vApp
+	IsSourceDelete      bool                 `xml:"IsSourceDelete,omitempty"`      // True if the source vApp should be deleted after cloning
+}
+
+// MoveVAppParams represents parameters for moving a vApp to another VDC
+// Type: MoveVAppParamsType
+// Namespace: http://www.vmware.com/vcloud/v1.5
+// Description: Parameters for a moveVApp request.
+// Since: 33.0
+type MoveVAppParams struct {
+	XMLName xml.Name `xml:"MoveVAppParams"`
+	Ovf     string   `xml:"xmlns:ovf,attr"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	// Elements
+	Source               *Reference                     `xml:"Source"`                         // Reference to the vApp being moved
+	NetworkConfigSection *NetworkConfigSection          `xml:"NetworkConfigSection,omitempty"` // vApp networks in the target VDC
+	SourcedItem          []*SourcedCompositionItemParam `xml:"SourcedItem,omitempty"`          // Placement parameters (e.g. storage profile) for each VM
+}
+
 // EdgeGateway represents a gateway.
 // Element: EdgeGateway
 // Type: GatewayType
@@ -2300,6 +2438,8 @@ type QueryResultVAppRecordType struct {
 	MemoryAllocationMB      int       `xml:"memoryAllocationMB,attr,omitempty"`
 	AutoDeleteNotified      bool      `xml:"isAutoDeleteNotified,attr,omitempty"`
 	AutoUndeployNotified    bool      `xml:"isAutoUndeployNotified,attr,omitempty"`
+	AutoDeleteDate          string    `xml:"autoDeleteDate,attr,omitempty"`   // Date when the vApp storage lease expires
+	AutoUndeployDate        string    `xml:"autoUndeployDate,attr,omitempty"` // Date when the vApp runtime lease expires
 	VdcEnabled              bool      `xml:"isVdcEnabled,attr,omitempty"`
 	HonorBootOrder          bool      `xml:"honorBookOrder,attr,omitempty"`
 	HighestSupportedVersion int       `xml:"pvdcHighestSupportedHardwareVersion,attr,omitempty"`
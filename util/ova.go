@@ -0,0 +1,323 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package util
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/ovf"
+)
+
+// signatureLine matches the signature of the manifest in a certificate file, such as
+// "SHA256(package.mf)= 0123abcd"
+var signatureLine = regexp.MustCompile(`^(SHA1|SHA256|SHA512)\((.+)\)\s*=\s*([0-9a-fA-F]+)$`)
+
+// VerifyOva checks the integrity of an OVA package without extracting it. Every file of the package
+// must be listed in its manifest (.mf) with a matching SHA1, SHA256 or SHA512 digest, and every file
+// listed in the manifest must be in the package.
+// When trustPool is not nil, the package must also be signed: the signature of the manifest found
+// in the certificate file (.cert) is checked, and the signing certificate must be valid and chain
+// to one of the certificates of trustPool.
+func VerifyOva(path string, trustPool *x509.CertPool) error {
+	var manifestName, certificateName string
+	var manifestContent, certificateContent []byte
+	err := walkOva(path, func(name string, reader io.Reader) error {
+		var err error
+		switch filepath.Ext(name) {
+		case ".mf":
+			if manifestName != "" {
+				return fmt.Errorf("OVA %s contains more than one manifest", path)
+			}
+			manifestName = name
+			manifestContent, err = ioutil.ReadAll(reader)
+		case ".cert":
+			if certificateName != "" {
+				return fmt.Errorf("OVA %s contains more than one certificate file", path)
+			}
+			certificateName = name
+			certificateContent, err = ioutil.ReadAll(reader)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if manifestName == "" {
+		return fmt.Errorf("OVA %s does not contain a manifest", path)
+	}
+	manifest, err := ovf.ParseManifest(bytes.NewReader(manifestContent))
+	if err != nil {
+		return fmt.Errorf("error reading manifest of OVA %s: %s", path, err)
+	}
+
+	if trustPool != nil {
+		if certificateName == "" {
+			return fmt.Errorf("OVA %s is not signed", path)
+		}
+		err = verifyManifestSignature(manifestName, manifestContent, certificateContent, trustPool)
+		if err != nil {
+			return fmt.Errorf("signature of OVA %s is not valid: %s", path, err)
+		}
+	}
+
+	verified := make(map[string]bool)
+	err = walkOva(path, func(name string, reader io.Reader) error {
+		if name == manifestName || name == certificateName {
+			return nil
+		}
+		verified[name] = true
+		return manifest.Verify(name, reader)
+	})
+	if err != nil {
+		return fmt.Errorf("OVA %s failed verification: %s", path, err)
+	}
+	return checkManifestComplete(manifest, verified, path)
+}
+
+// VerifyUnpackedOva checks the files of an OVA package extracted by Unpack against the manifest of
+// the package. filePaths are the extracted files and dir the directory they were extracted to.
+// A package without manifest is accepted, as the manifest is optional in the OVF specification.
+func VerifyUnpackedOva(dir string, filePaths []string) error {
+	return verifyUnpackedOva(dir, filePaths, nil)
+}
+
+// VerifySignedUnpackedOva checks the files of an OVA package extracted by Unpack like
+// VerifyUnpackedOva, but the package must contain a manifest and a certificate file. The signature
+// of the manifest must be made by a certificate which chains to one of the certificates of
+// trustPool. The extracted files are checked, so that they can be uploaded without reading the OVA
+// again.
+func VerifySignedUnpackedOva(dir string, filePaths []string, trustPool *x509.CertPool) error {
+	if trustPool == nil {
+		return fmt.Errorf("a trust pool is required to check the signature of the OVA")
+	}
+	return verifyUnpackedOva(dir, filePaths, trustPool)
+}
+
+// verifyUnpackedOva checks the extracted files of an OVA package against its manifest, and the
+// signature of the manifest when trustPool is not nil
+func verifyUnpackedOva(dir string, filePaths []string, trustPool *x509.CertPool) error {
+	var manifestName, manifestPath, certificatePath string
+	names := make(map[string]string)
+	for _, filePath := range filePaths {
+		name, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		switch filepath.Ext(name) {
+		case ".mf":
+			if manifestPath != "" {
+				return fmt.Errorf("OVA contains more than one manifest")
+			}
+			manifestName, manifestPath = name, filePath
+		case ".cert":
+			if certificatePath != "" {
+				return fmt.Errorf("OVA contains more than one certificate file")
+			}
+			certificatePath = filePath
+		default:
+			names[name] = filePath
+		}
+	}
+
+	if manifestPath == "" {
+		if trustPool != nil {
+			return fmt.Errorf("OVA extracted in %s does not contain a manifest", dir)
+		}
+		Logger.Printf("[TRACE] VerifyUnpackedOva: no manifest found in %s\n", dir)
+		return nil
+	}
+	// The same content is used to check the signature and the files
+	manifestContent, err := ioutil.ReadFile(filepath.Clean(manifestPath))
+	if err != nil {
+		return err
+	}
+	manifest, err := ovf.ParseManifest(bytes.NewReader(manifestContent))
+	if err != nil {
+		return fmt.Errorf("error reading manifest %s: %s", manifestPath, err)
+	}
+
+	if trustPool != nil {
+		if certificatePath == "" {
+			return fmt.Errorf("OVA extracted in %s is not signed", dir)
+		}
+		certificateContent, err := ioutil.ReadFile(filepath.Clean(certificatePath))
+		if err != nil {
+			return err
+		}
+		err = verifyManifestSignature(manifestName, manifestContent, certificateContent, trustPool)
+		if err != nil {
+			return fmt.Errorf("signature of OVA extracted in %s is not valid: %s", dir, err)
+		}
+	}
+
+	verified := make(map[string]bool)
+	for name, filePath := range names {
+		err = verifyFile(manifest, name, filePath)
+		if err != nil {
+			return err
+		}
+		verified[name] = true
+	}
+	return checkManifestComplete(manifest, verified, dir)
+}
+
+// walkOva calls visit with the name and the content of each regular file of an OVA package
+func walkOva(path string, visit func(name string, reader io.Reader) error) error {
+	// #nosec G304 - the path of the package is provided by the caller
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tarReader := tar.NewReader(file)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading OVA %s: %s", path, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		err = visit(sanitizedName(header.Name), tarReader)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// verifyFile checks the content of filePath against the digest of name in the manifest
+func verifyFile(manifest ovf.Manifest, name, filePath string) error {
+	file, err := os.Open(filepath.Clean(filePath))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return manifest.Verify(name, file)
+}
+
+// checkManifestComplete returns an error listing the files of the manifest that were not verified
+func checkManifestComplete(manifest ovf.Manifest, verified map[string]bool, source string) error {
+	var missing []string
+	for name := range manifest {
+		if !verified[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("files listed in the manifest were not found in %s: %s", source, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// verifyManifestSignature checks the content of a certificate file: the signature of the manifest
+// followed by the PEM encoded signing certificate and its intermediate certificates
+func verifyManifestSignature(manifestName string, manifestContent, certificateContent []byte, trustPool *x509.CertPool) error {
+	// The signature line is outside of the PEM blocks, usually at the beginning of the file
+	var signatureAlgorithm, signedName, signature string
+	for _, line := range strings.Split(string(certificateContent), "\n") {
+		matches := signatureLine.FindStringSubmatch(strings.TrimSpace(line))
+		if matches != nil {
+			signatureAlgorithm, signedName, signature = matches[1], matches[2], matches[3]
+			break
+		}
+	}
+
+	var certificates []*x509.Certificate
+	rest := certificateContent
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("error parsing certificate: %s", err)
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	if signature == "" {
+		return fmt.Errorf("signature of the manifest not found in the certificate file")
+	}
+	if signedName != manifestName {
+		return fmt.Errorf("the certificate file signs %s instead of the manifest %s", signedName, manifestName)
+	}
+	if len(certificates) == 0 {
+		return fmt.Errorf("signing certificate not found in the certificate file")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	signer := certificates[0]
+	_, err := signer.Verify(x509.VerifyOptions{
+		Roots:         trustPool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("signing certificate is not trusted: %s", err)
+	}
+
+	algorithm, err := x509SignatureAlgorithm(signatureAlgorithm, signer.PublicKeyAlgorithm)
+	if err != nil {
+		return err
+	}
+	signatureBytes, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("error decoding signature: %s", err)
+	}
+	err = signer.CheckSignature(algorithm, manifestContent, signatureBytes)
+	if err != nil {
+		return fmt.Errorf("signature does not match the manifest %s: %s", manifestName, err)
+	}
+	return nil
+}
+
+// x509SignatureAlgorithm returns the signature algorithm matching a digest of the certificate
+// file and the public key of the signing certificate
+func x509SignatureAlgorithm(digestAlgorithm string, keyAlgorithm x509.PublicKeyAlgorithm) (x509.SignatureAlgorithm, error) {
+	algorithms := map[x509.PublicKeyAlgorithm]map[string]x509.SignatureAlgorithm{
+		x509.RSA: {
+			ovf.DigestSha1:   x509.SHA1WithRSA,
+			ovf.DigestSha256: x509.SHA256WithRSA,
+			ovf.DigestSha512: x509.SHA512WithRSA,
+		},
+		x509.ECDSA: {
+			ovf.DigestSha1:   x509.ECDSAWithSHA1,
+			ovf.DigestSha256: x509.ECDSAWithSHA256,
+			ovf.DigestSha512: x509.ECDSAWithSHA512,
+		},
+	}
+	algorithm, found := algorithms[keyAlgorithm][digestAlgorithm]
+	if !found {
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported signature: %s with %s key", digestAlgorithm, keyAlgorithm)
+	}
+	return algorithm, nil
+}
@@ -0,0 +1,341 @@
+/*
+ * Copyright 2021 VMware, Inc.  All rights reserved.  Licensed under the Apache v2 License.
+ */
+
+package util
+
+import (
+	"archive/tar"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/ovf"
+)
+
+// ovaEntry is a file of an OVA package built by the tests
+type ovaEntry struct {
+	name    string
+	content []byte
+}
+
+// testCertificateAuthority generates a self signed CA certificate and a certificate signed by the CA
+func testCertificateAuthority(t *testing.T) (*x509.Certificate, *x509.Certificate, *rsa.PrivateKey) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating CA key: %s", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caBytes, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("error creating CA certificate: %s", err)
+	}
+	ca, err := x509.ParseCertificate(caBytes)
+	if err != nil {
+		t.Fatalf("error parsing CA certificate: %s", err)
+	}
+
+	signerKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating signer key: %s", err)
+	}
+	signerTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Test signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}
+	signerBytes, err := x509.CreateCertificate(rand.Reader, signerTemplate, ca, &signerKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("error creating signer certificate: %s", err)
+	}
+	signer, err := x509.ParseCertificate(signerBytes)
+	if err != nil {
+		t.Fatalf("error parsing signer certificate: %s", err)
+	}
+	return ca, signer, signerKey
+}
+
+// signManifest returns the content of a certificate file signing the manifest
+func signManifest(t *testing.T, manifestName string, manifest []byte, signer *x509.Certificate, key *rsa.PrivateKey) []byte {
+	digest := sha256.Sum256(manifest)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("error signing manifest: %s", err)
+	}
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: signer.Raw})
+	return append([]byte(fmt.Sprintf("SHA256(%s)= %s\n", manifestName, hex.EncodeToString(signature))), certificate...)
+}
+
+// writeOva writes an OVA package with the given files in dir
+func writeOva(t *testing.T, dir string, entries []ovaEntry) string {
+	path := filepath.Join(dir, "package.ova")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("error creating OVA: %s", err)
+	}
+	defer file.Close()
+
+	writer := tar.NewWriter(file)
+	for _, entry := range entries {
+		err = writer.WriteHeader(&tar.Header{Name: entry.name, Mode: 0600, Size: int64(len(entry.content)), Typeflag: tar.TypeReg})
+		if err != nil {
+			t.Fatalf("error writing OVA header: %s", err)
+		}
+		_, err = writer.Write(entry.content)
+		if err != nil {
+			t.Fatalf("error writing OVA content: %s", err)
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		t.Fatalf("error closing OVA: %s", err)
+	}
+	return path
+}
+
+func TestVerifyOva(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ova-test")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	descriptor := []byte("<Envelope/>")
+	disk := []byte("disk content")
+	manifest := ovf.Manifest{}
+	_ = manifest.Set("package.ovf", descriptor)
+	_ = manifest.Set("package-disk1.vmdk", disk)
+	manifestContent := manifest.Marshal()
+
+	ca, signer, signerKey := testCertificateAuthority(t)
+	trustPool := x509.NewCertPool()
+	trustPool.AddCert(ca)
+	otherCa, _, _ := testCertificateAuthority(t)
+	otherPool := x509.NewCertPool()
+	otherPool.AddCert(otherCa)
+	certificate := signManifest(t, "package.mf", manifestContent, signer, signerKey)
+	tamperedCertificate := signManifest(t, "package.mf", append(manifestContent, '\n'), signer, signerKey)
+
+	tests := []struct {
+		name      string
+		entries   []ovaEntry
+		trustPool *x509.CertPool
+		wantErr   string
+	}{
+		{
+			name:    "Valid",
+			entries: []ovaEntry{{"package.ovf", descriptor}, {"package.mf", manifestContent}, {"package-disk1.vmdk", disk}},
+		},
+		{
+			name: "ValidSigned",
+			entries: []ovaEntry{{"package.ovf", descriptor}, {"package.mf", manifestContent}, {"package.cert", certificate},
+				{"package-disk1.vmdk", disk}},
+			trustPool: trustPool,
+		},
+		{
+			name:    "NoManifest",
+			entries: []ovaEntry{{"package.ovf", descriptor}, {"package-disk1.vmdk", disk}},
+			wantErr: "does not contain a manifest",
+		},
+		{
+			name:    "DigestMismatch",
+			entries: []ovaEntry{{"package.ovf", descriptor}, {"package.mf", manifestContent}, {"package-disk1.vmdk", []byte("other content")}},
+			wantErr: "SHA256 digest of package-disk1.vmdk does not match the manifest",
+		},
+		{
+			name: "FileNotInManifest",
+			entries: []ovaEntry{{"package.ovf", descriptor}, {"package.mf", manifestContent}, {"package-disk1.vmdk", disk},
+				{"package-disk2.vmdk", disk}},
+			wantErr: "package-disk2.vmdk is not listed in the manifest",
+		},
+		{
+			name:    "MissingFile",
+			entries: []ovaEntry{{"package.ovf", descriptor}, {"package.mf", manifestContent}},
+			wantErr: "files listed in the manifest were not found",
+		},
+		{
+			name:      "NotSigned",
+			entries:   []ovaEntry{{"package.ovf", descriptor}, {"package.mf", manifestContent}, {"package-disk1.vmdk", disk}},
+			trustPool: trustPool,
+			wantErr:   "is not signed",
+		},
+		{
+			name: "UntrustedSigner",
+			entries: []ovaEntry{{"package.ovf", descriptor}, {"package.mf", manifestContent}, {"package.cert", certificate},
+				{"package-disk1.vmdk", disk}},
+			trustPool: otherPool,
+			wantErr:   "signing certificate is not trusted",
+		},
+		{
+			name: "SignatureMismatch",
+			entries: []ovaEntry{{"package.ovf", descriptor}, {"package.mf", manifestContent}, {"package.cert", tamperedCertificate},
+				{"package-disk1.vmdk", disk}},
+			trustPool: trustPool,
+			wantErr:   "signature does not match the manifest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyOva(writeOva(t, dir, tt.entries), tt.trustPool)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("VerifyOva() returned error: %s", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("VerifyOva() = %v, want error containing %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyOvaTestResources(t *testing.T) {
+	err := VerifyOva("../test-resources/vapp_with_3_vms.ova", nil)
+	if err != nil {
+		t.Errorf("VerifyOva() returned error: %s", err)
+	}
+	err = VerifyOva("../test-resources/test_vapp_template.ova", nil)
+	if err == nil {
+		t.Errorf("VerifyOva() should fail with an OVA without manifest")
+	}
+}
+
+func TestVerifyUnpackedOva(t *testing.T) {
+	filePaths, dir, err := Unpack("../test-resources/vapp_with_3_vms.ova")
+	defer os.RemoveAll(dir)
+	if err != nil {
+		t.Fatalf("error unpacking OVA: %s", err)
+	}
+	if err = VerifyUnpackedOva(dir, filePaths); err != nil {
+		t.Errorf("VerifyUnpackedOva() returned error: %s", err)
+	}
+
+	var diskPath string
+	for _, filePath := range filePaths {
+		if filepath.Ext(filePath) == ".vmdk" {
+			diskPath = filePath
+		}
+	}
+	err = ioutil.WriteFile(diskPath, []byte("other content"), 0600)
+	if err != nil {
+		t.Fatalf("error writing disk: %s", err)
+	}
+	err = VerifyUnpackedOva(dir, filePaths)
+	if err == nil || !strings.Contains(err.Error(), "does not match the manifest") {
+		t.Errorf("VerifyUnpackedOva() = %v, want digest mismatch", err)
+	}
+
+	// Packages without manifest are accepted
+	filePaths, dir, err = Unpack("../test-resources/test_vapp_template.ova")
+	defer os.RemoveAll(dir)
+	if err != nil {
+		t.Fatalf("error unpacking OVA: %s", err)
+	}
+	if err = VerifyUnpackedOva(dir, filePaths); err != nil {
+		t.Errorf("VerifyUnpackedOva() returned error: %s", err)
+	}
+}
+
+func TestVerifySignedUnpackedOva(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ova-test")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	descriptor := []byte("<Envelope/>")
+	manifest := ovf.Manifest{}
+	_ = manifest.Set("package.ovf", descriptor)
+	manifestContent := manifest.Marshal()
+
+	ca, signer, signerKey := testCertificateAuthority(t)
+	trustPool := x509.NewCertPool()
+	trustPool.AddCert(ca)
+	otherCa, _, _ := testCertificateAuthority(t)
+	otherPool := x509.NewCertPool()
+	otherPool.AddCert(otherCa)
+	certificate := signManifest(t, "package.mf", manifestContent, signer, signerKey)
+
+	tests := []struct {
+		name      string
+		entries   []ovaEntry
+		trustPool *x509.CertPool
+		wantErr   string
+	}{
+		{
+			name:      "ValidSigned",
+			entries:   []ovaEntry{{"package.ovf", descriptor}, {"package.mf", manifestContent}, {"package.cert", certificate}},
+			trustPool: trustPool,
+		},
+		{
+			name:    "NoTrustPool",
+			entries: []ovaEntry{{"package.ovf", descriptor}, {"package.mf", manifestContent}, {"package.cert", certificate}},
+			wantErr: "a trust pool is required",
+		},
+		{
+			name:      "NoManifest",
+			entries:   []ovaEntry{{"package.ovf", descriptor}},
+			trustPool: trustPool,
+			wantErr:   "does not contain a manifest",
+		},
+		{
+			name:      "NotSigned",
+			entries:   []ovaEntry{{"package.ovf", descriptor}, {"package.mf", manifestContent}},
+			trustPool: trustPool,
+			wantErr:   "is not signed",
+		},
+		{
+			name:      "UntrustedSigner",
+			entries:   []ovaEntry{{"package.ovf", descriptor}, {"package.mf", manifestContent}, {"package.cert", certificate}},
+			trustPool: otherPool,
+			wantErr:   "signing certificate is not trusted",
+		},
+		{
+			name: "DigestMismatch",
+			entries: []ovaEntry{{"package.ovf", []byte("<Envelope></Envelope>")}, {"package.mf", manifestContent},
+				{"package.cert", certificate}},
+			trustPool: trustPool,
+			wantErr:   "does not match the manifest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePaths, unpackDir, err := Unpack(writeOva(t, dir, tt.entries))
+			defer os.RemoveAll(unpackDir)
+			if err != nil {
+				t.Fatalf("error unpacking OVA: %s", err)
+			}
+			err = VerifySignedUnpackedOva(unpackDir, filePaths, tt.trustPool)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("VerifySignedUnpackedOva() returned error: %s", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("VerifySignedUnpackedOva() = %v, want error containing %s", err, tt.wantErr)
+			}
+		})
+	}
+}